@@ -7,6 +7,7 @@ import (
 	"hub-service/infrastructure/database/database"
 	"hub-service/infrastructure/database/redis"
 	"hub-service/infrastructure/external/deepl"
+	"hub-service/infrastructure/external/llm"
 	"hub-service/infrastructure/messaging/kafka"
 	"log"
 	"os"
@@ -20,6 +21,7 @@ type AppContext interface {
 	GetDatabase() *database.Database
 	GetTokenProvider() tokenprovider.Provider
 	GetDeeplClient() *deeplgo.Client
+	GetLLMClient() llm.Client
 	GetKafka() *kafka.KafkaClient
 	GetRedis() *redis.RedisClient
 	GetEnv(key string) string
@@ -32,6 +34,7 @@ type appContext struct {
 	db            *database.Database
 	tokenProvider tokenprovider.Provider
 	deeplClient   *deeplgo.Client
+	llmClient     llm.Client
 	kafkaClient   *kafka.KafkaClient
 	googleOAuth   *oauth.GoogleOAuthProvider
 	stateManager  *oauth.StateManager
@@ -40,6 +43,7 @@ type appContext struct {
 func NewAppContext(secretKey string, db *database.Database) *appContext {
 	tokenProvider := jwt.NewProvider(secretKey)
 	deeplClient := deepl.NewClient()
+	llmClient := llm.NewClient()
 	googleOAuth := oauth.NewGoogleOAuthProvider()
 	stateManager := oauth.NewStateManager(10 * time.Minute) // 10 minutes TTL
 
@@ -58,6 +62,7 @@ func NewAppContext(secretKey string, db *database.Database) *appContext {
 		db:            db,
 		tokenProvider: tokenProvider,
 		deeplClient:   deeplClient,
+		llmClient:     llmClient,
 		kafkaClient:   kafkaClient,
 		googleOAuth:   googleOAuth,
 		stateManager:  stateManager,
@@ -80,6 +85,10 @@ func (ctx *appContext) GetDeeplClient() *deeplgo.Client {
 	return ctx.deeplClient
 }
 
+func (ctx *appContext) GetLLMClient() llm.Client {
+	return ctx.llmClient
+}
+
 func (ctx *appContext) GetKafka() *kafka.KafkaClient {
 	return ctx.kafkaClient
 }
@@ -103,6 +103,11 @@ func (r *RedisClient) SetNX(key string, value interface{}, expiration time.Durat
 	return r.client.SetNX(r.ctx, key, value, expiration).Result()
 }
 
+// Incr atomically increments a counter and returns the new value
+func (r *RedisClient) Incr(key string) (int64, error) {
+	return r.client.Incr(r.ctx, key).Result()
+}
+
 // HealthCheck verifies Redis connection is alive
 func (r *RedisClient) HealthCheck() error {
 	_, err := r.client.Ping(r.ctx).Result()
@@ -1,11 +1,38 @@
 package deepl
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"os"
+	"strings"
+
+	"hub-service/common"
 
 	"github.com/bounoable/deepl"
 )
 
+// ErrTranslatorUnavailable is returned when a machine reference is requested without a DeepL key
+var ErrTranslatorUnavailable = common.NewFullErrorResponse(
+	http.StatusServiceUnavailable,
+	errors.New("deepl not configured"),
+	"Machine translation is not configured",
+	"deepl not configured",
+	"MACHINE_TRANSLATION_UNAVAILABLE",
+)
+
+// ErrTranslationFailed wraps a DeepL failure while generating references
+func ErrTranslationFailed(err error) *common.AppError {
+	return common.NewFullErrorResponse(
+		http.StatusBadGateway,
+		err,
+		"Could not generate the machine reference translation",
+		err.Error(),
+		"MACHINE_TRANSLATION_FAILED",
+	)
+}
+
 // NewClient creates a new DeepL client.
 // It reads the authentication key from the SYSTEM_DEEPL_API_KEY environment variable.
 func NewClient() *deepl.Client {
@@ -14,10 +41,71 @@ func NewClient() *deepl.Client {
 		return nil
 	}
 
-	client := deepl.New(
-		authKey,
-		deepl.BaseURL(os.Getenv("SYSTEM_DEEPL_BASE_URL")),
-	)
+	// An empty base URL would replace the library's default endpoint
+	var opts []deepl.ClientOption
+	if baseURL := os.Getenv("SYSTEM_DEEPL_BASE_URL"); baseURL != "" {
+		opts = append(opts, deepl.BaseURL(baseURL))
+	}
+
+	client := deepl.New(authKey, opts...)
 
 	return client
 }
+
+// Translator generates machine reference translations for learning content
+type Translator struct {
+	client *deepl.Client
+}
+
+// NewTranslator returns nil when DeepL is not configured
+func NewTranslator(client *deepl.Client) *Translator {
+	if client == nil {
+		return nil
+	}
+	return &Translator{client: client}
+}
+
+// TranslateMany translates texts in one request, keeping their order.
+// Languages are ISO 639-1 codes such as "vi" or "en".
+func (t *Translator) TranslateMany(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	if len(texts) == 0 {
+		return []string{}, nil
+	}
+
+	results, err := t.client.TranslateMany(ctx, texts, targetLanguage(targetLang), deepl.SourceLang(sourceLanguage(sourceLang)))
+	if err != nil {
+		return nil, fmt.Errorf("deepl translate: %w", err)
+	}
+	if len(results) != len(texts) {
+		return nil, fmt.Errorf("deepl returned %d translations for %d texts", len(results), len(texts))
+	}
+
+	translations := make([]string, len(results))
+	for i, result := range results {
+		translations[i] = strings.TrimSpace(result.Text)
+	}
+	return translations, nil
+}
+
+// sourceLanguage uppercases the base code, e.g. "en-US" → "EN"
+func sourceLanguage(lang string) deepl.Language {
+	lang = strings.ToUpper(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_"); i > 0 {
+		lang = lang[:i]
+	}
+	return deepl.Language(lang)
+}
+
+// targetLanguage picks a regional variant where DeepL rejects the bare code
+func targetLanguage(lang string) deepl.Language {
+	switch code := sourceLanguage(lang); code {
+	case deepl.English:
+		return deepl.EnglishAmerican
+	case deepl.Portuguese:
+		return deepl.PortugueseBrazil
+	case deepl.Chinese:
+		return deepl.ChineseSimplified
+	default:
+		return code
+	}
+}
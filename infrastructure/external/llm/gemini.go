@@ -0,0 +1,135 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+type geminiClient struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+type geminiRequest struct {
+	Contents []geminiContent `json:"contents"`
+}
+
+type geminiContent struct {
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+type geminiResponse struct {
+	Candidates     []geminiCandidate `json:"candidates"`
+	PromptFeedback struct {
+		BlockReason string `json:"blockReason"`
+	} `json:"promptFeedback"`
+	ModelVersion  string `json:"modelVersion"`
+	UsageMetadata struct {
+		PromptTokenCount     int `json:"promptTokenCount"`
+		CandidatesTokenCount int `json:"candidatesTokenCount"`
+		ThoughtsTokenCount   int `json:"thoughtsTokenCount"`
+	} `json:"usageMetadata"`
+}
+
+type geminiCandidate struct {
+	Content      geminiContent `json:"content"`
+	FinishReason string        `json:"finishReason"`
+}
+
+// geminiBlockedReasons are finish reasons for which Gemini withholds the answer
+var geminiBlockedReasons = map[string]bool{
+	"SAFETY":             true,
+	"RECITATION":         true,
+	"BLOCKLIST":          true,
+	"PROHIBITED_CONTENT": true,
+	"SPII":               true,
+}
+
+// newGeminiClient reads GEMINI_API_KEY and GEMINI_BASE_URL (the full generateContent URL)
+func newGeminiClient() Client {
+	apiKey := os.Getenv("GEMINI_API_KEY")
+	baseURL := os.Getenv("GEMINI_BASE_URL")
+	if apiKey == "" || baseURL == "" {
+		return nil
+	}
+
+	return &geminiClient{
+		apiKey:  apiKey,
+		baseURL: baseURL,
+		client:  sharedHTTPClient(),
+	}
+}
+
+func (c *geminiClient) Name() string {
+	return ProviderGemini
+}
+
+func (c *geminiClient) Generate(ctx context.Context, prompt string) (*Response, error) {
+	req := geminiRequest{
+		Contents: []geminiContent{
+			{
+				Parts: []geminiPart{
+					{Text: prompt},
+				},
+			},
+		},
+	}
+
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.New("failed to marshal request")
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.baseURL, strings.NewReader(string(jsonData)))
+	if err != nil {
+		return nil, errors.New("failed to create request")
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-goog-api-key", c.apiKey)
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, executeError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(ProviderGemini, resp)
+	}
+
+	var geminiResp geminiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&geminiResp); err != nil {
+		return nil, errors.New("failed to decode response")
+	}
+
+	if reason := geminiResp.PromptFeedback.BlockReason; reason != "" {
+		return nil, fmt.Errorf("%w: prompt blocked by Gemini (%s)", ErrBlocked, reason)
+	}
+	if len(geminiResp.Candidates) > 0 && geminiBlockedReasons[geminiResp.Candidates[0].FinishReason] {
+		return nil, fmt.Errorf("%w: candidate blocked by Gemini (%s)", ErrBlocked, geminiResp.Candidates[0].FinishReason)
+	}
+	if len(geminiResp.Candidates) == 0 || len(geminiResp.Candidates[0].Content.Parts) == 0 {
+		return nil, fmt.Errorf("%w: no response from Gemini", ErrEmptyResponse)
+	}
+
+	return &Response{
+		Text:  geminiResp.Candidates[0].Content.Parts[0].Text,
+		Model: geminiResp.ModelVersion,
+		// Thinking tokens are billed as output
+		Usage: Usage{
+			InputTokens:  geminiResp.UsageMetadata.PromptTokenCount,
+			OutputTokens: geminiResp.UsageMetadata.CandidatesTokenCount + geminiResp.UsageMetadata.ThoughtsTokenCount,
+		},
+	}, nil
+}
@@ -0,0 +1,97 @@
+package llm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	httpClient     *http.Client
+	httpClientOnce sync.Once
+)
+
+// sharedHTTPClient is reused by every provider so connections are pooled.
+// Per-call deadlines come from the request context set by resilientClient;
+// the client timeout is only a backstop. It is built on first use so
+// LLM_HTTP_TIMEOUT from .env is loaded by then.
+func sharedHTTPClient() *http.Client {
+	httpClientOnce.Do(func() {
+		timeout := envDuration("LLM_HTTP_TIMEOUT", 2*time.Minute)
+		httpClient = &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				DialContext: (&net.Dialer{
+					Timeout:   10 * time.Second,
+					KeepAlive: 30 * time.Second,
+				}).DialContext,
+				MaxIdleConns:          100,
+				MaxIdleConnsPerHost:   20,
+				IdleConnTimeout:       90 * time.Second,
+				TLSHandshakeTimeout:   10 * time.Second,
+				ResponseHeaderTimeout: timeout,
+			},
+		}
+	})
+	return httpClient
+}
+
+// StatusError is returned when a provider answers with a non-200 status
+type StatusError struct {
+	Provider   string
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s status code: %d, body: %s", e.Provider, e.StatusCode, e.Body)
+}
+
+// Retryable reports whether the provider may succeed if asked again
+func (e *StatusError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// newStatusError drains the body of a failed response into a StatusError
+func newStatusError(provider string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	var retryAfter time.Duration
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		retryAfter = time.Duration(seconds) * time.Second
+	}
+
+	return &StatusError{
+		Provider:   provider,
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		RetryAfter: retryAfter,
+	}
+}
+
+// executeError wraps transport failures so timeouts stay distinguishable from bad replies
+func executeError(err error) error {
+	return fmt.Errorf("failed to execute request: %w", err)
+}
+
+// isRetryable classifies an error from a single attempt
+func isRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Retryable()
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return errors.Is(err, io.ErrUnexpectedEOF)
+}
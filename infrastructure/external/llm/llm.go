@@ -0,0 +1,69 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+)
+
+// Supported provider names for the AI_PROVIDER environment variable
+const (
+	ProviderGemini = "gemini"
+	ProviderOpenAI = "openai"
+	ProviderOllama = "ollama"
+
+	// ProviderMock has no Client; graders use a built-in deterministic analyzer instead
+	ProviderMock = "mock"
+)
+
+var (
+	// ErrBlocked means the provider refused to answer, e.g. a safety filter blocked the prompt or reply
+	ErrBlocked = errors.New("ai response blocked")
+
+	// ErrEmptyResponse means the provider answered without any text
+	ErrEmptyResponse = errors.New("ai response empty")
+)
+
+// Client sends a single prompt to a text-generation backend and returns the raw reply
+type Client interface {
+	Generate(ctx context.Context, prompt string) (*Response, error)
+	Name() string
+}
+
+// Response is the provider-agnostic result of a Generate call
+type Response struct {
+	Text  string
+	Model string
+	Usage Usage
+}
+
+// ProviderName returns the normalized AI_PROVIDER value, defaulting to Gemini
+func ProviderName() string {
+	provider := strings.ToLower(strings.TrimSpace(os.Getenv("AI_PROVIDER")))
+	if provider == "" {
+		return ProviderGemini
+	}
+	return provider
+}
+
+// NewClient creates the client selected by the AI_PROVIDER environment variable.
+// It defaults to Gemini and returns nil when the selected provider is not configured.
+func NewClient() Client {
+	return NewClientFor(ProviderName())
+}
+
+// NewClientFor creates a client for the given provider name, or nil if it is not configured.
+// Every client is wrapped with retries, a per-provider circuit breaker and the global concurrency cap.
+func NewClientFor(provider string) Client {
+	var client Client
+	switch strings.ToLower(strings.TrimSpace(provider)) {
+	case "", ProviderGemini:
+		client = newGeminiClient()
+	case ProviderOpenAI:
+		client = newOpenAIClient()
+	case ProviderOllama:
+		client = newOllamaClient()
+	}
+	return NewResilientClient(client, LoadResilienceConfig())
+}
@@ -0,0 +1,118 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const defaultOllamaBaseURL = "http://localhost:11434"
+
+// ollamaClient talks to a local Ollama-style /api/chat endpoint
+type ollamaClient struct {
+	baseURL string
+	model   string
+	client  *http.Client
+}
+
+type ollamaMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type ollamaRequest struct {
+	Model    string          `json:"model"`
+	Messages []ollamaMessage `json:"messages"`
+	Stream   bool            `json:"stream"`
+	Format   string          `json:"format,omitempty"`
+}
+
+type ollamaResponse struct {
+	Model           string        `json:"model"`
+	Message         ollamaMessage `json:"message"`
+	PromptEvalCount int           `json:"prompt_eval_count"`
+	EvalCount       int           `json:"eval_count"`
+}
+
+// newOllamaClient reads OLLAMA_BASE_URL and OLLAMA_MODEL; the model is required
+func newOllamaClient() Client {
+	model := os.Getenv("OLLAMA_MODEL")
+	if model == "" {
+		return nil
+	}
+
+	baseURL := os.Getenv("OLLAMA_BASE_URL")
+	if baseURL == "" {
+		baseURL = defaultOllamaBaseURL
+	}
+
+	return &ollamaClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		model:   model,
+		client:  sharedHTTPClient(),
+	}
+}
+
+func (c *ollamaClient) Name() string {
+	return ProviderOllama
+}
+
+func (c *ollamaClient) Generate(ctx context.Context, prompt string) (*Response, error) {
+	req := ollamaRequest{
+		Model: c.model,
+		Messages: []ollamaMessage{
+			{Role: "user", Content: prompt},
+		},
+		Stream: false,
+		Format: "json",
+	}
+
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.New("failed to marshal request")
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/chat", strings.NewReader(string(jsonData)))
+	if err != nil {
+		return nil, errors.New("failed to create request")
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, executeError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(ProviderOllama, resp)
+	}
+
+	var ollamaResp ollamaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ollamaResp); err != nil {
+		return nil, errors.New("failed to decode response")
+	}
+
+	if ollamaResp.Message.Content == "" {
+		return nil, fmt.Errorf("%w: no response from Ollama", ErrEmptyResponse)
+	}
+
+	model := ollamaResp.Model
+	if model == "" {
+		model = c.model
+	}
+
+	return &Response{
+		Text:  ollamaResp.Message.Content,
+		Model: model,
+		Usage: Usage{
+			InputTokens:  ollamaResp.PromptEvalCount,
+			OutputTokens: ollamaResp.EvalCount,
+		},
+	}, nil
+}
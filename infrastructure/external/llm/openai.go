@@ -0,0 +1,133 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	defaultOpenAIBaseURL = "https://api.openai.com/v1"
+	defaultOpenAIModel   = "gpt-4o-mini"
+)
+
+// openAIClient talks to any OpenAI-compatible /chat/completions endpoint
+type openAIClient struct {
+	apiKey  string
+	baseURL string
+	model   string
+	client  *http.Client
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIRequest struct {
+	Model    string          `json:"model"`
+	Messages []openAIMessage `json:"messages"`
+}
+
+type openAIResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message      openAIMessage `json:"message"`
+		FinishReason string        `json:"finish_reason"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+// newOpenAIClient reads OPENAI_API_KEY, OPENAI_BASE_URL and OPENAI_MODEL
+func newOpenAIClient() Client {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		return nil
+	}
+
+	baseURL := os.Getenv("OPENAI_BASE_URL")
+	if baseURL == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+
+	model := os.Getenv("OPENAI_MODEL")
+	if model == "" {
+		model = defaultOpenAIModel
+	}
+
+	return &openAIClient{
+		apiKey:  apiKey,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		model:   model,
+		client:  sharedHTTPClient(),
+	}
+}
+
+func (c *openAIClient) Name() string {
+	return ProviderOpenAI
+}
+
+func (c *openAIClient) Generate(ctx context.Context, prompt string) (*Response, error) {
+	req := openAIRequest{
+		Model: c.model,
+		Messages: []openAIMessage{
+			{Role: "user", Content: prompt},
+		},
+	}
+
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.New("failed to marshal request")
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/chat/completions", strings.NewReader(string(jsonData)))
+	if err != nil {
+		return nil, errors.New("failed to create request")
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, executeError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(ProviderOpenAI, resp)
+	}
+
+	var openAIResp openAIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&openAIResp); err != nil {
+		return nil, errors.New("failed to decode response")
+	}
+
+	if len(openAIResp.Choices) > 0 && openAIResp.Choices[0].FinishReason == "content_filter" {
+		return nil, fmt.Errorf("%w: reply filtered by OpenAI-compatible endpoint", ErrBlocked)
+	}
+	if len(openAIResp.Choices) == 0 || openAIResp.Choices[0].Message.Content == "" {
+		return nil, fmt.Errorf("%w: no response from OpenAI-compatible endpoint", ErrEmptyResponse)
+	}
+
+	model := openAIResp.Model
+	if model == "" {
+		model = c.model
+	}
+
+	return &Response{
+		Text:  openAIResp.Choices[0].Message.Content,
+		Model: model,
+		Usage: Usage{
+			InputTokens:  openAIResp.Usage.PromptTokens,
+			OutputTokens: openAIResp.Usage.CompletionTokens,
+		},
+	}, nil
+}
@@ -0,0 +1,312 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrCircuitOpen is returned without calling the provider while its circuit breaker is open
+	ErrCircuitOpen = errors.New("ai provider circuit breaker is open")
+
+	// ErrOverloaded is returned when no grading slot frees up within LLM_QUEUE_TIMEOUT
+	ErrOverloaded = errors.New("too many concurrent ai requests")
+)
+
+const maxRetryDelay = 10 * time.Second
+
+// ResilienceConfig tunes resilientClient; every field has an LLM_* environment variable
+type ResilienceConfig struct {
+	CallTimeout      time.Duration // LLM_CALL_TIMEOUT, deadline of a single attempt
+	MaxRetries       int           // LLM_MAX_RETRIES, extra attempts after a 429/5xx or network error
+	RetryBaseDelay   time.Duration // LLM_RETRY_BASE_DELAY, doubled per attempt with full jitter
+	BreakerThreshold int           // LLM_BREAKER_THRESHOLD, consecutive failures that open the breaker
+	BreakerCooldown  time.Duration // LLM_BREAKER_COOLDOWN, time before a half-open probe
+	MaxConcurrency   int           // LLM_MAX_CONCURRENCY, in-flight calls across all providers
+	QueueTimeout     time.Duration // LLM_QUEUE_TIMEOUT, wait for a slot before shedding; 0 sheds immediately
+}
+
+// LoadResilienceConfig reads ResilienceConfig from the environment
+func LoadResilienceConfig() ResilienceConfig {
+	return ResilienceConfig{
+		CallTimeout:      envDuration("LLM_CALL_TIMEOUT", 30*time.Second),
+		MaxRetries:       envInt("LLM_MAX_RETRIES", 2),
+		RetryBaseDelay:   envDuration("LLM_RETRY_BASE_DELAY", 500*time.Millisecond),
+		BreakerThreshold: envInt("LLM_BREAKER_THRESHOLD", 5),
+		BreakerCooldown:  envDuration("LLM_BREAKER_COOLDOWN", 30*time.Second),
+		MaxConcurrency:   envInt("LLM_MAX_CONCURRENCY", 8),
+		QueueTimeout:     envDuration("LLM_QUEUE_TIMEOUT", 10*time.Second),
+	}
+}
+
+var (
+	semaphoreOnce sync.Once
+	semaphore     chan struct{}
+
+	breakersMu sync.Mutex
+	breakers   = map[string]*circuitBreaker{}
+)
+
+// globalSemaphore is shared by every client so the cap holds process-wide
+func globalSemaphore(size int) chan struct{} {
+	semaphoreOnce.Do(func() {
+		if size < 1 {
+			size = 1
+		}
+		semaphore = make(chan struct{}, size)
+	})
+	return semaphore
+}
+
+// breakerFor returns the process-wide breaker of a provider
+func breakerFor(provider string, cfg ResilienceConfig) *circuitBreaker {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+
+	if b, ok := breakers[provider]; ok {
+		return b
+	}
+	b := &circuitBreaker{threshold: cfg.BreakerThreshold, cooldown: cfg.BreakerCooldown}
+	breakers[provider] = b
+	return b
+}
+
+// resilientClient adds deadlines, retries, a circuit breaker and a concurrency cap to any Client
+type resilientClient struct {
+	next    Client
+	cfg     ResilienceConfig
+	breaker *circuitBreaker
+	slots   chan struct{}
+}
+
+// NewResilientClient wraps next; it returns nil when next is nil so "not configured" is preserved
+func NewResilientClient(next Client, cfg ResilienceConfig) Client {
+	if next == nil {
+		return nil
+	}
+	return &resilientClient{
+		next:    next,
+		cfg:     cfg,
+		breaker: breakerFor(next.Name(), cfg),
+		slots:   globalSemaphore(cfg.MaxConcurrency),
+	}
+}
+
+func (c *resilientClient) Name() string {
+	return c.next.Name()
+}
+
+func (c *resilientClient) Generate(ctx context.Context, prompt string) (*Response, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, c.retryDelay(attempt, lastErr)); err != nil {
+				return nil, lastErr
+			}
+		}
+
+		if !c.breaker.allow() {
+			return nil, ErrCircuitOpen
+		}
+
+		resp, err := c.attempt(ctx, prompt)
+		if err == nil {
+			c.breaker.success()
+			return resp, nil
+		}
+		if errors.Is(err, ErrOverloaded) {
+			c.breaker.release()
+			return nil, err
+		}
+
+		lastErr = err
+		// The caller gave up; this says nothing about the provider
+		if ctx.Err() != nil {
+			c.breaker.release()
+			return nil, err
+		}
+		if !isRetryable(err) {
+			// A well-formed rejection (e.g. 400) means the provider is up
+			c.breaker.success()
+			return nil, err
+		}
+
+		c.breaker.failure()
+		log.Printf("%s attempt %d/%d failed: %v", c.next.Name(), attempt+1, c.cfg.MaxRetries+1, err)
+	}
+
+	return nil, lastErr
+}
+
+// attempt holds a concurrency slot for a single call with its own deadline and reports it to the usage recorder
+func (c *resilientClient) attempt(ctx context.Context, prompt string) (*Response, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer func() { <-c.slots }()
+
+	callCtx := ctx
+	if c.cfg.CallTimeout > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeout(ctx, c.cfg.CallTimeout)
+		defer cancel()
+	}
+
+	started := time.Now()
+	resp, err := c.next.Generate(callCtx, prompt)
+
+	call := &Call{Provider: c.next.Name(), Latency: time.Since(started), Err: err}
+	if resp != nil {
+		call.Model = resp.Model
+		call.Usage = resp.Usage
+	}
+	recordCall(ctx, call)
+
+	return resp, err
+}
+
+// acquire queues for a slot for at most QueueTimeout, then sheds the call
+func (c *resilientClient) acquire(ctx context.Context) error {
+	select {
+	case c.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	if c.cfg.QueueTimeout <= 0 {
+		return ErrOverloaded
+	}
+
+	timer := time.NewTimer(c.cfg.QueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case c.slots <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return ErrOverloaded
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// retryDelay is exponential backoff with full jitter, raised to the provider's Retry-After
+func (c *resilientClient) retryDelay(attempt int, lastErr error) time.Duration {
+	backoff := c.cfg.RetryBaseDelay << (attempt - 1)
+	if backoff <= 0 || backoff > maxRetryDelay {
+		backoff = maxRetryDelay
+	}
+	delay := time.Duration(rand.Int63n(int64(backoff) + 1))
+
+	var statusErr *StatusError
+	if errors.As(lastErr, &statusErr) && statusErr.RetryAfter > delay {
+		delay = statusErr.RetryAfter
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+	return delay
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// circuitBreaker opens after threshold consecutive failures and lets a single
+// probe through once the cooldown has passed (half-open)
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openedAt  time.Time
+	probing   bool
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.threshold <= 0 || b.failures < b.threshold {
+		return true
+	}
+	if time.Since(b.openedAt) < b.cooldown || b.probing {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures >= b.threshold && b.threshold > 0 {
+		log.Println("AI provider circuit breaker closed")
+	}
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *circuitBreaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.threshold > 0 && b.failures >= b.threshold {
+		if b.failures == b.threshold {
+			log.Printf("AI provider circuit breaker opened after %d failures", b.failures)
+		}
+		b.openedAt = time.Now()
+	}
+}
+
+// release ends a probe that neither proved nor disproved the provider's health
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+func envDuration(key string, fallback time.Duration) time.Duration {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		log.Printf("Invalid %s %q, using %s", key, raw, fallback)
+		return fallback
+	}
+	return d
+}
+
+func envInt(key string, fallback int) int {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		log.Printf("Invalid %s %q, using %d", key, raw, fallback)
+		return fallback
+	}
+	return n
+}
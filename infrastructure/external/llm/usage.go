@@ -0,0 +1,50 @@
+package llm
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Usage is the token count reported by the provider for one call
+type Usage struct {
+	InputTokens  int
+	OutputTokens int
+}
+
+// Call describes a single request sent to a provider, successful or not
+type Call struct {
+	Provider string
+	Model    string
+	Usage    Usage
+	Latency  time.Duration
+	Err      error
+}
+
+// UsageRecorder receives every call made through a resilient client, e.g. to keep a cost ledger.
+// RecordCall runs on the request path and must not block.
+type UsageRecorder interface {
+	RecordCall(ctx context.Context, call *Call)
+}
+
+var (
+	usageRecorderMu sync.RWMutex
+	usageRecorder   UsageRecorder
+)
+
+// SetUsageRecorder installs the process-wide recorder; nil turns recording off
+func SetUsageRecorder(recorder UsageRecorder) {
+	usageRecorderMu.Lock()
+	defer usageRecorderMu.Unlock()
+	usageRecorder = recorder
+}
+
+func recordCall(ctx context.Context, call *Call) {
+	usageRecorderMu.RLock()
+	recorder := usageRecorder
+	usageRecorderMu.RUnlock()
+
+	if recorder != nil {
+		recorder.RecordCall(ctx, call)
+	}
+}
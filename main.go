@@ -5,10 +5,19 @@ import (
 	"hub-service/docs"
 	"hub-service/infrastructure/database/database"
 	"hub-service/middleware"
+	achievementJob "hub-service/module/achievement/job"
 	emailConsumer "hub-service/module/email/consumer"
 	emailRepository "hub-service/module/email/repository"
 	"hub-service/module/email/scheduler"
 	emailSender "hub-service/module/email/sender"
+	"hub-service/module/indexes"
+	leaderboardJob "hub-service/module/leaderboard/job"
+	regradeJob "hub-service/module/regrade/job"
+	scoreJob "hub-service/module/score/job"
+	scoreJobConsumer "hub-service/module/scorejob/consumer"
+	scoreJobReaper "hub-service/module/scorejob/job"
+	streakJob "hub-service/module/streak/job"
+	usageJob "hub-service/module/usage/job"
 	"log"
 	"os"
 	"os/signal"
@@ -58,8 +67,45 @@ func main() {
 		campaignScheduler := scheduler.NewCampaignScheduler(appContext)
 		campaignScheduler.Start()
 		defer campaignScheduler.Stop()
+
+		// Start asynchronous scoring worker
+		scoreJobWorker := scoreJobConsumer.NewScoreJobWorker(appContext)
+		scoreJobWorker.Start()
+		defer scoreJobWorker.Stop()
+
+		// Requeue score jobs left behind by a crashed worker
+		scoreJobs := scoreJobReaper.NewReaper(appContext)
+		scoreJobs.Start()
+		defer scoreJobs.Stop()
+
+		// Start comeback email scheduler
+		comebackScheduler := streakJob.NewComebackScheduler(appContext)
+		comebackScheduler.Start()
+		defer comebackScheduler.Stop()
 	}
 
+	// Record every AI provider call in the usage ledger
+	usageLedger := usageJob.NewLedgerWriter(appContext)
+	usageLedger.Start()
+	defer usageLedger.Stop()
+
+	// Run bulk re-grades queued by admins
+	regradeRunner := regradeJob.NewRunner(appContext)
+	regradeRunner.Start()
+	defer regradeRunner.Stop()
+
+	// Create the unique indexes the storages rely on
+	indexes.Ensure(appContext)
+
+	// Create the built-in badges that are missing
+	achievementJob.SeedDefaultBadges(appContext)
+
+	// Store grading errors of older scores as structured BSON
+	go scoreJob.MigrateErrors(appContext)
+
+	// Restore leaderboards from MongoDB if Redis lost them
+	go leaderboardJob.RebuildIfMissing(appContext)
+
 	// Setup router
 	r := gin.Default()
 
@@ -2,12 +2,28 @@ package middleware
 
 import (
 	"hub-service/core/appctx"
+	achievementTransport "hub-service/module/achievement/transport"
+	analyticsTransport "hub-service/module/analytics/transport"
+	appealTransport "hub-service/module/appeal/transport"
+	attemptTransport "hub-service/module/attempt/transport"
 	challengeTransport "hub-service/module/challenge/transport"
 	emailTransport "hub-service/module/email/transport"
+	hintTransport "hub-service/module/hint/transport"
+	integrityTransport "hub-service/module/integrity/transport"
+	leaderboardTransport "hub-service/module/leaderboard/transport"
+	overrideTransport "hub-service/module/override/transport"
+	progressTransport "hub-service/module/progress/transport"
+	promptTransport "hub-service/module/prompt/transport"
+	quotaTransport "hub-service/module/quota/transport"
+	regradeTransport "hub-service/module/regrade/transport"
+	reviewTransport "hub-service/module/review/transport"
 	scoreTransport "hub-service/module/score/transport"
+	scoreJobTransport "hub-service/module/scorejob/transport"
 	sectionTransport "hub-service/module/section/transport"
+	streakTransport "hub-service/module/streak/transport"
 	translationTransport "hub-service/module/translation/transport"
 	uploadTransport "hub-service/module/upload/transport"
+	usageTransport "hub-service/module/usage/transport"
 	ginuser "hub-service/module/user/transport"
 
 	"github.com/gin-gonic/gin"
@@ -29,5 +45,21 @@ func ApiServices(appCtx appctx.AppContext, r *gin.Engine) {
 	translationTransport.RegisterRoutes(v1, appCtx)
 	uploadTransport.RegisterRoutes(v1, appCtx)
 	emailTransport.RegisterRoutes(appCtx, v1)
+	attemptTransport.RegisterRoutes(v1, appCtx)
+	scoreJobTransport.RegisterRoutes(v1, appCtx)
+	quotaTransport.RegisterRoutes(v1, appCtx)
+	promptTransport.RegisterRoutes(v1, appCtx)
+	overrideTransport.RegisterRoutes(v1, appCtx)
+	appealTransport.RegisterRoutes(v1, appCtx)
+	leaderboardTransport.RegisterRoutes(v1, appCtx)
+	streakTransport.RegisterRoutes(v1, appCtx)
+	achievementTransport.RegisterRoutes(v1, appCtx)
+	reviewTransport.RegisterRoutes(v1, appCtx)
+	analyticsTransport.RegisterRoutes(v1, appCtx)
+	progressTransport.RegisterRoutes(v1, appCtx)
+	usageTransport.RegisterRoutes(v1, appCtx)
+	hintTransport.RegisterRoutes(v1, appCtx)
+	integrityTransport.RegisterRoutes(v1, appCtx)
+	regradeTransport.RegisterRoutes(v1, appCtx)
 }
 
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"errors"
+	"hub-service/common"
 	"hub-service/core/appctx"
 	"net/http"
 	"strings"
@@ -77,3 +78,10 @@ func RequireRoles(requiredRoles ...string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// IsAdmin reports whether the signed-in user is an admin or super admin.
+// It is false on public routes where AuthMiddleware did not run.
+func IsAdmin(c *gin.Context) bool {
+	role, _ := c.Get("user_role")
+	return role == common.RoleAdmin || role == common.RoleSuperAdmin
+}
@@ -0,0 +1,159 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"hub-service/common"
+	"hub-service/module/achievement/model"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrBadgeCodeTaken = common.NewFullErrorResponse(
+		http.StatusConflict,
+		errors.New("badge code taken"),
+		"A badge with this code already exists",
+		"badge code taken",
+		"ErrBadgeCodeTaken",
+	)
+	ErrInvalidBadgeCode = common.NewErrorResponse(
+		errors.New("invalid badge code"),
+		"Badge codes use lowercase letters, digits and dashes",
+		"invalid badge code",
+		"ErrInvalidBadgeCode",
+	)
+
+	badgeCodePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+type ManageBadgeStore interface {
+	CreateBadge(ctx context.Context, data *model.Badge) error
+	GetBadge(ctx context.Context, id primitive.ObjectID) (*model.Badge, error)
+	GetBadgeByCode(ctx context.Context, code string) (*model.Badge, error)
+	ListBadges(ctx context.Context, activeOnly bool) ([]model.Badge, error)
+	UpdateBadge(ctx context.Context, id primitive.ObjectID, data *model.BadgeUpdate) error
+}
+
+type manageBadgeBiz struct {
+	store ManageBadgeStore
+}
+
+func NewManageBadgeBiz(store ManageBadgeStore) *manageBadgeBiz {
+	return &manageBadgeBiz{store: store}
+}
+
+func (biz *manageBadgeBiz) CreateBadge(ctx context.Context, req *model.CreateBadgeRequest, createdBy primitive.ObjectID) (*model.Badge, error) {
+	code := strings.ToLower(strings.TrimSpace(req.Code))
+	if !badgeCodePattern.MatchString(code) {
+		return nil, ErrInvalidBadgeCode
+	}
+
+	rule, err := toRule(&req.Rule)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := biz.store.GetBadgeByCode(ctx, code)
+	if err != nil {
+		return nil, common.ErrDB(err)
+	}
+	if existing != nil {
+		return nil, ErrBadgeCodeTaken
+	}
+
+	now := time.Now()
+	badge := &model.Badge{
+		Code:        code,
+		Name:        req.Name,
+		Description: req.Description,
+		Icon:        req.Icon,
+		Rule:        *rule,
+		IsActive:    req.IsActive == nil || *req.IsActive,
+		CreatedBy:   &createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := biz.store.CreateBadge(ctx, badge); err != nil {
+		// Another admin created the same code since it was checked
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrBadgeCodeTaken
+		}
+		return nil, common.ErrCannotCreateEntity("Badge", err)
+	}
+
+	return badge, nil
+}
+
+func (biz *manageBadgeBiz) UpdateBadge(ctx context.Context, id primitive.ObjectID, req *model.UpdateBadgeRequest) (*model.Badge, error) {
+	badge, err := biz.store.GetBadge(ctx, id)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("Badge", err)
+	}
+	if badge == nil {
+		return nil, common.ErrEntityNotFound("Badge", common.RecordNotFound)
+	}
+
+	update := &model.BadgeUpdate{
+		Name:        req.Name,
+		Description: req.Description,
+		Icon:        req.Icon,
+		IsActive:    req.IsActive,
+		UpdatedAt:   time.Now(),
+	}
+	if req.Rule != nil {
+		if update.Rule, err = toRule(req.Rule); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := biz.store.UpdateBadge(ctx, id, update); err != nil {
+		return nil, common.ErrCannotUpdateEntity("Badge", err)
+	}
+
+	updated, err := biz.store.GetBadge(ctx, id)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("Badge", err)
+	}
+	return updated, nil
+}
+
+// ListBadges lists badge definitions; inactive ones are included only when asked for
+func (biz *manageBadgeBiz) ListBadges(ctx context.Context, includeInactive bool) ([]model.Badge, error) {
+	badges, err := biz.store.ListBadges(ctx, !includeInactive)
+	if err != nil {
+		return nil, common.ErrCannotListEntity("Badge", err)
+	}
+	return badges, nil
+}
+
+// toRule validates the parts of a rule that binding tags cannot express
+func toRule(req *model.RuleRequest) (*model.Rule, error) {
+	rule := &model.Rule{
+		Metric:    req.Metric,
+		Threshold: req.Threshold,
+		Kind:      req.Kind,
+	}
+
+	if req.Kind != "" && req.Metric != model.MetricSubmissionScore && req.Metric != model.MetricAttempts {
+		return nil, common.ErrInvalidRequest(errors.New("kind only applies to the submission_score and attempts metrics"))
+	}
+
+	if req.SectionID != "" {
+		if req.Metric != model.MetricSectionsCompleted {
+			return nil, common.ErrInvalidRequest(errors.New("section_id only applies to the sections_completed metric"))
+		}
+		sectionID, err := primitive.ObjectIDFromHex(req.SectionID)
+		if err != nil {
+			return nil, common.ErrInvalidRequest(err)
+		}
+		rule.SectionID = &sectionID
+	}
+
+	return rule, nil
+}
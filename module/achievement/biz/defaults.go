@@ -0,0 +1,68 @@
+package biz
+
+import (
+	"context"
+	"hub-service/module/achievement/model"
+	"time"
+)
+
+// DefaultBadges are created on startup when missing. Admins can edit or deactivate them like any other badge.
+func DefaultBadges() []model.Badge {
+	return []model.Badge{
+		{
+			Code:        "first-perfect-score",
+			Name:        "Flawless",
+			Description: "Score 100 points on a translation",
+			Rule:        model.Rule{Metric: model.MetricSubmissionScore, Threshold: 100},
+		},
+		{
+			Code:        "section-finisher",
+			Name:        "Section Finisher",
+			Description: "Complete every challenge in a section",
+			Rule:        model.Rule{Metric: model.MetricSectionsCompleted, Threshold: 1},
+		},
+		{
+			Code:        "week-streak",
+			Name:        "On Fire",
+			Description: "Meet your daily goal 7 days in a row",
+			Rule:        model.Rule{Metric: model.MetricStreak, Threshold: 7},
+		},
+		{
+			Code:        "month-streak",
+			Name:        "Unstoppable",
+			Description: "Meet your daily goal 30 days in a row",
+			Rule:        model.Rule{Metric: model.MetricStreak, Threshold: 30},
+		},
+		{
+			Code:        "fifty-attempts",
+			Name:        "Persistent",
+			Description: "Submit 50 translations",
+			Rule:        model.Rule{Metric: model.MetricAttempts, Threshold: 50},
+		},
+	}
+}
+
+type EnsureBadgeStore interface {
+	EnsureBadge(ctx context.Context, data *model.Badge) (bool, error)
+}
+
+// SeedDefaultBadges creates the default badges that do not exist yet and returns how many it created
+func SeedDefaultBadges(ctx context.Context, store EnsureBadgeStore) (int, error) {
+	now := time.Now()
+
+	created := 0
+	for _, badge := range DefaultBadges() {
+		badge.IsActive = true
+		badge.CreatedAt = now
+		badge.UpdatedAt = now
+
+		inserted, err := store.EnsureBadge(ctx, &badge)
+		if err != nil {
+			return created, err
+		}
+		if inserted {
+			created++
+		}
+	}
+	return created, nil
+}
@@ -0,0 +1,139 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"hub-service/module/achievement/model"
+	scorebiz "hub-service/module/score/biz"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type BadgeStore interface {
+	ListBadges(ctx context.Context, activeOnly bool) ([]model.Badge, error)
+	ListUserBadges(ctx context.Context, userID primitive.ObjectID) ([]model.UserBadge, error)
+	Award(ctx context.Context, data *model.UserBadge) (bool, error)
+}
+
+// MetricStore reads the learner's progress that badge rules are checked against
+type MetricStore interface {
+	CountAttempts(ctx context.Context, userID primitive.ObjectID, kind string) (int64, error)
+	CountCompletedChallenges(ctx context.Context, userID primitive.ObjectID, passScore float64) (int64, error)
+	TotalScore(ctx context.Context, userID primitive.ObjectID) (float64, error)
+	CurrentStreak(ctx context.Context, userID primitive.ObjectID) (int, error)
+	CompletedSections(ctx context.Context, userID primitive.ObjectID, sectionID *primitive.ObjectID, passScore float64) ([]primitive.ObjectID, error)
+}
+
+// evaluator awards the active badges a learner has just qualified for. It must run after
+// the attempt and streak listeners so the metrics include the current submission.
+type evaluator struct {
+	badges    BadgeStore
+	metrics   MetricStore
+	passScore float64
+}
+
+// NewEvaluator returns a SubmissionListener that awards badges
+func NewEvaluator(badges BadgeStore, metrics MetricStore) *evaluator {
+	return &evaluator{badges: badges, metrics: metrics, passScore: PassScore()}
+}
+
+func (e *evaluator) OnSubmission(ctx context.Context, event *scorebiz.SubmissionEvent) error {
+	badges, err := e.badges.ListBadges(ctx, true)
+	if err != nil || len(badges) == 0 {
+		return err
+	}
+
+	earned, err := e.badges.ListUserBadges(ctx, event.UserID)
+	if err != nil {
+		return err
+	}
+	has := make(map[primitive.ObjectID]bool, len(earned))
+	for _, b := range earned {
+		has[b.BadgeID] = true
+	}
+
+	reader := &metricReader{store: e.metrics, event: event, passScore: e.passScore, values: map[string]float64{}}
+	for _, badge := range badges {
+		if has[badge.ID] {
+			continue
+		}
+
+		value, err := reader.value(ctx, badge.Rule)
+		if err != nil {
+			return err
+		}
+		if value < badge.Rule.Threshold {
+			continue
+		}
+
+		awarded, err := e.badges.Award(ctx, &model.UserBadge{
+			UserID:    event.UserID,
+			BadgeID:   badge.ID,
+			Code:      badge.Code,
+			Value:     value,
+			AwardedAt: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		if awarded {
+			log.Printf("User %s earned badge %s", event.UserID.Hex(), badge.Code)
+		}
+	}
+	return nil
+}
+
+// metricReader evaluates each metric at most once per submission
+type metricReader struct {
+	store     MetricStore
+	event     *scorebiz.SubmissionEvent
+	passScore float64
+	values    map[string]float64
+}
+
+func (r *metricReader) value(ctx context.Context, rule model.Rule) (float64, error) {
+	key := rule.Metric + "|" + rule.Kind
+	if rule.SectionID != nil {
+		key += "|" + rule.SectionID.Hex()
+	}
+	if v, ok := r.values[key]; ok {
+		return v, nil
+	}
+
+	v, err := r.read(ctx, rule)
+	if err != nil {
+		return 0, err
+	}
+	r.values[key] = v
+	return v, nil
+}
+
+func (r *metricReader) read(ctx context.Context, rule model.Rule) (float64, error) {
+	userID := r.event.UserID
+
+	switch rule.Metric {
+	case model.MetricSubmissionScore:
+		// A score awaiting or failing paste review earns nothing until a reviewer clears it
+		if r.event.Analysis == nil || (rule.Kind != "" && rule.Kind != r.event.Kind) || !r.event.PasteFlag.CountsTowardTotals() {
+			return 0, nil
+		}
+		return r.event.Analysis.Score, nil
+	case model.MetricAttempts:
+		n, err := r.store.CountAttempts(ctx, userID, rule.Kind)
+		return float64(n), err
+	case model.MetricChallengesCompleted:
+		n, err := r.store.CountCompletedChallenges(ctx, userID, r.passScore)
+		return float64(n), err
+	case model.MetricTotalScore:
+		return r.store.TotalScore(ctx, userID)
+	case model.MetricStreak:
+		n, err := r.store.CurrentStreak(ctx, userID)
+		return float64(n), err
+	case model.MetricSectionsCompleted:
+		sections, err := r.store.CompletedSections(ctx, userID, rule.SectionID, r.passScore)
+		return float64(len(sections)), err
+	}
+	return 0, fmt.Errorf("unknown badge metric %q", rule.Metric)
+}
@@ -0,0 +1,25 @@
+package biz
+
+import (
+	"log"
+	"os"
+	"strconv"
+)
+
+const defaultPassScore = 50.0
+
+// PassScore reads ACHIEVEMENT_PASS_SCORE, the best score (0-100) from which a challenge counts
+// as completed for badges
+func PassScore() float64 {
+	raw := os.Getenv("ACHIEVEMENT_PASS_SCORE")
+	if raw == "" {
+		return defaultPassScore
+	}
+
+	score, err := strconv.ParseFloat(raw, 64)
+	if err != nil || score < 0 || score > 100 {
+		log.Printf("Invalid ACHIEVEMENT_PASS_SCORE %q, using %v", raw, defaultPassScore)
+		return defaultPassScore
+	}
+	return score
+}
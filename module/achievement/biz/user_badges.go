@@ -0,0 +1,65 @@
+package biz
+
+import (
+	"context"
+	"hub-service/common"
+	"hub-service/module/achievement/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type UserBadgeStore interface {
+	ListUserBadges(ctx context.Context, userID primitive.ObjectID) ([]model.UserBadge, error)
+	ListBadgesByIDs(ctx context.Context, ids []primitive.ObjectID) ([]model.Badge, error)
+}
+
+type listUserBadgesBiz struct {
+	store UserBadgeStore
+}
+
+func NewListUserBadgesBiz(store UserBadgeStore) *listUserBadgesBiz {
+	return &listUserBadgesBiz{store: store}
+}
+
+// ListUserBadges returns the badges a learner earned, in the order they were awarded.
+// Badges that were deactivated since are still shown.
+func (biz *listUserBadgesBiz) ListUserBadges(ctx context.Context, userID primitive.ObjectID) ([]model.AwardedBadge, error) {
+	earned, err := biz.store.ListUserBadges(ctx, userID)
+	if err != nil {
+		return nil, common.ErrCannotListEntity("UserBadge", err)
+	}
+
+	result := []model.AwardedBadge{}
+	if len(earned) == 0 {
+		return result, nil
+	}
+
+	ids := make([]primitive.ObjectID, len(earned))
+	for i, b := range earned {
+		ids[i] = b.BadgeID
+	}
+
+	definitions, err := biz.store.ListBadgesByIDs(ctx, ids)
+	if err != nil {
+		return nil, common.ErrCannotListEntity("Badge", err)
+	}
+	byID := make(map[primitive.ObjectID]model.Badge, len(definitions))
+	for _, d := range definitions {
+		byID[d.ID] = d
+	}
+
+	for _, b := range earned {
+		definition, ok := byID[b.BadgeID]
+		if !ok {
+			continue
+		}
+		result = append(result, model.AwardedBadge{
+			Code:        definition.Code,
+			Name:        definition.Name,
+			Description: definition.Description,
+			Icon:        definition.Icon,
+			AwardedAt:   b.AwardedAt,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,29 @@
+// Package job creates the default badges when the service starts
+package job
+
+import (
+	"context"
+	"hub-service/core/appctx"
+	"hub-service/module/achievement/biz"
+	"hub-service/module/achievement/storage"
+	"log"
+	"time"
+)
+
+const seedTimeout = 30 * time.Second
+
+// SeedDefaultBadges adds the built-in badges that are missing. Existing badges, including
+// admin edits to the defaults, are left as they are.
+func SeedDefaultBadges(appCtx appctx.AppContext) {
+	ctx, cancel := context.WithTimeout(context.Background(), seedTimeout)
+	defer cancel()
+
+	created, err := biz.SeedDefaultBadges(ctx, storage.NewStorage(appCtx.GetDatabase()))
+	if err != nil {
+		log.Printf("Seeding default badges failed: %v", err)
+		return
+	}
+	if created > 0 {
+		log.Printf("Created %d default badges", created)
+	}
+}
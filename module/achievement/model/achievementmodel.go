@@ -0,0 +1,116 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	BadgeCollectionName     = "badges"
+	UserBadgeCollectionName = "user_badges"
+)
+
+// Rule metrics. A badge is awarded once the learner's value for its metric reaches the threshold.
+const (
+	// MetricSubmissionScore is the score of a single graded submission
+	MetricSubmissionScore = "submission_score"
+	// MetricAttempts counts every graded submission
+	MetricAttempts = "attempts"
+	// MetricChallengesCompleted counts distinct challenges with a passing best score
+	MetricChallengesCompleted = "challenges_completed"
+	// MetricTotalScore sums best scores over challenges and passage sentences
+	MetricTotalScore = "total_score"
+	// MetricSectionsCompleted counts sections whose every challenge has a passing best score
+	MetricSectionsCompleted = "sections_completed"
+	// MetricStreak is the current daily goal streak
+	MetricStreak = "streak"
+)
+
+// Rule decides when a badge is earned
+type Rule struct {
+	Metric    string  `json:"metric" bson:"metric" example:"submission_score"`
+	Threshold float64 `json:"threshold" bson:"threshold" example:"100"`
+	// Kind limits submission_score and attempts to challenge or sentence submissions
+	Kind string `json:"kind,omitempty" bson:"kind,omitempty" example:"challenge"`
+	// SectionID limits sections_completed to a single section
+	SectionID *primitive.ObjectID `json:"section_id,omitempty" bson:"section_id,omitempty"`
+}
+
+// Badge is an achievement definition. Definitions live in MongoDB so admins can add them without a deploy.
+type Badge struct {
+	ID          primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	Code        string              `json:"code" bson:"code" example:"perfect-score"`
+	Name        string              `json:"name" bson:"name" example:"Flawless"`
+	Description string              `json:"description" bson:"description"`
+	Icon        string              `json:"icon,omitempty" bson:"icon,omitempty"`
+	Rule        Rule                `json:"rule" bson:"rule"`
+	IsActive    bool                `json:"is_active" bson:"is_active"`
+	CreatedBy   *primitive.ObjectID `json:"created_by,omitempty" bson:"created_by,omitempty"`
+	CreatedAt   time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at" bson:"updated_at"`
+}
+
+func (Badge) TableName() string {
+	return BadgeCollectionName
+}
+
+// UserBadge records that a learner earned a badge. It is kept when the badge is later deactivated.
+type UserBadge struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
+	BadgeID   primitive.ObjectID `json:"badge_id" bson:"badge_id"`
+	Code      string             `json:"code" bson:"code"`
+	Value     float64            `json:"value" bson:"value"`
+	AwardedAt time.Time          `json:"awarded_at" bson:"awarded_at"`
+}
+
+func (UserBadge) TableName() string {
+	return UserBadgeCollectionName
+}
+
+// AwardedBadge is a badge as shown on a learner's profile
+type AwardedBadge struct {
+	Code        string    `json:"code" example:"perfect-score"`
+	Name        string    `json:"name" example:"Flawless"`
+	Description string    `json:"description"`
+	Icon        string    `json:"icon,omitempty"`
+	AwardedAt   time.Time `json:"awarded_at"`
+}
+
+// RuleRequest is a badge rule as sent by an admin
+type RuleRequest struct {
+	Metric    string  `json:"metric" binding:"required,oneof=submission_score attempts challenges_completed total_score sections_completed streak" example:"attempts"`
+	Threshold float64 `json:"threshold" binding:"required,gt=0" example:"50"`
+	Kind      string  `json:"kind" binding:"omitempty,oneof=challenge sentence"`
+	SectionID string  `json:"section_id"`
+}
+
+// CreateBadgeRequest defines a new badge. Learners who already qualify receive it with their next submission.
+type CreateBadgeRequest struct {
+	Code        string      `json:"code" binding:"required,max=64" example:"fifty-attempts"`
+	Name        string      `json:"name" binding:"required,max=100" example:"Persistent"`
+	Description string      `json:"description" binding:"max=500"`
+	Icon        string      `json:"icon"`
+	Rule        RuleRequest `json:"rule" binding:"required"`
+	IsActive    *bool       `json:"is_active"`
+}
+
+// UpdateBadgeRequest changes a badge definition; the code is fixed once created
+type UpdateBadgeRequest struct {
+	Name        *string      `json:"name" binding:"omitempty,max=100"`
+	Description *string      `json:"description" binding:"omitempty,max=500"`
+	Icon        *string      `json:"icon"`
+	Rule        *RuleRequest `json:"rule"`
+	IsActive    *bool        `json:"is_active"`
+}
+
+// BadgeUpdate is the $set document for a badge update
+type BadgeUpdate struct {
+	Name        *string   `bson:"name,omitempty"`
+	Description *string   `bson:"description,omitempty"`
+	Icon        *string   `bson:"icon,omitempty"`
+	Rule        *Rule     `bson:"rule,omitempty"`
+	IsActive    *bool     `bson:"is_active,omitempty"`
+	UpdatedAt   time.Time `bson:"updated_at"`
+}
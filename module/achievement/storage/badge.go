@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/achievement/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Storage) CreateBadge(ctx context.Context, data *model.Badge) error {
+	collection := s.db.MongoDB.GetCollection(model.BadgeCollectionName)
+
+	result, err := collection.InsertOne(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	data.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// EnsureBadge inserts the badge unless one with the same code exists, leaving admin edits untouched
+func (s *Storage) EnsureBadge(ctx context.Context, data *model.Badge) (bool, error) {
+	collection := s.db.MongoDB.GetCollection(model.BadgeCollectionName)
+
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"code": data.Code},
+		bson.M{"$setOnInsert": data},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return result.UpsertedCount > 0, nil
+}
+
+func (s *Storage) GetBadge(ctx context.Context, id primitive.ObjectID) (*model.Badge, error) {
+	return s.findBadge(ctx, bson.M{"_id": id})
+}
+
+func (s *Storage) GetBadgeByCode(ctx context.Context, code string) (*model.Badge, error) {
+	return s.findBadge(ctx, bson.M{"code": code})
+}
+
+func (s *Storage) findBadge(ctx context.Context, filter bson.M) (*model.Badge, error) {
+	collection := s.db.MongoDB.GetCollection(model.BadgeCollectionName)
+
+	var badge model.Badge
+	if err := collection.FindOne(ctx, filter).Decode(&badge); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &badge, nil
+}
+
+// ListBadges returns badge definitions ordered by code
+func (s *Storage) ListBadges(ctx context.Context, activeOnly bool) ([]model.Badge, error) {
+	collection := s.db.MongoDB.GetCollection(model.BadgeCollectionName)
+
+	filter := bson.M{}
+	if activeOnly {
+		filter["is_active"] = true
+	}
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.M{"code": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	badges := []model.Badge{}
+	if err = cursor.All(ctx, &badges); err != nil {
+		return nil, err
+	}
+
+	return badges, nil
+}
+
+func (s *Storage) UpdateBadge(ctx context.Context, id primitive.ObjectID, data *model.BadgeUpdate) error {
+	collection := s.db.MongoDB.GetCollection(model.BadgeCollectionName)
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": data})
+	return err
+}
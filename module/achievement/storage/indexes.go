@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/achievement/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureIndexes makes badge codes unique and lets a learner earn each badge once, so concurrent
+// creates and awards cannot store duplicates
+func (s *Storage) EnsureIndexes(ctx context.Context) error {
+	_, err := s.db.MongoDB.GetCollection(model.BadgeCollectionName).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "code", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.MongoDB.GetCollection(model.UserBadgeCollectionName).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "badge_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"context"
+	attemptmodel "hub-service/module/attempt/model"
+	challengemodel "hub-service/module/challenge/model"
+	scoremodel "hub-service/module/score/model"
+	streakmodel "hub-service/module/streak/model"
+	translationmodel "hub-service/module/translation/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CountAttempts counts the user's graded submissions, optionally of one kind
+func (s *Storage) CountAttempts(ctx context.Context, userID primitive.ObjectID, kind string) (int64, error) {
+	filter := bson.M{"user_id": userID}
+	if kind != "" {
+		filter["kind"] = kind
+	}
+	return s.db.MongoDB.GetCollection(attemptmodel.CollectionName).CountDocuments(ctx, filter)
+}
+
+// CountCompletedChallenges counts the distinct challenges the user has a best score of at least passScore for
+func (s *Storage) CountCompletedChallenges(ctx context.Context, userID primitive.ObjectID, passScore float64) (int64, error) {
+	ids, err := s.db.MongoDB.GetCollection(scoremodel.CollectionName).Distinct(ctx, "challenge_id", bson.M{
+		"user_id":    userID,
+		"best_score": bson.M{"$gte": passScore},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
+}
+
+// TotalScore sums the user's best scores over challenges and passage sentences, leaving out scores
+// with a pending or confirmed paste flag
+func (s *Storage) TotalScore(ctx context.Context, userID primitive.ObjectID) (float64, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"user_id":           userID,
+			"paste_flag.status": bson.M{"$nin": []string{scoremodel.PasteStatusPending, scoremodel.PasteStatusConfirmed}},
+		}},
+		{"$group": bson.M{"_id": nil, "total": bson.M{"$sum": "$best_score"}}},
+	}
+
+	total := 0.0
+	for _, name := range []string{scoremodel.CollectionName, translationmodel.UserTranslationScoreCollectionName} {
+		cursor, err := s.db.MongoDB.GetCollection(name).Aggregate(ctx, pipeline)
+		if err != nil {
+			return 0, err
+		}
+
+		var results []struct {
+			Total float64 `bson:"total"`
+		}
+		err = cursor.All(ctx, &results)
+		cursor.Close(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if len(results) > 0 {
+			total += results[0].Total
+		}
+	}
+	return total, nil
+}
+
+// CurrentStreak returns the user's stored daily goal streak
+func (s *Storage) CurrentStreak(ctx context.Context, userID primitive.ObjectID) (int, error) {
+	var streak struct {
+		CurrentStreak int `bson:"current_streak"`
+	}
+	err := s.db.MongoDB.GetCollection(streakmodel.CollectionName).FindOne(ctx, bson.M{"user_id": userID}).Decode(&streak)
+	if err != nil {
+		return 0, ignoreNoDocuments(err)
+	}
+	return streak.CurrentStreak, nil
+}
+
+// CompletedSections returns the sections in which the user has a best score of at least passScore
+// on every challenge, limited to sectionID when it is set
+func (s *Storage) CompletedSections(ctx context.Context, userID primitive.ObjectID, sectionID *primitive.ObjectID, passScore float64) ([]primitive.ObjectID, error) {
+	challengeMatch := bson.M{}
+	if sectionID != nil {
+		challengeMatch["section_id"] = *sectionID
+	}
+
+	sizes, err := s.countBySection(ctx, challengemodel.CollectionName, []bson.M{
+		{"$match": challengeMatch},
+		{"$group": bson.M{"_id": "$section_id", "count": bson.M{"$sum": 1}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scoredPipeline := []bson.M{
+		{"$match": bson.M{"user_id": userID, "best_score": bson.M{"$gte": passScore}}},
+		{"$group": bson.M{"_id": "$challenge_id"}},
+		{"$lookup": bson.M{
+			"from":         challengemodel.CollectionName,
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "challenge",
+		}},
+		{"$unwind": "$challenge"},
+	}
+	if sectionID != nil {
+		scoredPipeline = append(scoredPipeline, bson.M{"$match": bson.M{"challenge.section_id": *sectionID}})
+	}
+	scoredPipeline = append(scoredPipeline, bson.M{"$group": bson.M{"_id": "$challenge.section_id", "count": bson.M{"$sum": 1}}})
+
+	scored, err := s.countBySection(ctx, scoremodel.CollectionName, scoredPipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	completed := []primitive.ObjectID{}
+	for section, count := range scored {
+		if size := sizes[section]; size > 0 && count >= size {
+			completed = append(completed, section)
+		}
+	}
+	return completed, nil
+}
+
+func (s *Storage) countBySection(ctx context.Context, collection string, pipeline []bson.M) (map[primitive.ObjectID]int, error) {
+	cursor, err := s.db.MongoDB.GetCollection(collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		SectionID primitive.ObjectID `bson:"_id"`
+		Count     int                `bson:"count"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	counts := map[primitive.ObjectID]int{}
+	for _, r := range results {
+		counts[r.SectionID] = r.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"hub-service/infrastructure/database/database"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type Storage struct {
+	db *database.Database
+}
+
+func NewStorage(db *database.Database) *Storage {
+	return &Storage{db: db}
+}
+
+func ignoreNoDocuments(err error) error {
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/achievement/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Award records the badge for the user. It reports false when they already had it.
+func (s *Storage) Award(ctx context.Context, data *model.UserBadge) (bool, error) {
+	collection := s.db.MongoDB.GetCollection(model.UserBadgeCollectionName)
+
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"user_id": data.UserID, "badge_id": data.BadgeID},
+		bson.M{"$setOnInsert": data},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		// A concurrent submission awarded it first
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return result.UpsertedCount > 0, nil
+}
+
+// ListUserBadges returns the badges a user earned, oldest first
+func (s *Storage) ListUserBadges(ctx context.Context, userID primitive.ObjectID) ([]model.UserBadge, error) {
+	collection := s.db.MongoDB.GetCollection(model.UserBadgeCollectionName)
+
+	cursor, err := collection.Find(ctx, bson.M{"user_id": userID}, options.Find().SetSort(bson.M{"awarded_at": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	badges := []model.UserBadge{}
+	if err = cursor.All(ctx, &badges); err != nil {
+		return nil, err
+	}
+
+	return badges, nil
+}
+
+// ListBadgesByIDs returns the definitions of the given badges, active or not
+func (s *Storage) ListBadgesByIDs(ctx context.Context, ids []primitive.ObjectID) ([]model.Badge, error) {
+	collection := s.db.MongoDB.GetCollection(model.BadgeCollectionName)
+
+	cursor, err := collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	badges := []model.Badge{}
+	if err = cursor.All(ctx, &badges); err != nil {
+		return nil, err
+	}
+
+	return badges, nil
+}
@@ -0,0 +1,129 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+	"hub-service/module/achievement/biz"
+	"hub-service/module/achievement/model"
+	"hub-service/module/achievement/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ListBadges godoc
+// @Summary List badges
+// @Description List the active badge definitions and the rules to earn them. Admins can pass include_inactive=true to see deactivated badges too.
+// @Tags badges
+// @Produce json
+// @Security BearerAuth
+// @Param include_inactive query bool false "Include deactivated badges (admin only)"
+// @Success 200 {object} common.Response{data=[]model.Badge} "Success"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Router /api/badges [get]
+func ListBadges(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		business := biz.NewManageBadgeBiz(storage.NewStorage(appCtx.GetDatabase()))
+		result, err := business.ListBadges(c.Request.Context(), auth.IsAdmin(c) && c.Query("include_inactive") == "true")
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// CreateBadge godoc
+// @Summary Create a badge
+// @Description Define a new badge. The rule awards it once a metric (submission_score, attempts, challenges_completed, total_score, sections_completed or streak) reaches the threshold; learners who already qualify earn it with their next submission. Admin only.
+// @Tags badges
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.CreateBadgeRequest true "Badge definition"
+// @Success 200 {object} common.Response{data=model.Badge} "Success"
+// @Failure 400 {object} common.AppError "Invalid code or rule"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Failure 409 {object} common.AppError "Code already taken"
+// @Router /api/badges [post]
+func CreateBadge(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req model.CreateBadgeRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		userID := c.MustGet("user_id").(primitive.ObjectID)
+
+		business := biz.NewManageBadgeBiz(storage.NewStorage(appCtx.GetDatabase()))
+		result, err := business.CreateBadge(c.Request.Context(), &req, userID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// UpdateBadge godoc
+// @Summary Update a badge
+// @Description Change a badge's name, description, icon or rule, or deactivate it. Badges already awarded are kept. Admin only.
+// @Tags badges
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Badge ID"
+// @Param request body model.UpdateBadgeRequest true "Fields to change"
+// @Success 200 {object} common.Response{data=model.Badge} "Success"
+// @Failure 400 {object} common.AppError "Invalid rule"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Failure 404 {object} common.AppError "Badge not found"
+// @Router /api/badges/{id} [patch]
+func UpdateBadge(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := primitive.ObjectIDFromHex(c.Param("id"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		var req model.UpdateBadgeRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		business := biz.NewManageBadgeBiz(storage.NewStorage(appCtx.GetDatabase()))
+		result, err := business.UpdateBadge(c.Request.Context(), id, &req)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// ListMyBadges godoc
+// @Summary List my badges
+// @Description The badges the caller has earned, oldest first, with the time each was awarded.
+// @Tags badges
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} common.Response{data=[]model.AwardedBadge} "Success"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Router /api/badges/me [get]
+func ListMyBadges(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("user_id").(primitive.ObjectID)
+
+		business := biz.NewListUserBadgesBiz(storage.NewStorage(appCtx.GetDatabase()))
+		result, err := business.ListUserBadges(c.Request.Context(), userID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
@@ -0,0 +1,25 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
+	badges := g.Group("/badges")
+	badges.Use(auth.AuthMiddleware(appCtx))
+	{
+		badges.GET("", ListBadges(appCtx))
+		badges.GET("/me", ListMyBadges(appCtx))
+
+		admin := badges.Group("")
+		admin.Use(auth.RequireRoles(common.RoleAdmin, common.RoleSuperAdmin))
+		{
+			admin.POST("", CreateBadge(appCtx))
+			admin.PATCH("/:id", UpdateBadge(appCtx))
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hub-service/common"
+	"hub-service/module/analytics/model"
+	scoremodel "hub-service/module/score/model"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const dateLayout = "2006-01-02"
+
+// knownTypes are listed first and always present, even with no errors
+var knownTypes = []string{scoremodel.ErrorTypeGrammar, scoremodel.ErrorTypeSyntax, scoremodel.ErrorTypeVocabulary}
+
+type WeaknessStore interface {
+	ListGradedErrors(ctx context.Context, userID primitive.ObjectID, from, to time.Time) ([]model.GradedErrors, error)
+	CohortStats(ctx context.Context, from, to time.Time) (*model.CohortStats, error)
+}
+
+type weaknessBiz struct {
+	store WeaknessStore
+}
+
+func NewWeaknessBiz(store WeaknessStore) *weaknessBiz {
+	return &weaknessBiz{store: store}
+}
+
+// GetWeaknessReport breaks the learner's grading errors down by type and period, lists the
+// corrections they get most often and compares their error rates with all learners
+func (biz *weaknessBiz) GetWeaknessReport(ctx context.Context, userID primitive.ObjectID, query *model.WeaknessQuery) (*model.WeaknessReport, error) {
+	from, to, err := reportWindow(query, time.Now())
+	if err != nil {
+		return nil, common.ErrInvalidRequest(err)
+	}
+
+	interval := query.Interval
+	if interval == "" {
+		interval = model.IntervalWeek
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = model.DefaultCorrectionLimit
+	}
+
+	if periods := countPeriods(from, to, interval); periods > model.MaxPeriods {
+		return nil, common.ErrInvalidRequest(fmt.Errorf("range covers more than %d %ss", model.MaxPeriods, interval))
+	}
+
+	graded, err := biz.store.ListGradedErrors(ctx, userID, from, to)
+	if err != nil {
+		return nil, common.ErrCannotListEntity("Score", err)
+	}
+
+	cohort, err := biz.store.CohortStats(ctx, from, to)
+	if err != nil {
+		return nil, common.ErrCannotListEntity("Score", err)
+	}
+
+	counts := map[string]int{}
+	for _, g := range graded {
+		for _, e := range g.Errors {
+			counts[e.Type]++
+		}
+	}
+	types := orderedTypes(counts, cohort.Counts)
+
+	return &model.WeaknessReport{
+		UserID:         userID,
+		From:           from,
+		To:             to,
+		Interval:       interval,
+		GradedItems:    len(graded),
+		Totals:         totals(types, counts, len(graded)),
+		Timeline:       timeline(graded, interval, from, to),
+		TopCorrections: topCorrections(graded, limit),
+		Cohort:         compare(types, counts, len(graded), cohort),
+	}, nil
+}
+
+// reportWindow resolves the query to [from, to) in UTC, the last 90 days by default.
+// to is inclusive in the query, so the window ends at the start of the following day.
+func reportWindow(query *model.WeaknessQuery, now time.Time) (time.Time, time.Time, error) {
+	today := now.UTC().Truncate(24 * time.Hour)
+
+	to := today.AddDate(0, 0, 1)
+	if query.To != "" {
+		t, err := time.Parse(dateLayout, query.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to date: %w", err)
+		}
+		to = t.AddDate(0, 0, 1)
+	}
+
+	from := to.AddDate(0, 0, -model.DefaultWindowDays)
+	if query.From != "" {
+		t, err := time.Parse(dateLayout, query.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from date: %w", err)
+		}
+		from = t
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, errors.New("from must not be after to")
+	}
+	return from, to, nil
+}
+
+// orderedTypes lists the known types, then any other type seen, alphabetically
+func orderedTypes(counts ...map[string]int) []string {
+	types := append([]string{}, knownTypes...)
+	seen := map[string]bool{}
+	for _, t := range knownTypes {
+		seen[t] = true
+	}
+
+	extra := []string{}
+	for _, c := range counts {
+		for t := range c {
+			if t != "" && !seen[t] {
+				seen[t] = true
+				extra = append(extra, t)
+			}
+		}
+	}
+	sort.Strings(extra)
+	return append(types, extra...)
+}
+
+func perItem(count int, items int64) float64 {
+	if items == 0 {
+		return 0
+	}
+	return math.Round(float64(count)/float64(items)*1000) / 1000
+}
+
+func totals(types []string, counts map[string]int, items int) []model.TypeCount {
+	result := make([]model.TypeCount, 0, len(types))
+	for _, t := range types {
+		result = append(result, model.TypeCount{Type: t, Count: counts[t], PerItem: perItem(counts[t], int64(items))})
+	}
+	return result
+}
+
+func compare(types []string, counts map[string]int, items int, cohort *model.CohortStats) []model.CohortComparison {
+	result := make([]model.CohortComparison, 0, len(types))
+	for _, t := range types {
+		user := perItem(counts[t], int64(items))
+		all := perItem(cohort.Counts[t], cohort.GradedItems)
+		result = append(result, model.CohortComparison{
+			Type:          t,
+			UserPerItem:   user,
+			CohortPerItem: all,
+			Difference:    math.Round((user-all)*1000) / 1000,
+		})
+	}
+	return result
+}
+
+// timeline buckets errors by ISO week or calendar month, including periods without grades
+func timeline(graded []model.GradedErrors, interval string, from, to time.Time) []model.TimelineBucket {
+	buckets := []model.TimelineBucket{}
+	index := map[string]int{}
+	for start := periodStart(from, interval); start.Before(to); start = nextPeriod(start, interval) {
+		label := periodLabel(start, interval)
+		index[label] = len(buckets)
+		buckets = append(buckets, model.TimelineBucket{Period: label, Start: start, Counts: map[string]int{}})
+	}
+
+	for _, g := range graded {
+		i, ok := index[periodLabel(periodStart(g.GradedAt, interval), interval)]
+		if !ok {
+			continue
+		}
+		buckets[i].GradedItems++
+		for _, e := range g.Errors {
+			buckets[i].Counts[e.Type]++
+		}
+	}
+	return buckets
+}
+
+// countPeriods counts the timeline buckets of [from, to), stopping once past the cap
+func countPeriods(from, to time.Time, interval string) int {
+	n := 0
+	for start := periodStart(from, interval); start.Before(to) && n <= model.MaxPeriods; start = nextPeriod(start, interval) {
+		n++
+	}
+	return n
+}
+
+func periodStart(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	if interval == model.IntervalMonth {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	// ISO weeks start on Monday
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+func nextPeriod(start time.Time, interval string) time.Time {
+	if interval == model.IntervalMonth {
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 7)
+}
+
+func periodLabel(start time.Time, interval string) string {
+	if interval == model.IntervalMonth {
+		return start.Format("2006-01")
+	}
+	year, week := start.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// topCorrections counts suggested corrections, ignoring case and surrounding space
+func topCorrections(graded []model.GradedErrors, limit int) []model.CorrectionCount {
+	type key struct{ typ, correction string }
+	counts := map[key]int{}
+	for _, g := range graded {
+		for _, e := range g.Errors {
+			correction := strings.ToLower(strings.TrimSpace(e.Correction))
+			if correction == "" {
+				continue
+			}
+			counts[key{e.Type, correction}]++
+		}
+	}
+
+	result := make([]model.CorrectionCount, 0, len(counts))
+	for k, n := range counts {
+		result = append(result, model.CorrectionCount{Type: k.typ, Correction: k.correction, Count: n})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		if result[i].Correction != result[j].Correction {
+			return result[i].Correction < result[j].Correction
+		}
+		return result[i].Type < result[j].Type
+	})
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	scoremodel "hub-service/module/score/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Timeline intervals
+const (
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+const (
+	DefaultWindowDays      = 90
+	DefaultCorrectionLimit = 10
+	// MaxPeriods caps the number of timeline buckets in one report
+	MaxPeriods = 104
+)
+
+// WeaknessQuery selects the window of the report. Dates are UTC days, e.g. 2026-10-01.
+type WeaknessQuery struct {
+	From     string `form:"from" example:"2026-07-01"`
+	To       string `form:"to" example:"2026-10-01"`
+	Interval string `form:"interval" binding:"omitempty,oneof=week month" example:"week"`
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=50" example:"10"`
+}
+
+// GradedErrors are the errors of one graded attempt on a challenge or passage sentence
+type GradedErrors struct {
+	GradedAt time.Time            `bson:"created_at"`
+	Errors   scoremodel.ErrorList `bson:"errors"`
+}
+
+// CohortStats are error counts over every learner's graded attempts in a window
+type CohortStats struct {
+	GradedItems int64
+	Counts      map[string]int
+}
+
+// TypeCount is how often an error type occurred. PerItem is the count divided by the number of graded items.
+type TypeCount struct {
+	Type    string  `json:"type" example:"grammar"`
+	Count   int     `json:"count"`
+	PerItem float64 `json:"per_item" example:"0.8"`
+}
+
+// TimelineBucket counts errors by type in one week or month
+type TimelineBucket struct {
+	Period      string         `json:"period" example:"2026-W42"`
+	Start       time.Time      `json:"start"`
+	GradedItems int            `json:"graded_items"`
+	Counts      map[string]int `json:"counts"`
+}
+
+// CorrectionCount is a correction the grader suggested, with how often it was suggested
+type CorrectionCount struct {
+	Type       string `json:"type" example:"grammar"`
+	Correction string `json:"correction" example:"went"`
+	Count      int    `json:"count"`
+}
+
+// CohortComparison compares the learner's errors per graded item with every learner's.
+// A positive difference means the learner makes this error more often than average.
+type CohortComparison struct {
+	Type          string  `json:"type" example:"vocabulary"`
+	UserPerItem   float64 `json:"user_per_item"`
+	CohortPerItem float64 `json:"cohort_per_item"`
+	Difference    float64 `json:"difference"`
+}
+
+// WeaknessReport breaks down a learner's grading errors. Each challenge and passage sentence
+// counts with the errors of its latest grade, dated when it was graded.
+type WeaknessReport struct {
+	UserID         primitive.ObjectID `json:"user_id"`
+	From           time.Time          `json:"from"`
+	To             time.Time          `json:"to"`
+	Interval       string             `json:"interval"`
+	GradedItems    int                `json:"graded_items"`
+	Totals         []TypeCount        `json:"totals"`
+	Timeline       []TimelineBucket   `json:"timeline"`
+	TopCorrections []CorrectionCount  `json:"top_corrections"`
+	Cohort         []CohortComparison `json:"cohort"`
+}
@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/analytics/model"
+	attemptmodel "hub-service/module/attempt/model"
+	scoremodel "hub-service/module/score/model"
+	translationmodel "hub-service/module/translation/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func window(from, to time.Time) bson.M {
+	return bson.M{"created_at": bson.M{"$gte": from, "$lt": to}}
+}
+
+// ListGradedErrors returns the errors of every attempt the user made in [from, to). Attempts
+// are never overwritten, so an error fixed on a retry still counts for the attempt that made it.
+// Grades from before the attempt history existed are read from the score records, which hold
+// the errors of their latest attempt.
+func (s *Storage) ListGradedErrors(ctx context.Context, userID primitive.ObjectID, from, to time.Time) ([]model.GradedErrors, error) {
+	collection := s.db.MongoDB.GetCollection(attemptmodel.CollectionName)
+
+	filter := window(from, to)
+	filter["user_id"] = userID
+	opts := options.Find().SetProjection(bson.M{"created_at": 1, "errors": 1})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	result := []model.GradedErrors{}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+
+	challengeItem := bson.A{
+		bson.M{"$eq": bson.A{"$kind", attemptmodel.KindChallenge}},
+		bson.M{"$eq": bson.A{"$challenge_id", "$$challenge_id"}},
+	}
+	sentenceItem := bson.A{
+		bson.M{"$eq": bson.A{"$kind", attemptmodel.KindSentence}},
+		bson.M{"$eq": bson.A{"$translation_id", "$$translation_id"}},
+		bson.M{"$eq": bson.A{"$sentence_index", "$$sentence_index"}},
+	}
+	legacySources := []struct {
+		collection string
+		item       bson.A
+	}{
+		{scoremodel.CollectionName, challengeItem},
+		{translationmodel.UserTranslationScoreCollectionName, sentenceItem},
+	}
+	for _, source := range legacySources {
+		legacy, err := s.legacyGradedErrors(ctx, source.collection, source.item, userID, from, to)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, legacy...)
+	}
+	return result, nil
+}
+
+// legacyGradedErrors reads the score records last graded in [from, to) whose latest attempt is
+// missing from the attempt history, dated by their last submission
+func (s *Storage) legacyGradedErrors(ctx context.Context, collectionName string, item bson.A, userID primitive.ObjectID, from, to time.Time) ([]model.GradedErrors, error) {
+	collection := s.db.MongoDB.GetCollection(collectionName)
+
+	match := append(bson.A{
+		bson.M{"$eq": bson.A{"$user_id", userID}},
+		bson.M{"$eq": bson.A{"$attempt_number", "$$attempt_count"}},
+	}, item...)
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"user_id":    userID,
+			"updated_at": bson.M{"$gte": from, "$lt": to},
+		}},
+		{"$lookup": bson.M{
+			"from": attemptmodel.CollectionName,
+			"let": bson.M{
+				"challenge_id":   "$challenge_id",
+				"translation_id": "$translation_id",
+				"sentence_index": "$sentence_index",
+				"attempt_count":  "$attempt_count",
+			},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$and": match}}},
+				bson.M{"$limit": 1},
+				bson.M{"$project": bson.M{"_id": 1}},
+			},
+			"as": "attempt",
+		}},
+		{"$match": bson.M{"attempt": bson.M{"$size": 0}}},
+		{"$project": bson.M{"created_at": "$updated_at", "errors": 1}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	result := []model.GradedErrors{}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CohortStats counts every learner's graded attempts and errors by type in [from, to)
+func (s *Storage) CohortStats(ctx context.Context, from, to time.Time) (*model.CohortStats, error) {
+	collection := s.db.MongoDB.GetCollection(attemptmodel.CollectionName)
+	stats := &model.CohortStats{Counts: map[string]int{}}
+
+	items, err := collection.CountDocuments(ctx, window(from, to))
+	if err != nil {
+		return nil, err
+	}
+	stats.GradedItems = items
+
+	pipeline := []bson.M{
+		{"$match": window(from, to)},
+		{"$unwind": "$errors"},
+		{"$group": bson.M{"_id": "$errors.type", "count": bson.M{"$sum": 1}}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var counts []struct {
+		Type  string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+	for _, c := range counts {
+		stats.Counts[c.Type] += c.Count
+	}
+
+	return stats, nil
+}
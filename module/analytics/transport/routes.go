@@ -0,0 +1,18 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
+	analytics := g.Group("/analytics")
+	analytics.Use(auth.AuthMiddleware(appCtx))
+	{
+		analytics.GET("/weaknesses/me", GetMyWeaknessReport(appCtx))
+		analytics.GET("/weaknesses/users/:user_id", auth.RequireRoles(common.RoleAdmin, common.RoleSuperAdmin), GetUserWeaknessReport(appCtx))
+	}
+}
@@ -0,0 +1,74 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/module/analytics/biz"
+	"hub-service/module/analytics/model"
+	"hub-service/module/analytics/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetMyWeaknessReport godoc
+// @Summary Get my weakness report
+// @Description Break down the caller's grading errors (grammar, syntax, vocabulary) by week or month, list the corrections they get most often and compare their errors per graded item with all learners. Every graded attempt counts, including retries. The range may cover at most 104 weeks or months.
+// @Tags analytics
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First day, e.g. 2026-07-01 (defaults to 90 days before to)"
+// @Param to query string false "Last day, inclusive (defaults to today, UTC)"
+// @Param interval query string false "week or month" default(week)
+// @Param limit query int false "Number of top corrections" default(10)
+// @Success 200 {object} common.Response{data=model.WeaknessReport} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Router /api/analytics/weaknesses/me [get]
+func GetMyWeaknessReport(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("user_id").(primitive.ObjectID)
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(weaknessReport(c, appCtx, userID)))
+	}
+}
+
+// GetUserWeaknessReport godoc
+// @Summary Get a learner's weakness report
+// @Description The weakness report of any learner. Admin only.
+// @Tags analytics
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path string true "User ID"
+// @Param from query string false "First day, e.g. 2026-07-01 (defaults to 90 days before to)"
+// @Param to query string false "Last day, inclusive (defaults to today, UTC)"
+// @Param interval query string false "week or month" default(week)
+// @Param limit query int false "Number of top corrections" default(10)
+// @Success 200 {object} common.Response{data=model.WeaknessReport} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Router /api/analytics/weaknesses/users/{user_id} [get]
+func GetUserWeaknessReport(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := primitive.ObjectIDFromHex(c.Param("user_id"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(weaknessReport(c, appCtx, userID)))
+	}
+}
+
+func weaknessReport(c *gin.Context, appCtx appctx.AppContext, userID primitive.ObjectID) *model.WeaknessReport {
+	var query model.WeaknessQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		panic(common.ErrInvalidRequest(err))
+	}
+
+	business := biz.NewWeaknessBiz(storage.NewStorage(appCtx.GetDatabase()))
+	result, err := business.GetWeaknessReport(c.Request.Context(), userID, &query)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
@@ -0,0 +1,132 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"hub-service/common"
+	"hub-service/module/appeal/model"
+	scoremodel "hub-service/module/score/model"
+	translationmodel "hub-service/module/translation/model"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrAppealAlreadyOpen = common.NewFullErrorResponse(
+		http.StatusConflict,
+		errors.New("appeal already open"),
+		"This score is already waiting for review",
+		"appeal already open",
+		"ErrAppealAlreadyOpen",
+	)
+	ErrScoreAlreadyReviewed = common.NewErrorResponse(
+		errors.New("score already reviewed"),
+		"This score has already been reviewed by a teacher",
+		"score already reviewed",
+		"ErrScoreAlreadyReviewed",
+	)
+)
+
+type CreateAppealStore interface {
+	Create(ctx context.Context, data *model.Appeal) error
+	HasOpenAppeal(ctx context.Context, scoreID primitive.ObjectID) (bool, error)
+}
+
+type ChallengeScoreStore interface {
+	GetScoreByUserAndChallenge(ctx context.Context, userID, challengeID primitive.ObjectID) (*scoremodel.Score, error)
+}
+
+type SentenceScoreStore interface {
+	GetUserScore(ctx context.Context, userID, translationID primitive.ObjectID, sentenceIndex int) (*translationmodel.UserTranslationScore, error)
+}
+
+type createAppealBiz struct {
+	store          CreateAppealStore
+	challengeStore ChallengeScoreStore
+	sentenceStore  SentenceScoreStore
+}
+
+func NewCreateAppealBiz(store CreateAppealStore, challengeStore ChallengeScoreStore, sentenceStore SentenceScoreStore) *createAppealBiz {
+	return &createAppealBiz{
+		store:          store,
+		challengeStore: challengeStore,
+		sentenceStore:  sentenceStore,
+	}
+}
+
+// AppealChallengeScore disputes the caller's latest grade on a challenge
+func (biz *createAppealBiz) AppealChallengeScore(ctx context.Context, userID, challengeID primitive.ObjectID, req *model.CreateAppealRequest, feedbackLanguage string) (*model.Appeal, error) {
+	score, err := biz.challengeStore.GetScoreByUserAndChallenge(ctx, userID, challengeID)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("Score", err)
+	}
+	if score == nil {
+		return nil, common.ErrEntityNotFound("Score", common.RecordNotFound)
+	}
+	if score.HumanReviewed {
+		return nil, ErrScoreAlreadyReviewed
+	}
+
+	return biz.open(ctx, &model.Appeal{
+		Kind:             model.KindChallenge,
+		ScoreID:          score.ID,
+		UserID:           userID,
+		ChallengeID:      challengeID,
+		AttemptNumber:    score.AttemptCount,
+		UserTranslation:  score.UserTranslation,
+		DisputedScore:    score.Score,
+		Comment:          req.Comment,
+		FeedbackLanguage: feedbackLanguage,
+	})
+}
+
+// AppealSentenceScore disputes the caller's latest grade on one passage sentence
+func (biz *createAppealBiz) AppealSentenceScore(ctx context.Context, userID, translationID primitive.ObjectID, sentenceIndex int, req *model.CreateAppealRequest, feedbackLanguage string) (*model.Appeal, error) {
+	score, err := biz.sentenceStore.GetUserScore(ctx, userID, translationID, sentenceIndex)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, common.ErrEntityNotFound("Score", common.RecordNotFound)
+		}
+		return nil, common.ErrCannotGetEntity("Score", err)
+	}
+	if score.HumanReviewed {
+		return nil, ErrScoreAlreadyReviewed
+	}
+
+	return biz.open(ctx, &model.Appeal{
+		Kind:             model.KindSentence,
+		ScoreID:          score.ID,
+		UserID:           userID,
+		TranslationID:    translationID,
+		SentenceIndex:    sentenceIndex,
+		AttemptNumber:    score.AttemptCount,
+		UserTranslation:  score.UserTranslation,
+		DisputedScore:    score.Score,
+		Comment:          req.Comment,
+		FeedbackLanguage: feedbackLanguage,
+	})
+}
+
+func (biz *createAppealBiz) open(ctx context.Context, appeal *model.Appeal) (*model.Appeal, error) {
+	exists, err := biz.store.HasOpenAppeal(ctx, appeal.ScoreID)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("Appeal", err)
+	}
+	if exists {
+		return nil, ErrAppealAlreadyOpen
+	}
+
+	appeal.Status = model.StatusOpen
+	appeal.CreatedAt = time.Now()
+	if err := biz.store.Create(ctx, appeal); err != nil {
+		// A concurrent appeal on the same score won the unique open-appeal index
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrAppealAlreadyOpen
+		}
+		return nil, common.ErrCannotCreateEntity("Appeal", err)
+	}
+	return appeal, nil
+}
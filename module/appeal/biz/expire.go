@@ -0,0 +1,38 @@
+package biz
+
+import (
+	"context"
+	"hub-service/module/appeal/model"
+	scorebiz "hub-service/module/score/biz"
+)
+
+type ExpireAppealStore interface {
+	ExpireOpen(ctx context.Context, filter *model.OpenAppealFilter) error
+}
+
+// expirer closes the open appeal of a score once the learner submits a newer attempt, since
+// the disputed grade is no longer the latest one and the next appeal must not be refused
+type expirer struct {
+	store ExpireAppealStore
+}
+
+// NewExpirer returns the SubmissionListener that expires superseded appeals
+func NewExpirer(store ExpireAppealStore) *expirer {
+	return &expirer{store: store}
+}
+
+func (e *expirer) OnSubmission(ctx context.Context, event *scorebiz.SubmissionEvent) error {
+	filter := &model.OpenAppealFilter{
+		UserID:        event.UserID,
+		BeforeAttempt: event.AttemptCount,
+	}
+	if event.Kind == scorebiz.SubmissionKindChallenge {
+		filter.Kind = model.KindChallenge
+		filter.ChallengeID = event.ChallengeID
+	} else {
+		filter.Kind = model.KindSentence
+		filter.TranslationID = event.TranslationID
+		filter.SentenceIndex = event.SentenceIndex
+	}
+	return e.store.ExpireOpen(ctx, filter)
+}
@@ -0,0 +1,27 @@
+package biz
+
+import (
+	"context"
+	"hub-service/common"
+	"hub-service/module/appeal/model"
+)
+
+type ListAppealStore interface {
+	List(ctx context.Context, filter *model.AppealFilter, paging *common.Paging) ([]model.Appeal, error)
+}
+
+type listAppealBiz struct {
+	store ListAppealStore
+}
+
+func NewListAppealBiz(store ListAppealStore) *listAppealBiz {
+	return &listAppealBiz{store: store}
+}
+
+func (biz *listAppealBiz) ListAppeals(ctx context.Context, filter *model.AppealFilter, paging *common.Paging) ([]model.Appeal, error) {
+	result, err := biz.store.List(ctx, filter, paging)
+	if err != nil {
+		return nil, common.ErrCannotListEntity("Appeal", err)
+	}
+	return result, nil
+}
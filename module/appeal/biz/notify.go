@@ -0,0 +1,88 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"hub-service/module/appeal/model"
+	emailmodel "hub-service/module/email/model"
+	"hub-service/module/email/templates"
+	usermodel "hub-service/module/user/model"
+	"log"
+	"os"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type UserStore interface {
+	GetByID(ctx context.Context, id primitive.ObjectID) (*usermodel.User, error)
+}
+
+type EmailQueue interface {
+	QueueEmail(ctx context.Context, req *emailmodel.SendEmailRequest) (*emailmodel.EmailResponse, error)
+}
+
+// emailNotifier sends the appeal outcome through the email pipeline
+type emailNotifier struct {
+	users  UserStore
+	emails EmailQueue
+}
+
+func NewEmailNotifier(users UserStore, emails EmailQueue) *emailNotifier {
+	return &emailNotifier{users: users, emails: emails}
+}
+
+func (n *emailNotifier) AppealResolved(ctx context.Context, appeal *model.Appeal) {
+	user, err := n.users.GetByID(ctx, appeal.UserID)
+	if err != nil || user == nil || user.Email == "" {
+		log.Printf("Cannot notify appeal %s: learner not found: %v", appeal.ID.Hex(), err)
+		return
+	}
+
+	loginUrl := os.Getenv("BASE_URL_TRANSMASTER_PROD")
+	if loginUrl == "" {
+		loginUrl = "https://transmaster.site"
+	}
+
+	newScore := appeal.DisputedScore
+	if appeal.ResolvedScore != nil {
+		newScore = *appeal.ResolvedScore
+	}
+
+	htmlBody := templates.GetAppealResultEmailHTML(templates.AppealResultEmailData{
+		Name:          user.Name,
+		ItemLabel:     itemLabel(appeal),
+		Outcome:       outcomeText(appeal.Status),
+		PreviousScore: fmt.Sprintf("%.1f", appeal.DisputedScore),
+		NewScore:      fmt.Sprintf("%.1f", newScore),
+		Note:          appeal.ResolutionNote,
+		LoginUrl:      loginUrl,
+	})
+
+	_, err = n.emails.QueueEmail(ctx, &emailmodel.SendEmailRequest{
+		To:       []string{user.Email},
+		Subject:  templates.GetAppealResultEmailSubject(),
+		HTMLBody: htmlBody,
+		Priority: emailmodel.EmailPriorityNormal,
+	})
+	if err != nil {
+		log.Printf("Failed to queue appeal result email for %s: %v", user.Email, err)
+	}
+}
+
+func itemLabel(appeal *model.Appeal) string {
+	if appeal.Kind == model.KindSentence {
+		return fmt.Sprintf("Passage sentence %d", appeal.SentenceIndex+1)
+	}
+	return "Translation challenge"
+}
+
+func outcomeText(status string) string {
+	switch status {
+	case model.StatusAccepted:
+		return "Good news: a teacher reviewed your translation and agreed with your appeal. Your score has been updated."
+	case model.StatusRegraded:
+		return "A teacher had your translation graded again by a second AI model. Your score has been updated with the new result."
+	default:
+		return "A teacher reviewed your translation and decided to keep the original score."
+	}
+}
@@ -0,0 +1,281 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hub-service/common"
+	"hub-service/module/appeal/model"
+	challengemodel "hub-service/module/challenge/model"
+	overridemodel "hub-service/module/override/model"
+	scorebiz "hub-service/module/score/biz"
+	translationmodel "hub-service/module/translation/model"
+	usagebiz "hub-service/module/usage/biz"
+	usagemodel "hub-service/module/usage/model"
+	"log"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrAppealNotOpen = common.NewFullErrorResponse(
+	http.StatusConflict,
+	errors.New("appeal not open"),
+	"This appeal has already been resolved or another reviewer is resolving it",
+	"appeal not open",
+	"ErrAppealNotOpen",
+)
+
+// claimLease is how long a reviewer holds an appeal while its grade is applied; it covers a re-grade
+// by the AI provider
+const claimLease = 5 * time.Minute
+
+type ResolveAppealStore interface {
+	Get(ctx context.Context, id primitive.ObjectID) (*model.Appeal, error)
+	Claim(ctx context.Context, id primitive.ObjectID, until time.Time) (bool, error)
+	Release(ctx context.Context, id primitive.ObjectID) error
+	Resolve(ctx context.Context, id primitive.ObjectID, data *model.AppealResolution) (bool, error)
+}
+
+// ScoreOverrider applies a reviewer's grade and keeps the audit trail
+type ScoreOverrider interface {
+	OverrideChallengeScore(ctx context.Context, scoreID primitive.ObjectID, req *overridemodel.OverrideScoreRequest, reviewerID primitive.ObjectID) (*overridemodel.ScoreOverride, error)
+	OverrideSentenceScore(ctx context.Context, scoreID primitive.ObjectID, req *overridemodel.OverrideScoreRequest, reviewerID primitive.ObjectID) (*overridemodel.ScoreOverride, error)
+}
+
+type ChallengeStore interface {
+	Get(ctx context.Context, id primitive.ObjectID) (*challengemodel.Challenge, error)
+}
+
+type PassageStore interface {
+	GetTranslation(ctx context.Context, id primitive.ObjectID) (*translationmodel.Translation, error)
+	GetSentencesByTranslationID(ctx context.Context, translationID primitive.ObjectID) ([]translationmodel.TranslationSentence, error)
+}
+
+// ProviderFactory returns the grader for a named AI provider
+type ProviderFactory func(provider string) (scorebiz.GradingProvider, error)
+
+// Notifier tells the learner how their appeal ended; failures are logged, not returned
+type Notifier interface {
+	AppealResolved(ctx context.Context, appeal *model.Appeal)
+}
+
+type resolveAppealBiz struct {
+	store         ResolveAppealStore
+	overrider     ScoreOverrider
+	challenges    ChallengeStore
+	passages      PassageStore
+	providers     ProviderFactory
+	languagePairs scorebiz.LanguagePairs
+	notifier      Notifier
+}
+
+func NewResolveAppealBiz(
+	store ResolveAppealStore,
+	overrider ScoreOverrider,
+	challenges ChallengeStore,
+	passages PassageStore,
+	providers ProviderFactory,
+	languagePairs scorebiz.LanguagePairs,
+	notifier Notifier,
+) *resolveAppealBiz {
+	return &resolveAppealBiz{
+		store:         store,
+		overrider:     overrider,
+		challenges:    challenges,
+		passages:      passages,
+		providers:     providers,
+		languagePairs: languagePairs,
+		notifier:      notifier,
+	}
+}
+
+// Accept upholds the appeal with the reviewer's score
+func (biz *resolveAppealBiz) Accept(ctx context.Context, id primitive.ObjectID, req *model.AcceptAppealRequest, reviewerID primitive.ObjectID) (*model.Appeal, error) {
+	return biz.withClaim(ctx, id, func(appeal *model.Appeal) (*model.AppealResolution, error) {
+		reason := "Appeal accepted"
+		if req.Note != "" {
+			reason = fmt.Sprintf("%s: %s", reason, req.Note)
+		}
+
+		override, err := biz.override(ctx, appeal, &overridemodel.OverrideScoreRequest{
+			Score:    req.Score,
+			Feedback: req.Feedback,
+			Reason:   reason,
+			Source:   overridemodel.SourceAppeal,
+		}, reviewerID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &model.AppealResolution{
+			Status:         model.StatusAccepted,
+			ResolvedScore:  &override.Score,
+			ResolutionNote: req.Note,
+			OverrideID:     override.ID,
+			ReviewerID:     reviewerID,
+		}, nil
+	})
+}
+
+// Reject closes the appeal and keeps the original grade
+func (biz *resolveAppealBiz) Reject(ctx context.Context, id primitive.ObjectID, req *model.RejectAppealRequest, reviewerID primitive.ObjectID) (*model.Appeal, error) {
+	return biz.withClaim(ctx, id, func(appeal *model.Appeal) (*model.AppealResolution, error) {
+		return &model.AppealResolution{
+			Status:         model.StatusRejected,
+			ResolvedScore:  &appeal.DisputedScore,
+			ResolutionNote: req.Note,
+			ReviewerID:     reviewerID,
+		}, nil
+	})
+}
+
+// Regrade grades the disputed translation again with another provider and applies that grade as is
+func (biz *resolveAppealBiz) Regrade(ctx context.Context, id primitive.ObjectID, req *model.RegradeAppealRequest, reviewerID primitive.ObjectID) (*model.Appeal, error) {
+	return biz.withClaim(ctx, id, func(appeal *model.Appeal) (*model.AppealResolution, error) {
+		provider, err := biz.providers(req.Provider)
+		if err != nil {
+			return nil, err
+		}
+
+		gradeReq, err := biz.gradeRequest(ctx, appeal)
+		if err != nil {
+			return nil, err
+		}
+
+		analysis, err := provider.AnalyzeGrammar(usagebiz.WithCaller(ctx, usagemodel.FeatureAppeal, appeal.UserID), gradeReq)
+		if err != nil {
+			return nil, err
+		}
+
+		reason := fmt.Sprintf("Appeal re-graded by %s", provider.Name())
+		if req.Note != "" {
+			reason = fmt.Sprintf("%s: %s", reason, req.Note)
+		}
+
+		override, err := biz.override(ctx, appeal, &overridemodel.OverrideScoreRequest{
+			Score:    &analysis.Score,
+			Feedback: analysis.Feedback,
+			Reason:   reason,
+			Source:   overridemodel.SourceRegrade,
+		}, reviewerID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &model.AppealResolution{
+			Status:          model.StatusRegraded,
+			ResolvedScore:   &override.Score,
+			ResolutionNote:  req.Note,
+			RegradeProvider: provider.Name(),
+			OverrideID:      override.ID,
+			ReviewerID:      reviewerID,
+		}, nil
+	})
+}
+
+// withClaim claims the appeal before decide changes any grade, so a second reviewer is turned away
+// instead of overriding the score again. The appeal reopens when decide fails.
+func (biz *resolveAppealBiz) withClaim(ctx context.Context, id primitive.ObjectID, decide func(appeal *model.Appeal) (*model.AppealResolution, error)) (*model.Appeal, error) {
+	appeal, err := biz.store.Get(ctx, id)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("Appeal", err)
+	}
+	if appeal == nil {
+		return nil, common.ErrEntityNotFound("Appeal", common.RecordNotFound)
+	}
+	if appeal.Status != model.StatusOpen && appeal.Status != model.StatusResolving {
+		return nil, ErrAppealNotOpen
+	}
+
+	claimed, err := biz.store.Claim(ctx, appeal.ID, time.Now().Add(claimLease))
+	if err != nil {
+		return nil, common.ErrCannotUpdateEntity("Appeal", err)
+	}
+	if !claimed {
+		return nil, ErrAppealNotOpen
+	}
+
+	resolution, err := decide(appeal)
+	if err != nil {
+		if releaseErr := biz.store.Release(ctx, appeal.ID); releaseErr != nil {
+			log.Printf("Releasing appeal %s failed: %v", appeal.ID.Hex(), releaseErr)
+		}
+		return nil, err
+	}
+	return biz.resolve(ctx, appeal, resolution)
+}
+
+// override pins the override to the disputed attempt so a newer submission is left alone
+func (biz *resolveAppealBiz) override(ctx context.Context, appeal *model.Appeal, req *overridemodel.OverrideScoreRequest, reviewerID primitive.ObjectID) (*overridemodel.ScoreOverride, error) {
+	req.AppealID = appeal.ID
+	req.AttemptNumber = appeal.AttemptNumber
+
+	if appeal.Kind == model.KindSentence {
+		return biz.overrider.OverrideSentenceScore(ctx, appeal.ScoreID, req, reviewerID)
+	}
+	return biz.overrider.OverrideChallengeScore(ctx, appeal.ScoreID, req, reviewerID)
+}
+
+func (biz *resolveAppealBiz) resolve(ctx context.Context, appeal *model.Appeal, resolution *model.AppealResolution) (*model.Appeal, error) {
+	resolution.ResolvedAt = time.Now()
+
+	ok, err := biz.store.Resolve(ctx, appeal.ID, resolution)
+	if err != nil {
+		return nil, common.ErrCannotUpdateEntity("Appeal", err)
+	}
+	if !ok {
+		return nil, ErrAppealNotOpen
+	}
+
+	appeal.Status = resolution.Status
+	appeal.ResolvedScore = resolution.ResolvedScore
+	appeal.ResolutionNote = resolution.ResolutionNote
+	appeal.RegradeProvider = resolution.RegradeProvider
+	appeal.OverrideID = resolution.OverrideID
+	appeal.ReviewerID = resolution.ReviewerID
+	appeal.ResolvedAt = &resolution.ResolvedAt
+
+	biz.notifier.AppealResolved(ctx, appeal)
+	return appeal, nil
+}
+
+// gradeRequest rebuilds the grading input of the disputed attempt from its challenge or passage sentence
+func (biz *resolveAppealBiz) gradeRequest(ctx context.Context, appeal *model.Appeal) (*scorebiz.GradeRequest, error) {
+	if appeal.Kind == model.KindChallenge {
+		challenge, err := biz.challenges.Get(ctx, appeal.ChallengeID)
+		if err != nil {
+			return nil, common.ErrCannotGetEntity("Challenge", err)
+		}
+
+		gradeReq, err := biz.languagePairs.NewGradeRequest(challenge.Content, appeal.UserTranslation, challenge.SourceLang, challenge.TargetLang, appeal.FeedbackLanguage)
+		if err != nil {
+			return nil, err
+		}
+		gradeReq.References = challenge.GradingReferences()
+		gradeReq.MachineTranslation = challenge.MachineTranslation
+		return gradeReq, nil
+	}
+
+	translation, err := biz.passages.GetTranslation(ctx, appeal.TranslationID)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("Translation", err)
+	}
+	sentences, err := biz.passages.GetSentencesByTranslationID(ctx, appeal.TranslationID)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("TranslationSentence", err)
+	}
+	if appeal.SentenceIndex >= len(sentences) {
+		return nil, common.ErrEntityNotFound("TranslationSentence", common.RecordNotFound)
+	}
+	sentence := sentences[appeal.SentenceIndex]
+
+	gradeReq, err := biz.languagePairs.NewGradeRequest(sentence.Content, appeal.UserTranslation, translation.SourceLang, translation.TargetLang, appeal.FeedbackLanguage)
+	if err != nil {
+		return nil, err
+	}
+	gradeReq.References = sentence.GradingReferences()
+	gradeReq.MachineTranslation = sentence.MachineTranslation
+	return gradeReq, nil
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const CollectionName = "score_appeals"
+
+// Appeal kinds, matching the attempt kinds
+const (
+	KindChallenge = "challenge"
+	KindSentence  = "sentence"
+)
+
+// Appeal statuses; every status but open and resolving is final
+const (
+	StatusOpen = "open"
+	// StatusResolving holds an appeal while a reviewer's grade is applied; it reopens when that fails
+	StatusResolving = "resolving"
+	StatusAccepted  = "accepted"
+	StatusRejected  = "rejected"
+	StatusRegraded  = "regraded"
+	// StatusExpired closes an appeal whose attempt was superseded by a newer submission
+	StatusExpired = "expired"
+)
+
+// Appeal is a learner's dispute of one graded attempt, reviewed by an admin.
+// The disputed attempt is pinned by AttemptNumber; resubmitting expires the appeal.
+type Appeal struct {
+	ID               primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Kind             string             `json:"kind" bson:"kind"`
+	ScoreID          primitive.ObjectID `json:"score_id" bson:"score_id"`
+	UserID           primitive.ObjectID `json:"user_id" bson:"user_id"`
+	ChallengeID      primitive.ObjectID `json:"challenge_id,omitempty" bson:"challenge_id,omitempty"`
+	TranslationID    primitive.ObjectID `json:"translation_id,omitempty" bson:"translation_id,omitempty"`
+	SentenceIndex    int                `json:"sentence_index" bson:"sentence_index"`
+	AttemptNumber    int                `json:"attempt_number" bson:"attempt_number"`
+	UserTranslation  string             `json:"user_translation" bson:"user_translation"`
+	DisputedScore    float64            `json:"disputed_score" bson:"disputed_score"`
+	Comment          string             `json:"comment" bson:"comment"`
+	FeedbackLanguage string             `json:"-" bson:"feedback_language"`
+	Status           string             `json:"status" bson:"status"`
+	ResolvedScore    *float64           `json:"resolved_score,omitempty" bson:"resolved_score,omitempty"`
+	ResolutionNote   string             `json:"resolution_note,omitempty" bson:"resolution_note,omitempty"`
+	RegradeProvider  string             `json:"regrade_provider,omitempty" bson:"regrade_provider,omitempty"`
+	OverrideID       primitive.ObjectID `json:"override_id,omitempty" bson:"override_id,omitempty"`
+	ReviewerID       primitive.ObjectID `json:"reviewer_id,omitempty" bson:"reviewer_id,omitempty"`
+	ClaimedUntil     *time.Time         `json:"-" bson:"claimed_until,omitempty"`
+	CreatedAt        time.Time          `json:"created_at" bson:"created_at"`
+	ResolvedAt       *time.Time         `json:"resolved_at,omitempty" bson:"resolved_at,omitempty"`
+}
+
+func (Appeal) TableName() string {
+	return CollectionName
+}
+
+// OpenAppealFilter selects the open appeals on one challenge or passage sentence whose disputed
+// attempt is older than BeforeAttempt
+type OpenAppealFilter struct {
+	Kind          string
+	UserID        primitive.ObjectID
+	ChallengeID   primitive.ObjectID
+	TranslationID primitive.ObjectID
+	SentenceIndex int
+	BeforeAttempt int
+}
+
+// AppealResolution is the outcome written when a claimed appeal is closed
+type AppealResolution struct {
+	Status          string             `bson:"status"`
+	ResolvedScore   *float64           `bson:"resolved_score,omitempty"`
+	ResolutionNote  string             `bson:"resolution_note,omitempty"`
+	RegradeProvider string             `bson:"regrade_provider,omitempty"`
+	OverrideID      primitive.ObjectID `bson:"override_id,omitempty"`
+	ReviewerID      primitive.ObjectID `bson:"reviewer_id"`
+	ResolvedAt      time.Time          `bson:"resolved_at"`
+}
+
+// CreateAppealRequest disputes the caller's latest grade on a challenge or sentence
+type CreateAppealRequest struct {
+	Comment string `json:"comment" binding:"required,max=2000" example:"\"Big\" and \"large\" are both correct here"`
+}
+
+// AcceptAppealRequest upholds the appeal and overrides the grade. Feedback is kept when empty.
+type AcceptAppealRequest struct {
+	Score    *float64 `json:"score" binding:"required,min=0,max=100" example:"90"`
+	Feedback string   `json:"feedback"`
+	Note     string   `json:"note"`
+}
+
+// RejectAppealRequest keeps the original grade; the note is sent to the learner
+type RejectAppealRequest struct {
+	Note string `json:"note" binding:"required"`
+}
+
+// RegradeAppealRequest grades the disputed translation again with another provider and applies that grade
+type RegradeAppealRequest struct {
+	Provider string `json:"provider" binding:"required" example:"openai"`
+	Note     string `json:"note"`
+}
+
+// AppealFilter narrows appeal lists; zero values match everything
+type AppealFilter struct {
+	UserID primitive.ObjectID
+	Status string
+	Kind   string
+}
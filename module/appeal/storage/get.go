@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"hub-service/common"
+	"hub-service/module/appeal/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Storage) Get(ctx context.Context, id primitive.ObjectID) (*model.Appeal, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	var appeal model.Appeal
+	if err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&appeal); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &appeal, nil
+}
+
+// HasOpenAppeal reports whether the score record already has an appeal waiting for review or being resolved
+func (s *Storage) HasOpenAppeal(ctx context.Context, scoreID primitive.ObjectID) (bool, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	count, err := collection.CountDocuments(ctx, bson.M{
+		"score_id": scoreID,
+		"status":   bson.M{"$in": bson.A{model.StatusOpen, model.StatusResolving}},
+	}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// List returns appeals newest first, except the open queue which is served oldest first
+func (s *Storage) List(ctx context.Context, filter *model.AppealFilter, paging *common.Paging) ([]model.Appeal, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	query := bson.M{}
+	if !filter.UserID.IsZero() {
+		query["user_id"] = filter.UserID
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	if filter.Kind != "" {
+		query["kind"] = filter.Kind
+	}
+
+	order := -1
+	if filter.Status == model.StatusOpen {
+		order = 1
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSkip(int64((paging.Page - 1) * paging.Limit))
+	findOptions.SetLimit(int64(paging.Limit))
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: order}})
+
+	cursor, err := collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	appeals := []model.Appeal{}
+	if err = cursor.All(ctx, &appeals); err != nil {
+		return nil, err
+	}
+
+	total, err := collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	paging.Total = total
+
+	return appeals, nil
+}
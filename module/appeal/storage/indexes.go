@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/appeal/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureIndexes allows a single open appeal per score, so two concurrent appeals cannot both
+// pass the HasOpenAppeal check
+func (s *Storage) EnsureIndexes(ctx context.Context) error {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "score_id", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"status": model.StatusOpen}),
+	})
+	return err
+}
@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/appeal/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Claim moves an open appeal to resolving until the claim runs out. It reports false when the appeal
+// is neither open nor held by a claim that has run out, so two reviewers cannot resolve it at once.
+func (s *Storage) Claim(ctx context.Context, id primitive.ObjectID, until time.Time) (bool, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": id, "$or": bson.A{
+			bson.M{"status": model.StatusOpen},
+			bson.M{"status": model.StatusResolving, "claimed_until": bson.M{"$lt": time.Now()}},
+		}},
+		bson.M{"$set": bson.M{"status": model.StatusResolving, "claimed_until": until}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// Release reopens a claimed appeal whose resolution failed
+func (s *Storage) Release(ctx context.Context, id primitive.ObjectID) error {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	_, err := collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": model.StatusResolving},
+		bson.M{
+			"$set":   bson.M{"status": model.StatusOpen},
+			"$unset": bson.M{"claimed_until": ""},
+		},
+	)
+	return err
+}
+
+// Resolve closes a claimed appeal. It reports false when the claim was lost, e.g. because the
+// appeal expired in the meantime.
+func (s *Storage) Resolve(ctx context.Context, id primitive.ObjectID, data *model.AppealResolution) (bool, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": model.StatusResolving},
+		bson.M{
+			"$set":   data,
+			"$unset": bson.M{"claimed_until": ""},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// ExpireOpen closes the open and claimed appeals matching the filter
+func (s *Storage) ExpireOpen(ctx context.Context, filter *model.OpenAppealFilter) error {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	query := bson.M{
+		"kind":           filter.Kind,
+		"user_id":        filter.UserID,
+		"status":         bson.M{"$in": bson.A{model.StatusOpen, model.StatusResolving}},
+		"attempt_number": bson.M{"$lt": filter.BeforeAttempt},
+	}
+	if filter.Kind == model.KindChallenge {
+		query["challenge_id"] = filter.ChallengeID
+	} else {
+		query["translation_id"] = filter.TranslationID
+		query["sentence_index"] = filter.SentenceIndex
+	}
+
+	_, err := collection.UpdateMany(ctx, query, bson.M{
+		"$set": bson.M{
+			"status":          model.StatusExpired,
+			"resolution_note": "Superseded by a newer attempt",
+			"resolved_at":     time.Now(),
+		},
+		"$unset": bson.M{"claimed_until": ""},
+	})
+	return err
+}
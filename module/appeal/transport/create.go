@@ -0,0 +1,103 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/module/appeal/biz"
+	"hub-service/module/appeal/model"
+	"hub-service/module/appeal/storage"
+	"hub-service/module/grading"
+	scorestorage "hub-service/module/score/storage"
+	translationstorage "hub-service/module/translation/storage"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AppealChallengeScore godoc
+// @Summary Dispute a challenge score
+// @Description Open a review ticket for the caller's latest graded attempt on a challenge. Only one appeal per score can be open, and grades already reviewed by a teacher cannot be appealed.
+// @Tags appeals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param challenge_id path string true "Challenge ID"
+// @Param request body model.CreateAppealRequest true "Why the grade is wrong"
+// @Success 200 {object} common.Response{data=model.Appeal} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 404 {object} common.AppError "No score for this challenge"
+// @Failure 409 {object} common.AppError "Appeal already open"
+// @Router /api/appeals/challenges/{challenge_id} [post]
+func AppealChallengeScore(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		challengeID, err := primitive.ObjectIDFromHex(c.Param("challenge_id"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		var req model.CreateAppealRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		userID := c.MustGet("user_id").(primitive.ObjectID)
+
+		db := appCtx.GetDatabase()
+		business := biz.NewCreateAppealBiz(storage.NewStorage(db), scorestorage.NewStorage(db), translationstorage.NewStorage(db))
+		result, err := business.AppealChallengeScore(c.Request.Context(), userID, challengeID, &req, grading.FeedbackLanguage(c, appCtx))
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// AppealSentenceScore godoc
+// @Summary Dispute a passage sentence score
+// @Description Open a review ticket for the caller's latest graded attempt on one sentence of a translation. Only one appeal per score can be open, and grades already reviewed by a teacher cannot be appealed.
+// @Tags appeals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param translation_id path string true "Translation ID"
+// @Param sentence_index path int true "Sentence index"
+// @Param request body model.CreateAppealRequest true "Why the grade is wrong"
+// @Success 200 {object} common.Response{data=model.Appeal} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 404 {object} common.AppError "No score for this sentence"
+// @Failure 409 {object} common.AppError "Appeal already open"
+// @Router /api/appeals/translations/{translation_id}/sentences/{sentence_index} [post]
+func AppealSentenceScore(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		translationID, err := primitive.ObjectIDFromHex(c.Param("translation_id"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		sentenceIndex, err := strconv.Atoi(c.Param("sentence_index"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		var req model.CreateAppealRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		userID := c.MustGet("user_id").(primitive.ObjectID)
+
+		db := appCtx.GetDatabase()
+		business := biz.NewCreateAppealBiz(storage.NewStorage(db), scorestorage.NewStorage(db), translationstorage.NewStorage(db))
+		result, err := business.AppealSentenceScore(c.Request.Context(), userID, translationID, sentenceIndex, &req, grading.FeedbackLanguage(c, appCtx))
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
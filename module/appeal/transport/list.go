@@ -0,0 +1,89 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/module/appeal/biz"
+	"hub-service/module/appeal/model"
+	"hub-service/module/appeal/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ListMyAppeals godoc
+// @Summary List my appeals
+// @Description The caller's score appeals and their outcomes, newest first.
+// @Tags appeals
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "open, resolving, accepted, rejected, regraded or expired"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Success 200 {object} common.Response{data=[]model.Appeal,meta=common.Paging} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Router /api/appeals/me [get]
+func ListMyAppeals(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter := &model.AppealFilter{
+			UserID: c.MustGet("user_id").(primitive.ObjectID),
+			Status: c.Query("status"),
+		}
+
+		listAppeals(c, appCtx, filter)
+	}
+}
+
+// ListAppeals godoc
+// @Summary Appeal review queue
+// @Description Score appeals filtered by status (open by default). Open appeals are listed oldest first so the queue is worked in order. Admin only.
+// @Tags appeals
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "open, resolving, accepted, rejected, regraded or expired" default(open)
+// @Param kind query string false "challenge or sentence"
+// @Param user_id query string false "Learner ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Success 200 {object} common.Response{data=[]model.Appeal,meta=common.Paging} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Router /api/appeals [get]
+func ListAppeals(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter := &model.AppealFilter{
+			Status: c.DefaultQuery("status", model.StatusOpen),
+			Kind:   c.Query("kind"),
+		}
+		if userID := c.Query("user_id"); userID != "" {
+			id, err := primitive.ObjectIDFromHex(userID)
+			if err != nil {
+				panic(common.ErrInvalidRequest(err))
+			}
+			filter.UserID = id
+		}
+
+		listAppeals(c, appCtx, filter)
+	}
+}
+
+func listAppeals(c *gin.Context, appCtx appctx.AppContext, filter *model.AppealFilter) {
+	var paging common.Paging
+	if err := c.ShouldBind(&paging); err != nil {
+		panic(common.ErrInvalidRequest(err))
+	}
+	paging.Fulfill()
+
+	store := storage.NewStorage(appCtx.GetDatabase())
+	business := biz.NewListAppealBiz(store)
+
+	result, err := business.ListAppeals(c.Request.Context(), filter, &paging)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, common.NewSuccessResponse(result, paging, nil))
+}
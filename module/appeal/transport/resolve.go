@@ -0,0 +1,184 @@
+package transport
+
+import (
+	"context"
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/module/appeal/biz"
+	"hub-service/module/appeal/model"
+	"hub-service/module/appeal/storage"
+	attemptstorage "hub-service/module/attempt/storage"
+	challengestorage "hub-service/module/challenge/storage"
+	emailbiz "hub-service/module/email/biz"
+	emailrepository "hub-service/module/email/repository"
+	"hub-service/module/grading"
+	overridebiz "hub-service/module/override/biz"
+	overridestorage "hub-service/module/override/storage"
+	scorebiz "hub-service/module/score/biz"
+	scorestorage "hub-service/module/score/storage"
+	translationstorage "hub-service/module/translation/storage"
+	userstorage "hub-service/module/user/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AcceptAppeal godoc
+// @Summary Accept an appeal
+// @Description Uphold the appeal and override the disputed grade with the reviewer's score. The override is audited and the learner is emailed. Admin only.
+// @Tags appeals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Appeal ID"
+// @Param request body model.AcceptAppealRequest true "New score"
+// @Success 200 {object} common.Response{data=model.Appeal} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Failure 404 {object} common.AppError "Appeal not found"
+// @Failure 409 {object} common.AppError "Appeal already resolved or score resubmitted"
+// @Router /api/appeals/{id}/accept [post]
+func AcceptAppeal(appCtx appctx.AppContext) gin.HandlerFunc {
+	pairs := scorebiz.SupportedLanguagePairs()
+
+	return func(c *gin.Context) {
+		id := appealID(c)
+
+		var req model.AcceptAppealRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		reviewerID := c.MustGet("user_id").(primitive.ObjectID)
+
+		result, err := newResolveAppealBiz(appCtx, pairs).Accept(c.Request.Context(), id, &req, reviewerID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// RejectAppeal godoc
+// @Summary Reject an appeal
+// @Description Close the appeal and keep the original grade. The note is emailed to the learner. Admin only.
+// @Tags appeals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Appeal ID"
+// @Param request body model.RejectAppealRequest true "Reason for keeping the grade"
+// @Success 200 {object} common.Response{data=model.Appeal} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Failure 404 {object} common.AppError "Appeal not found"
+// @Failure 409 {object} common.AppError "Appeal already resolved"
+// @Router /api/appeals/{id}/reject [post]
+func RejectAppeal(appCtx appctx.AppContext) gin.HandlerFunc {
+	pairs := scorebiz.SupportedLanguagePairs()
+
+	return func(c *gin.Context) {
+		id := appealID(c)
+
+		var req model.RejectAppealRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		reviewerID := c.MustGet("user_id").(primitive.ObjectID)
+
+		result, err := newResolveAppealBiz(appCtx, pairs).Reject(c.Request.Context(), id, &req, reviewerID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// RegradeAppeal godoc
+// @Summary Re-grade an appeal with another model
+// @Description Grade the disputed translation again with a different AI provider (gemini, openai, ollama) and apply that grade. The override is audited and the learner is emailed. Admin only.
+// @Tags appeals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Appeal ID"
+// @Param request body model.RegradeAppealRequest true "Provider to re-grade with"
+// @Success 200 {object} common.Response{data=model.Appeal} "Success"
+// @Failure 400 {object} common.AppError "Bad request or provider not configured"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Failure 404 {object} common.AppError "Appeal not found"
+// @Failure 409 {object} common.AppError "Appeal already resolved or score resubmitted"
+// @Failure 503 {object} common.AppError "AI provider unavailable"
+// @Router /api/appeals/{id}/regrade [post]
+func RegradeAppeal(appCtx appctx.AppContext) gin.HandlerFunc {
+	pairs := scorebiz.SupportedLanguagePairs()
+
+	return func(c *gin.Context) {
+		id := appealID(c)
+
+		var req model.RegradeAppealRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		reviewerID := c.MustGet("user_id").(primitive.ObjectID)
+
+		result, err := newResolveAppealBiz(appCtx, pairs).Regrade(c.Request.Context(), id, &req, reviewerID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+func appealID(c *gin.Context) primitive.ObjectID {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		panic(common.ErrInvalidRequest(err))
+	}
+	return id
+}
+
+// resolver is the resolve biz as used by the handlers above
+type resolver interface {
+	Accept(ctx context.Context, id primitive.ObjectID, req *model.AcceptAppealRequest, reviewerID primitive.ObjectID) (*model.Appeal, error)
+	Reject(ctx context.Context, id primitive.ObjectID, req *model.RejectAppealRequest, reviewerID primitive.ObjectID) (*model.Appeal, error)
+	Regrade(ctx context.Context, id primitive.ObjectID, req *model.RegradeAppealRequest, reviewerID primitive.ObjectID) (*model.Appeal, error)
+}
+
+func newResolveAppealBiz(appCtx appctx.AppContext, pairs scorebiz.LanguagePairs) resolver {
+	db := appCtx.GetDatabase()
+
+	overrider := overridebiz.NewOverrideScoreBiz(
+		overridestorage.NewStorage(db),
+		scorestorage.NewStorage(db),
+		translationstorage.NewStorage(db),
+		attemptstorage.NewStorage(db),
+		grading.ScoreChangeListeners(appCtx)...,
+	)
+
+	emails := emailbiz.NewEmailBusiness(emailrepository.NewEmailRepository(db.MongoDB.Database), appCtx.GetKafka(), appCtx.GetRedis())
+	notifier := biz.NewEmailNotifier(userstorage.NewUserStorage(appCtx), emails)
+
+	providers := func(provider string) (scorebiz.GradingProvider, error) {
+		return grading.NewRegradeProvider(appCtx, provider)
+	}
+
+	return biz.NewResolveAppealBiz(
+		storage.NewStorage(db),
+		overrider,
+		challengestorage.NewStorage(db),
+		translationstorage.NewStorage(db),
+		providers,
+		pairs,
+		notifier,
+	)
+}
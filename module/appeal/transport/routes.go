@@ -0,0 +1,30 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
+	appeals := g.Group("/appeals")
+	appeals.Use(auth.AuthMiddleware(appCtx))
+	{
+		appeals.GET("/me", ListMyAppeals(appCtx))
+		appeals.POST("/challenges/:challenge_id", AppealChallengeScore(appCtx))
+		appeals.POST("/translations/:translation_id/sentences/:sentence_index", AppealSentenceScore(appCtx))
+	}
+
+	// Review queue
+	review := g.Group("/appeals")
+	review.Use(auth.AuthMiddleware(appCtx))
+	review.Use(auth.RequireRoles(common.RoleAdmin, common.RoleSuperAdmin))
+	{
+		review.GET("", ListAppeals(appCtx))
+		review.POST("/:id/accept", AcceptAppeal(appCtx))
+		review.POST("/:id/reject", RejectAppeal(appCtx))
+		review.POST("/:id/regrade", RegradeAppeal(appCtx))
+	}
+}
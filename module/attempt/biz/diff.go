@@ -0,0 +1,159 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"hub-service/common"
+	"hub-service/module/attempt/model"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrAttemptsNotComparable = common.NewErrorResponse(
+	errors.New("attempts belong to different items"),
+	"Only attempts on the same challenge or sentence can be compared",
+	"attempts belong to different items",
+	"ErrAttemptsNotComparable",
+)
+
+type GetAttemptStore interface {
+	Get(ctx context.Context, id primitive.ObjectID) (*model.Attempt, error)
+}
+
+type diffAttemptBiz struct {
+	store GetAttemptStore
+}
+
+func NewDiffAttemptBiz(store GetAttemptStore) *diffAttemptBiz {
+	return &diffAttemptBiz{store: store}
+}
+
+// DiffAttempts compares two attempts owned by requesterID; admins may compare anyone's attempts
+func (biz *diffAttemptBiz) DiffAttempts(ctx context.Context, fromID, toID, requesterID primitive.ObjectID, isAdmin bool) (*model.AttemptDiff, error) {
+	from, err := biz.getOwned(ctx, fromID, requesterID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := biz.getOwned(ctx, toID, requesterID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sameItem(from, to) {
+		return nil, ErrAttemptsNotComparable
+	}
+
+	return &model.AttemptDiff{
+		From:           *from,
+		To:             *to,
+		ScoreDelta:     to.Score - from.Score,
+		TranslationOps: diffWords(from.UserTranslation, to.UserTranslation),
+		FixedErrors:    subtractErrors(from.Errors, to.Errors),
+		NewErrors:      subtractErrors(to.Errors, from.Errors),
+	}, nil
+}
+
+func (biz *diffAttemptBiz) getOwned(ctx context.Context, id, requesterID primitive.ObjectID, isAdmin bool) (*model.Attempt, error) {
+	attempt, err := biz.store.Get(ctx, id)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("Attempt", err)
+	}
+	if attempt == nil {
+		return nil, common.ErrEntityNotFound("Attempt", common.RecordNotFound)
+	}
+	if !isAdmin && attempt.UserID != requesterID {
+		return nil, common.ErrNoPermission(errors.New("attempt belongs to another user"))
+	}
+	return attempt, nil
+}
+
+func sameItem(a, b *model.Attempt) bool {
+	if a.UserID != b.UserID || a.Kind != b.Kind {
+		return false
+	}
+	if a.Kind == model.KindChallenge {
+		return a.ChallengeID == b.ChallengeID
+	}
+	return a.TranslationID == b.TranslationID && a.SentenceIndex == b.SentenceIndex
+}
+
+// subtractErrors returns the errors in a that have no counterpart in b
+func subtractErrors(a, b []model.AttemptError) []model.AttemptError {
+	seen := make(map[string]int, len(b))
+	for _, e := range b {
+		seen[errorKey(e)]++
+	}
+
+	result := []model.AttemptError{}
+	for _, e := range a {
+		key := errorKey(e)
+		if seen[key] > 0 {
+			seen[key]--
+			continue
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
+// errorKey ignores position and description, which shift as the sentence is edited
+func errorKey(e model.AttemptError) string {
+	return strings.ToLower(e.Type) + "|" + strings.ToLower(strings.TrimSpace(e.Correction))
+}
+
+// diffWords computes a word-level diff using the longest common subsequence
+func diffWords(from, to string) []model.DiffOp {
+	a := strings.Fields(from)
+	b := strings.Fields(to)
+
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops := []model.DiffOp{}
+	push := func(op, word string) {
+		if n := len(ops); n > 0 && ops[n-1].Op == op {
+			ops[n-1].Text += " " + word
+			return
+		}
+		ops = append(ops, model.DiffOp{Op: op, Text: word})
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			push("equal", a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			push("delete", a[i])
+			i++
+		default:
+			push("insert", b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		push("delete", a[i])
+	}
+	for ; j < len(b); j++ {
+		push("insert", b[j])
+	}
+
+	return ops
+}
@@ -0,0 +1,27 @@
+package biz
+
+import (
+	"context"
+	"hub-service/common"
+	"hub-service/module/attempt/model"
+)
+
+type ListAttemptStore interface {
+	List(ctx context.Context, filter *model.AttemptFilter, paging *common.Paging) ([]model.Attempt, error)
+}
+
+type listAttemptBiz struct {
+	store ListAttemptStore
+}
+
+func NewListAttemptBiz(store ListAttemptStore) *listAttemptBiz {
+	return &listAttemptBiz{store: store}
+}
+
+func (biz *listAttemptBiz) ListAttempts(ctx context.Context, filter *model.AttemptFilter, paging *common.Paging) ([]model.Attempt, error) {
+	result, err := biz.store.List(ctx, filter, paging)
+	if err != nil {
+		return nil, common.ErrCannotListEntity("Attempt", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,62 @@
+package biz
+
+import (
+	"context"
+	"hub-service/module/attempt/model"
+	scorebiz "hub-service/module/score/biz"
+)
+
+type CreateAttemptStore interface {
+	Create(ctx context.Context, data *model.Attempt) error
+}
+
+// recorder appends every graded submission to the attempt history
+type recorder struct {
+	store CreateAttemptStore
+}
+
+func NewRecorder(store CreateAttemptStore) *recorder {
+	return &recorder{store: store}
+}
+
+func (r *recorder) OnSubmission(ctx context.Context, event *scorebiz.SubmissionEvent) error {
+	attempt := &model.Attempt{
+		UserID:          event.UserID,
+		Kind:            event.Kind,
+		ChallengeID:     event.ChallengeID,
+		SectionID:       event.SectionID,
+		TranslationID:   event.TranslationID,
+		SentenceID:      event.SentenceID,
+		SentenceIndex:   event.SentenceIndex,
+		AttemptNumber:   event.AttemptCount,
+		OriginalContent: event.OriginalContent,
+		UserTranslation: event.UserTranslation,
+		PreviousBest:    event.PreviousBest,
+		IsNewBest:       event.IsNewBest,
+		HintsUsed:       event.HintsUsed,
+		HintPenalty:     event.HintPenalty,
+		CreatedAt:       event.SubmittedAt,
+		Errors:          []model.AttemptError{},
+		Suggestions:     []string{},
+	}
+
+	if analysis := event.Analysis; analysis != nil {
+		attempt.Score = analysis.Score
+		attempt.Feedback = analysis.Feedback
+		attempt.Provider = analysis.Provider
+		attempt.PromptVersion = analysis.PromptVersion
+		for _, e := range analysis.Errors {
+			attempt.Errors = append(attempt.Errors, model.AttemptError{
+				Type:        e.Type,
+				Description: e.Description,
+				Position:    e.Position,
+				Correction:  e.Correction,
+			})
+		}
+		if analysis.Suggestions != nil {
+			attempt.Suggestions = analysis.Suggestions
+		}
+	}
+
+	return r.store.Create(ctx, attempt)
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const CollectionName = "score_attempts"
+
+// Attempt kinds
+const (
+	KindChallenge = "challenge"
+	KindSentence  = "sentence"
+)
+
+// AttemptError is a single grading error stored as structured BSON
+type AttemptError struct {
+	Type        string `json:"type" bson:"type"`
+	Description string `json:"description" bson:"description"`
+	Position    int    `json:"position" bson:"position"`
+	Correction  string `json:"correction" bson:"correction"`
+}
+
+// Attempt is a record of one graded submission.
+// Unlike Score and UserTranslationScore it is never replaced, so every translation and its feedback is kept.
+// Only Score is corrected when a reviewer or an applied re-grade changes the grade; GradedScore keeps the original.
+type Attempt struct {
+	ID              primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID          primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Kind            string             `json:"kind" bson:"kind"`
+	ChallengeID     primitive.ObjectID `json:"challenge_id,omitempty" bson:"challenge_id,omitempty"`
+	SectionID       primitive.ObjectID `json:"section_id,omitempty" bson:"section_id,omitempty"`
+	TranslationID   primitive.ObjectID `json:"translation_id,omitempty" bson:"translation_id,omitempty"`
+	SentenceID      primitive.ObjectID `json:"sentence_id,omitempty" bson:"sentence_id,omitempty"`
+	SentenceIndex   int                `json:"sentence_index" bson:"sentence_index"`
+	AttemptNumber   int                `json:"attempt_number" bson:"attempt_number"`
+	OriginalContent string             `json:"original_content" bson:"original_content"`
+	UserTranslation string             `json:"user_translation" bson:"user_translation"`
+	Score           float64            `json:"score" bson:"score"`
+	Feedback        string             `json:"feedback" bson:"feedback"`
+	Errors          []AttemptError     `json:"errors" bson:"errors"`
+	Suggestions     []string           `json:"suggestions" bson:"suggestions"`
+	Provider        string             `json:"provider" bson:"provider"`
+	PromptVersion   string             `json:"prompt_version" bson:"prompt_version"`
+	PreviousBest    float64            `json:"previous_best" bson:"previous_best"`
+	IsNewBest       bool               `json:"is_new_best" bson:"is_new_best"`
+	HintsUsed       int                `json:"hints_used" bson:"hints_used"`
+	HintPenalty     float64            `json:"hint_penalty" bson:"hint_penalty"`
+	GradedScore     *float64           `json:"graded_score,omitempty" bson:"graded_score,omitempty"`
+	CorrectedAt     *time.Time         `json:"corrected_at,omitempty" bson:"corrected_at,omitempty"`
+	CreatedAt       time.Time          `json:"created_at" bson:"created_at"`
+}
+
+func (Attempt) TableName() string {
+	return CollectionName
+}
+
+// AttemptFilter selects a user's attempts for one challenge or one passage sentence
+type AttemptFilter struct {
+	UserID        primitive.ObjectID
+	Kind          string
+	ChallengeID   primitive.ObjectID
+	TranslationID primitive.ObjectID
+	SentenceIndex int
+}
+
+// DiffOp is one word-level edit between two translations
+type DiffOp struct {
+	Op   string `json:"op" example:"equal"` // equal | insert | delete
+	Text string `json:"text"`
+}
+
+// AttemptDiff compares two attempts on the same challenge or sentence
+type AttemptDiff struct {
+	From           Attempt        `json:"from"`
+	To             Attempt        `json:"to"`
+	ScoreDelta     float64        `json:"score_delta"`
+	TranslationOps []DiffOp       `json:"translation_ops"`
+	FixedErrors    []AttemptError `json:"fixed_errors"`
+	NewErrors      []AttemptError `json:"new_errors"`
+}
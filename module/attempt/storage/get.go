@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"hub-service/common"
+	"hub-service/module/attempt/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Storage) Get(ctx context.Context, id primitive.ObjectID) (*model.Attempt, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	var attempt model.Attempt
+	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&attempt)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &attempt, nil
+}
+
+// List returns a user's attempts for one item, newest first, and fills paging.Total
+func (s *Storage) List(ctx context.Context, filter *model.AttemptFilter, paging *common.Paging) ([]model.Attempt, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	query := filterQuery(filter)
+
+	findOptions := options.Find()
+	findOptions.SetSkip(int64((paging.Page - 1) * paging.Limit))
+	findOptions.SetLimit(int64(paging.Limit))
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	attempts := []model.Attempt{}
+	if err = cursor.All(ctx, &attempts); err != nil {
+		return nil, err
+	}
+
+	total, err := collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	paging.Total = total
+
+	return attempts, nil
+}
+
+// BestScoreBefore returns the highest score among attempts numbered below attemptNumber,
+// or nil when there are none on record
+func (s *Storage) BestScoreBefore(ctx context.Context, filter *model.AttemptFilter, attemptNumber int) (*float64, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	query := filterQuery(filter)
+	query["attempt_number"] = bson.M{"$lt": attemptNumber}
+
+	findOptions := options.FindOne().SetSort(bson.D{{Key: "score", Value: -1}})
+
+	var attempt model.Attempt
+	if err := collection.FindOne(ctx, query, findOptions).Decode(&attempt); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &attempt.Score, nil
+}
+
+func filterQuery(filter *model.AttemptFilter) bson.M {
+	query := bson.M{
+		"user_id": filter.UserID,
+		"kind":    filter.Kind,
+	}
+	if filter.Kind == model.KindChallenge {
+		query["challenge_id"] = filter.ChallengeID
+	} else {
+		query["translation_id"] = filter.TranslationID
+		query["sentence_index"] = filter.SentenceIndex
+	}
+	return query
+}
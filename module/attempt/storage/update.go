@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/attempt/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CorrectScore replaces the score of one attempt after its grade was changed.
+// The first correction moves the score as graded to graded_score, so it is never lost.
+func (s *Storage) CorrectScore(ctx context.Context, filter *model.AttemptFilter, attemptNumber int, score float64, correctedAt time.Time) error {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	query := filterQuery(filter)
+	query["attempt_number"] = attemptNumber
+
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"graded_score": bson.M{"$ifNull": bson.A{"$graded_score", "$score"}},
+			"score":        bson.M{"$literal": score},
+			"corrected_at": bson.M{"$literal": correctedAt},
+		}}},
+	}
+	_, err := collection.UpdateOne(ctx, query, update)
+	return err
+}
@@ -0,0 +1,53 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+	"hub-service/module/attempt/biz"
+	"hub-service/module/attempt/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DiffAttempts godoc
+// @Summary Compare two attempts
+// @Description Word-level diff of two attempts on the same challenge or sentence, with score delta and fixed/new errors
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param from query string true "Earlier attempt ID"
+// @Param to query string true "Later attempt ID"
+// @Success 200 {object} common.Response{data=model.AttemptDiff} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 500 {object} common.AppError "Internal server error"
+// @Router /api/attempts/diff [get]
+func DiffAttempts(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fromID, err := primitive.ObjectIDFromHex(c.Query("from"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		toID, err := primitive.ObjectIDFromHex(c.Query("to"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		userID := c.MustGet("user_id").(primitive.ObjectID)
+
+		store := storage.NewStorage(appCtx.GetDatabase())
+		business := biz.NewDiffAttemptBiz(store)
+
+		result, err := business.DiffAttempts(c.Request.Context(), fromID, toID, userID, auth.IsAdmin(c))
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
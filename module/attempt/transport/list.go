@@ -0,0 +1,127 @@
+package transport
+
+import (
+	"errors"
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+	"hub-service/module/attempt/biz"
+	"hub-service/module/attempt/model"
+	"hub-service/module/attempt/storage"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ListChallengeAttempts godoc
+// @Summary List attempts for a challenge
+// @Description Get the caller's submission history for a challenge, newest first. Admins may pass user_id to inspect another user.
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param challenge_id path string true "Challenge ID"
+// @Param user_id query string false "User ID (admin only)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Success 200 {object} common.Response{data=[]model.Attempt,meta=common.Paging} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 500 {object} common.AppError "Internal server error"
+// @Router /api/attempts/challenges/{challenge_id} [get]
+func ListChallengeAttempts(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		challengeID, err := primitive.ObjectIDFromHex(c.Param("challenge_id"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		filter := &model.AttemptFilter{
+			UserID:      targetUserID(c),
+			Kind:        model.KindChallenge,
+			ChallengeID: challengeID,
+		}
+
+		listAttempts(c, appCtx, filter)
+	}
+}
+
+// ListSentenceAttempts godoc
+// @Summary List attempts for a passage sentence
+// @Description Get the caller's submission history for one sentence of a translation, newest first. Admins may pass user_id to inspect another user.
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param translation_id path string true "Translation ID"
+// @Param sentence_index path int true "Sentence index"
+// @Param user_id query string false "User ID (admin only)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Success 200 {object} common.Response{data=[]model.Attempt,meta=common.Paging} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 500 {object} common.AppError "Internal server error"
+// @Router /api/attempts/translations/{translation_id}/sentences/{sentence_index} [get]
+func ListSentenceAttempts(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		translationID, err := primitive.ObjectIDFromHex(c.Param("translation_id"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		sentenceIndex, err := strconv.Atoi(c.Param("sentence_index"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		filter := &model.AttemptFilter{
+			UserID:        targetUserID(c),
+			Kind:          model.KindSentence,
+			TranslationID: translationID,
+			SentenceIndex: sentenceIndex,
+		}
+
+		listAttempts(c, appCtx, filter)
+	}
+}
+
+func listAttempts(c *gin.Context, appCtx appctx.AppContext, filter *model.AttemptFilter) {
+	var paging common.Paging
+	if err := c.ShouldBind(&paging); err != nil {
+		panic(common.ErrInvalidRequest(err))
+	}
+	paging.Fulfill()
+
+	store := storage.NewStorage(appCtx.GetDatabase())
+	business := biz.NewListAttemptBiz(store)
+
+	result, err := business.ListAttempts(c.Request.Context(), filter, &paging)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusOK, common.NewSuccessResponse(result, paging, nil))
+}
+
+// targetUserID is the caller, or the user_id query parameter when the caller is an admin
+func targetUserID(c *gin.Context) primitive.ObjectID {
+	userID := c.MustGet("user_id").(primitive.ObjectID)
+
+	requested := c.Query("user_id")
+	if requested == "" {
+		return userID
+	}
+
+	if !auth.IsAdmin(c) {
+		panic(common.ErrNoPermission(errors.New("only admins can view other users' attempts")))
+	}
+
+	id, err := primitive.ObjectIDFromHex(requested)
+	if err != nil {
+		panic(common.ErrInvalidRequest(err))
+	}
+	return id
+}
@@ -0,0 +1,18 @@
+package transport
+
+import (
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
+	attempts := g.Group("/attempts")
+	attempts.Use(auth.AuthMiddleware(appCtx))
+	{
+		attempts.GET("/challenges/:challenge_id", ListChallengeAttempts(appCtx))
+		attempts.GET("/translations/:translation_id/sentences/:sentence_index", ListSentenceAttempts(appCtx))
+		attempts.GET("/diff", DiffAttempts(appCtx))
+	}
+}
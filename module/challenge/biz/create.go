@@ -2,6 +2,7 @@ package biz
 
 import (
 	"context"
+	"hub-service/infrastructure/external/deepl"
 	"hub-service/module/challenge/model"
 	"slices"
 )
@@ -10,12 +11,18 @@ type CreateChallengeStore interface {
 	Create(ctx context.Context, data *model.ChallengeCreate) error
 }
 
+// MachineTranslator generates machine reference translations; nil when DeepL is not configured
+type MachineTranslator interface {
+	TranslateMany(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error)
+}
+
 type createChallengeBiz struct {
-	store CreateChallengeStore
+	store      CreateChallengeStore
+	translator MachineTranslator
 }
 
-func NewCreateChallengeBiz(store CreateChallengeStore) *createChallengeBiz {
-	return &createChallengeBiz{store: store}
+func NewCreateChallengeBiz(store CreateChallengeStore, translator MachineTranslator) *createChallengeBiz {
+	return &createChallengeBiz{store: store, translator: translator}
 }
 
 func (biz *createChallengeBiz) CreateChallenge(ctx context.Context, data *model.ChallengeCreate) error {
@@ -24,6 +31,17 @@ func (biz *createChallengeBiz) CreateChallenge(ctx context.Context, data *model.
 		return err
 	}
 
+	if data.GenerateReference && data.MachineTranslation == "" {
+		if biz.translator == nil {
+			return deepl.ErrTranslatorUnavailable
+		}
+		translations, err := biz.translator.TranslateMany(ctx, []string{data.Content}, data.SourceLang, data.TargetLang)
+		if err != nil {
+			return deepl.ErrTranslationFailed(err)
+		}
+		data.MachineTranslation = translations[0]
+	}
+
 	if err := biz.store.Create(ctx, data); err != nil {
 		return err
 	}
@@ -0,0 +1,163 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"hub-service/common"
+	"hub-service/module/challenge/model"
+	"log"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrDraftReviewed is returned when a draft was already approved or discarded
+var ErrDraftReviewed = common.NewFullErrorResponse(
+	http.StatusConflict,
+	errors.New("draft already reviewed"),
+	"This draft has already been approved or discarded",
+	"draft already reviewed",
+	"ErrDraftReviewed",
+)
+
+type ReviewDraftStore interface {
+	GetDraft(ctx context.Context, id primitive.ObjectID) (*model.ChallengeDraft, error)
+	ListDrafts(ctx context.Context, paging *common.Paging, sectionID *primitive.ObjectID, status string) ([]model.ChallengeDraft, error)
+	UpdateDraft(ctx context.Context, id primitive.ObjectID, data *model.DraftUpdate) (bool, error)
+	ClaimDraft(ctx context.Context, id, reviewerID primitive.ObjectID) (*model.ChallengeDraft, error)
+	SetDraftStatus(ctx context.Context, id primitive.ObjectID, from, to string, reviewerID primitive.ObjectID, challengeID *primitive.ObjectID) (bool, error)
+}
+
+// ChallengeCreator publishes an approved draft, see NewCreateChallengeBiz
+type ChallengeCreator interface {
+	CreateChallenge(ctx context.Context, data *model.ChallengeCreate) error
+}
+
+type reviewDraftBiz struct {
+	store   ReviewDraftStore
+	creator ChallengeCreator
+}
+
+func NewReviewDraftBiz(store ReviewDraftStore, creator ChallengeCreator) *reviewDraftBiz {
+	return &reviewDraftBiz{store: store, creator: creator}
+}
+
+func (biz *reviewDraftBiz) ListDrafts(ctx context.Context, paging *common.Paging, filter *model.DraftFilter) ([]model.ChallengeDraft, error) {
+	status := filter.Status
+	if status == "" {
+		status = model.DraftStatusPending
+	}
+
+	var sectionID *primitive.ObjectID
+	if filter.SectionID != "" {
+		id, err := primitive.ObjectIDFromHex(filter.SectionID)
+		if err != nil {
+			return nil, common.ErrInvalidRequest(err)
+		}
+		sectionID = &id
+	}
+
+	drafts, err := biz.store.ListDrafts(ctx, paging, sectionID, status)
+	if err != nil {
+		return nil, common.ErrCannotListEntity("Draft", err)
+	}
+	return drafts, nil
+}
+
+func (biz *reviewDraftBiz) UpdateDraft(ctx context.Context, id primitive.ObjectID, data *model.DraftUpdate) (*model.ChallengeDraft, error) {
+	if !data.HasUpdates() {
+		return nil, common.ErrInvalidRequest(errors.New("no fields to update"))
+	}
+
+	updated, err := biz.store.UpdateDraft(ctx, id, data)
+	if err != nil {
+		return nil, common.ErrCannotUpdateEntity("Draft", err)
+	}
+	if !updated {
+		if _, err := biz.pending(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrDraftReviewed
+	}
+
+	return biz.get(ctx, id)
+}
+
+// ApproveDraft publishes the draft as a challenge. The draft is claimed first so a
+// concurrent approval cannot publish it twice, and released if publishing fails.
+func (biz *reviewDraftBiz) ApproveDraft(ctx context.Context, id, adminID primitive.ObjectID) (*model.ChallengeDraft, error) {
+	draft, err := biz.store.ClaimDraft(ctx, id, adminID)
+	if err != nil {
+		return nil, common.ErrCannotUpdateEntity("Draft", err)
+	}
+	if draft == nil {
+		if _, err := biz.pending(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrDraftReviewed
+	}
+
+	challenge := &model.ChallengeCreate{
+		Title:                 draft.Title,
+		Content:               draft.Content,
+		SourceLang:            draft.SourceLang,
+		TargetLang:            draft.TargetLang,
+		Difficulty:            draft.Difficulty,
+		Category:              draft.Category,
+		SectionID:             draft.SectionID,
+		ReferenceTranslations: draft.ReferenceTranslations,
+	}
+	if err := biz.creator.CreateChallenge(ctx, challenge); err != nil {
+		if _, releaseErr := biz.store.SetDraftStatus(ctx, id, model.DraftStatusApproved, model.DraftStatusPending, adminID, nil); releaseErr != nil {
+			log.Printf("Cannot release draft %s after a failed publish: %v", id.Hex(), releaseErr)
+		}
+		return nil, common.ErrCannotCreateEntity("Challenge", err)
+	}
+
+	// The challenge is published either way; a failure only loses the draft's link to it
+	if _, err := biz.store.SetDraftStatus(ctx, id, model.DraftStatusApproved, model.DraftStatusApproved, adminID, &challenge.ID); err != nil {
+		log.Printf("Cannot link draft %s to challenge %s: %v", id.Hex(), challenge.ID.Hex(), err)
+	}
+
+	draft.ChallengeID = &challenge.ID
+	return draft, nil
+}
+
+// DiscardDraft rejects a pending draft; it is kept for reference but never published
+func (biz *reviewDraftBiz) DiscardDraft(ctx context.Context, id, adminID primitive.ObjectID) (*model.ChallengeDraft, error) {
+	discarded, err := biz.store.SetDraftStatus(ctx, id, model.DraftStatusPending, model.DraftStatusDiscarded, adminID, nil)
+	if err != nil {
+		return nil, common.ErrCannotUpdateEntity("Draft", err)
+	}
+	if !discarded {
+		if _, err := biz.pending(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrDraftReviewed
+	}
+
+	return biz.get(ctx, id)
+}
+
+func (biz *reviewDraftBiz) get(ctx context.Context, id primitive.ObjectID) (*model.ChallengeDraft, error) {
+	draft, err := biz.store.GetDraft(ctx, id)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("Draft", err)
+	}
+	if draft == nil {
+		return nil, common.ErrEntityNotFound("Draft", common.RecordNotFound)
+	}
+	return draft, nil
+}
+
+// pending returns the draft, or an error when it is missing or already reviewed
+func (biz *reviewDraftBiz) pending(ctx context.Context, id primitive.ObjectID) (*model.ChallengeDraft, error) {
+	draft, err := biz.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if draft.Status != model.DraftStatusPending {
+		return nil, ErrDraftReviewed
+	}
+	return draft, nil
+}
@@ -0,0 +1,36 @@
+package biz
+
+// DraftChallengePrompt asks the model for new translation challenges.
+// Arguments: count, source language, target language, difficulty, category, section title,
+// section description, extra instructions, existing challenges to avoid.
+var DraftChallengePrompt = `
+    You are writing translation exercises for %[3]s learners whose native language is %[2]s.
+
+    Write %[1]d new challenges. Each challenge is a short %[2]s text the learner translates into %[3]s.
+
+    Section: %[6]s
+    Section description: %[7]s
+    Difficulty: %[4]s
+    Category: %[5]s
+    Additional instructions: %[8]s
+
+    Do not repeat or closely paraphrase these existing challenges:
+%[9]s
+
+    Return a JSON object with the following fields:
+
+    {
+        "challenges": [
+            {
+                "title": "A short title in %[3]s",
+                "content": "The %[2]s text to translate",
+                "reference_translation": "A natural %[3]s translation of content"
+            }
+        ]
+    }
+
+    Requirements:
+    - easy: one short everyday sentence with common words; medium: one or two sentences with some idioms or less common grammar; hard: two or three sentences with nuanced vocabulary.
+    - Keep every challenge on the section's topic and category, and make them different from each other.
+    - Do NOT return any markdown, explanation, or extra text. Only respond with the raw JSON object.
+`
@@ -0,0 +1,162 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hub-service/common"
+	"hub-service/module/challenge/model"
+	scorebiz "hub-service/module/score/biz"
+	sectionmodel "hub-service/module/section/model"
+	usagebiz "hub-service/module/usage/biz"
+	usagemodel "hub-service/module/usage/model"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// maxExistingInPrompt caps the section's current challenges listed in the prompt
+const maxExistingInPrompt = 30
+
+type GenerateDraftsStore interface {
+	CreateDrafts(ctx context.Context, drafts []model.ChallengeDraft) error
+	List(ctx context.Context, paging *common.Paging, sectionID string, search string, moreKeys ...string) ([]model.Challenge, error)
+}
+
+type SectionStore interface {
+	GetSectionOnly(ctx context.Context, id primitive.ObjectID) (*sectionmodel.Section, error)
+}
+
+// DraftGenerator is the grading backend asked for JSON content, see scorebiz.NewJSONGenerator
+type DraftGenerator interface {
+	Name() string
+	GenerateJSON(ctx context.Context, prompt string) (string, error)
+}
+
+type generateDraftsBiz struct {
+	store         GenerateDraftsStore
+	sections      SectionStore
+	generator     DraftGenerator
+	languagePairs scorebiz.LanguagePairs
+}
+
+// NewGenerateDraftsBiz only generates content for the language pairs that can be graded
+func NewGenerateDraftsBiz(store GenerateDraftsStore, sections SectionStore, generator DraftGenerator, languagePairs scorebiz.LanguagePairs) *generateDraftsBiz {
+	return &generateDraftsBiz{store: store, sections: sections, generator: generator, languagePairs: languagePairs}
+}
+
+type generatedChallenges struct {
+	Challenges []struct {
+		Title                string `json:"title"`
+		Content              string `json:"content"`
+		ReferenceTranslation string `json:"reference_translation"`
+	} `json:"challenges"`
+}
+
+// GenerateDrafts asks the model for up to req.Count challenges and stores them as pending drafts.
+// Replies that repeat an existing challenge of the section are dropped, so fewer drafts may come back.
+func (biz *generateDraftsBiz) GenerateDrafts(ctx context.Context, req *model.GenerateDraftsRequest, adminID primitive.ObjectID) ([]model.ChallengeDraft, error) {
+	sectionID, err := primitive.ObjectIDFromHex(req.SectionID)
+	if err != nil {
+		return nil, common.ErrInvalidRequest(err)
+	}
+
+	section, err := biz.sections.GetSectionOnly(ctx, sectionID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, common.ErrEntityNotFound("Section", common.RecordNotFound)
+		}
+		return nil, common.ErrCannotGetEntity("Section", err)
+	}
+
+	source := scorebiz.NormalizeLanguage(req.SourceLang)
+	target := scorebiz.NormalizeLanguage(req.TargetLang)
+	if !biz.languagePairs.Supports(source, target) {
+		return nil, scorebiz.ErrUnsupportedLanguagePair
+	}
+
+	existing, err := biz.store.List(ctx, &common.Paging{Page: 1, Limit: maxExistingInPrompt}, req.SectionID, "")
+	if err != nil {
+		return nil, common.ErrCannotListEntity("Challenge", err)
+	}
+
+	seen := map[string]bool{}
+	var existingList strings.Builder
+	for _, c := range existing {
+		seen[normalizeContent(c.Content)] = true
+		fmt.Fprintf(&existingList, "    - %s\n", c.Content)
+	}
+	if existingList.Len() == 0 {
+		existingList.WriteString("    (none)\n")
+	}
+
+	instructions := strings.TrimSpace(req.Instructions)
+	if instructions == "" {
+		instructions = "none"
+	}
+
+	prompt := fmt.Sprintf(DraftChallengePrompt,
+		req.Count, scorebiz.LanguageName(source), scorebiz.LanguageName(target),
+		req.Difficulty, req.Category, section.Title, section.Content, instructions, strings.TrimRight(existingList.String(), "\n"))
+
+	body, err := biz.generator.GenerateJSON(usagebiz.WithCaller(ctx, usagemodel.FeatureGeneration, adminID), prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	var reply generatedChallenges
+	if err := json.Unmarshal([]byte(body), &reply); err != nil {
+		return nil, scorebiz.ErrAIResponseUnparsable
+	}
+
+	now := time.Now()
+	drafts := []model.ChallengeDraft{}
+	for _, c := range reply.Challenges {
+		title := strings.TrimSpace(c.Title)
+		content := strings.TrimSpace(c.Content)
+		key := normalizeContent(content)
+		if title == "" || content == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		references := []string{}
+		if ref := strings.TrimSpace(c.ReferenceTranslation); ref != "" {
+			references = append(references, ref)
+		}
+
+		drafts = append(drafts, model.ChallengeDraft{
+			Title:                 title,
+			Content:               content,
+			SourceLang:            source,
+			TargetLang:            target,
+			Difficulty:            req.Difficulty,
+			Category:              req.Category,
+			SectionID:             sectionID,
+			ReferenceTranslations: references,
+			Status:                model.DraftStatusPending,
+			Provider:              biz.generator.Name(),
+			GeneratedBy:           adminID,
+			CreatedAt:             now,
+			UpdatedAt:             now,
+		})
+		if len(drafts) == req.Count {
+			break
+		}
+	}
+	if len(drafts) == 0 {
+		return nil, scorebiz.ErrAIResponseEmpty
+	}
+
+	if err := biz.store.CreateDrafts(ctx, drafts); err != nil {
+		return nil, common.ErrCannotCreateEntity("Draft", err)
+	}
+	return drafts, nil
+}
+
+func normalizeContent(content string) string {
+	return strings.ToLower(strings.Join(strings.Fields(content), " "))
+}
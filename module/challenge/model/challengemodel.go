@@ -26,37 +26,62 @@ var (
 // Challenge represents a translation challenge stored in the database.
 // @Description Contains the details of a translation challenge.
 type Challenge struct {
-	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty" example:"62b4c3789196e8a159933552"`
-	Title      string             `json:"title" bson:"title" example:"Greetings"`
-	Content    string             `json:"content" bson:"content" example:"Hello, world!"`
-	SourceLang string             `json:"source_lang" bson:"source_lang" example:"VI"`
-	TargetLang string             `json:"target_lang" bson:"target_lang" example:"EN"`
-	Difficulty string             `json:"difficulty" bson:"difficulty" example:"easy"`
-	Category   string             `json:"category" bson:"category" example:"work"`
-	SectionID  primitive.ObjectID `json:"section_id" bson:"section_id" example:"62b4c3789196e8a159933552"`
-	CreatedAt  *time.Time         `json:"created_at" bson:"created_at"`
-	UpdatedAt  *time.Time         `json:"updated_at" bson:"updated_at"`
-	Image      string             `json:"image" bson:"image"`
+	ID                    primitive.ObjectID `json:"id" bson:"_id,omitempty" example:"62b4c3789196e8a159933552"`
+	Title                 string             `json:"title" bson:"title" example:"Greetings"`
+	Content               string             `json:"content" bson:"content" example:"Hello, world!"`
+	SourceLang            string             `json:"source_lang" bson:"source_lang" example:"VI"`
+	TargetLang            string             `json:"target_lang" bson:"target_lang" example:"EN"`
+	Difficulty            string             `json:"difficulty" bson:"difficulty" example:"easy"`
+	Category              string             `json:"category" bson:"category" example:"work"`
+	SectionID             primitive.ObjectID `json:"section_id" bson:"section_id" example:"62b4c3789196e8a159933552"`
+	CreatedAt             *time.Time         `json:"created_at" bson:"created_at"`
+	UpdatedAt             *time.Time         `json:"updated_at" bson:"updated_at"`
+	Image                 string             `json:"image" bson:"image"`
+	ReferenceTranslations []string           `json:"reference_translations,omitempty" bson:"reference_translations,omitempty"`
+	MachineTranslation    string             `json:"machine_translation,omitempty" bson:"machine_translation,omitempty"`
 }
 
 func (Challenge) TableName() string {
 	return CollectionName
 }
 
+// HideReferences drops the accepted answers before the challenge is shown to a learner
+func (c *Challenge) HideReferences() {
+	c.ReferenceTranslations = nil
+	c.MachineTranslation = ""
+}
+
+// GradingReferences are the admin references a submission is accepted against. The machine
+// translation is left out: pasting it must not match, it is only given to the grader as context.
+func (c *Challenge) GradingReferences() []string {
+	return c.ReferenceTranslations
+}
+
+// HintReferences are the admin references followed by the machine reference, for hints
+func (c *Challenge) HintReferences() []string {
+	if c.MachineTranslation == "" {
+		return c.ReferenceTranslations
+	}
+	return append(append([]string{}, c.ReferenceTranslations...), c.MachineTranslation)
+}
+
 // ChallengeCreate is the model for creating a new challenge.
 // @Description Required fields for creating a new translation challenge.
 type ChallengeCreate struct {
-	ID         primitive.ObjectID `json:"-" bson:"_id,omitempty"`
-	Title      string             `json:"title" bson:"title" binding:"required" example:"Greetings"`
-	Content    string             `json:"content" bson:"content" binding:"required" example:"Hello, world!"`
-	SourceLang string             `json:"source_lang" bson:"source_lang" binding:"required" example:"VI"`
-	TargetLang string             `json:"target_lang" bson:"target_lang" binding:"required" example:"EN"`
-	Difficulty string             `json:"difficulty" bson:"difficulty" binding:"required,oneof=easy medium hard" example:"easy"`
-	Category   string             `json:"category" bson:"category" binding:"omitempty" example:"work"`
-	SectionID  primitive.ObjectID `json:"section_id" bson:"section_id" example:"62b4c3789196e8a159933552"`
-	CreatedAt  *time.Time         `json:"-" bson:"created_at"`
-	UpdatedAt  *time.Time         `json:"-" bson:"updated_at"`
-	Image      string             `json:"image" bson:"image"`
+	ID                    primitive.ObjectID `json:"-" bson:"_id,omitempty"`
+	Title                 string             `json:"title" bson:"title" binding:"required" example:"Greetings"`
+	Content               string             `json:"content" bson:"content" binding:"required" example:"Hello, world!"`
+	SourceLang            string             `json:"source_lang" bson:"source_lang" binding:"required" example:"VI"`
+	TargetLang            string             `json:"target_lang" bson:"target_lang" binding:"required" example:"EN"`
+	Difficulty            string             `json:"difficulty" bson:"difficulty" binding:"required,oneof=easy medium hard" example:"easy"`
+	Category              string             `json:"category" bson:"category" binding:"omitempty" example:"work"`
+	SectionID             primitive.ObjectID `json:"section_id" bson:"section_id" example:"62b4c3789196e8a159933552"`
+	CreatedAt             *time.Time         `json:"-" bson:"created_at"`
+	UpdatedAt             *time.Time         `json:"-" bson:"updated_at"`
+	Image                 string             `json:"image" bson:"image"`
+	ReferenceTranslations []string           `json:"reference_translations" bson:"reference_translations,omitempty" binding:"omitempty,dive,required"`
+	MachineTranslation    string             `json:"machine_translation" bson:"machine_translation,omitempty"`
+	GenerateReference     bool               `json:"generate_reference" bson:"-"`
 }
 
 func (ChallengeCreate) TableName() string {
@@ -66,15 +91,17 @@ func (ChallengeCreate) TableName() string {
 // ChallengeUpdate is the model for updating an existing challenge.
 // @Description Fields available for updating a translation challenge. All fields are optional.
 type ChallengeUpdate struct {
-	Title      *string             `json:"title,omitempty" bson:"title,omitempty" binding:"omitempty,min=1" example:"Formal Greetings"`
-	Content    *string             `json:"content,omitempty" bson:"content,omitempty" binding:"omitempty,min=1" example:"Good morning, everyone."`
-	SourceLang *string             `json:"source_lang,omitempty" bson:"source_lang,omitempty" binding:"omitempty,min=2,max=2" example:"VI"`
-	TargetLang *string             `json:"target_lang,omitempty" bson:"target_lang,omitempty" binding:"omitempty,min=2,max=2" example:"EN"`
-	Difficulty *string             `json:"difficulty,omitempty" bson:"difficulty,omitempty" binding:"omitempty,oneof=easy medium hard" example:"easy"`
-	Category   *string             `json:"category,omitempty" bson:"category,omitempty" example:"work"`
-	SectionID  *primitive.ObjectID `json:"section_id,omitempty" bson:"section_id,omitempty" example:"62b4c3789196e8a159933552"`
-	UpdatedAt  *time.Time          `json:"-" bson:"updated_at,omitempty"`
-	Image      *string             `json:"image,omitempty" bson:"image,omitempty"`
+	Title                 *string             `json:"title,omitempty" bson:"title,omitempty" binding:"omitempty,min=1" example:"Formal Greetings"`
+	Content               *string             `json:"content,omitempty" bson:"content,omitempty" binding:"omitempty,min=1" example:"Good morning, everyone."`
+	SourceLang            *string             `json:"source_lang,omitempty" bson:"source_lang,omitempty" binding:"omitempty,min=2,max=2" example:"VI"`
+	TargetLang            *string             `json:"target_lang,omitempty" bson:"target_lang,omitempty" binding:"omitempty,min=2,max=2" example:"EN"`
+	Difficulty            *string             `json:"difficulty,omitempty" bson:"difficulty,omitempty" binding:"omitempty,oneof=easy medium hard" example:"easy"`
+	Category              *string             `json:"category,omitempty" bson:"category,omitempty" example:"work"`
+	SectionID             *primitive.ObjectID `json:"section_id,omitempty" bson:"section_id,omitempty" example:"62b4c3789196e8a159933552"`
+	UpdatedAt             *time.Time          `json:"-" bson:"updated_at,omitempty"`
+	Image                 *string             `json:"image,omitempty" bson:"image,omitempty"`
+	ReferenceTranslations *[]string           `json:"reference_translations,omitempty" bson:"reference_translations,omitempty" binding:"omitempty,dive,required"`
+	MachineTranslation    *string             `json:"machine_translation,omitempty" bson:"machine_translation,omitempty"`
 }
 
 func (ChallengeUpdate) TableName() string {
@@ -84,7 +111,8 @@ func (ChallengeUpdate) TableName() string {
 // HasUpdates returns true if at least one field is provided for update
 func (cu ChallengeUpdate) HasUpdates() bool {
 	return cu.Title != nil || cu.Content != nil || cu.SourceLang != nil ||
-		cu.TargetLang != nil || cu.Difficulty != nil || cu.Category != nil
+		cu.TargetLang != nil || cu.Difficulty != nil || cu.Category != nil ||
+		cu.ReferenceTranslations != nil || cu.MachineTranslation != nil
 }
 
 // Helper functions to generate validation strings
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const DraftCollectionName = "challenge_drafts"
+
+// Draft review states. Only approved drafts become challenges learners can see.
+const (
+	DraftStatusPending   = "pending"
+	DraftStatusApproved  = "approved"
+	DraftStatusDiscarded = "discarded"
+)
+
+// MaxGeneratedDrafts caps the drafts asked of the model in one request
+const MaxGeneratedDrafts = 20
+
+// ChallengeDraft is an AI-generated challenge waiting for an admin's review.
+// Drafts live in their own collection so they never reach learner-facing queries.
+type ChallengeDraft struct {
+	ID                    primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	Title                 string              `json:"title" bson:"title" example:"Ordering coffee"`
+	Content               string              `json:"content" bson:"content" example:"Cho tôi một ly cà phê sữa đá."`
+	SourceLang            string              `json:"source_lang" bson:"source_lang" example:"vi"`
+	TargetLang            string              `json:"target_lang" bson:"target_lang" example:"en"`
+	Difficulty            string              `json:"difficulty" bson:"difficulty" example:"easy"`
+	Category              string              `json:"category" bson:"category" example:"food"`
+	SectionID             primitive.ObjectID  `json:"section_id" bson:"section_id"`
+	ReferenceTranslations []string            `json:"reference_translations" bson:"reference_translations"`
+	Status                string              `json:"status" bson:"status" example:"pending"`
+	Provider              string              `json:"provider" bson:"provider" example:"gemini"`
+	GeneratedBy           primitive.ObjectID  `json:"generated_by" bson:"generated_by"`
+	ReviewedBy            *primitive.ObjectID `json:"reviewed_by,omitempty" bson:"reviewed_by,omitempty"`
+	ChallengeID           *primitive.ObjectID `json:"challenge_id,omitempty" bson:"challenge_id,omitempty"`
+	CreatedAt             time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt             time.Time           `json:"updated_at" bson:"updated_at"`
+}
+
+// GenerateDraftsRequest asks the model for draft challenges in a section
+type GenerateDraftsRequest struct {
+	SectionID  string `json:"section_id" binding:"required" example:"62b4c3789196e8a159933552"`
+	Count      int    `json:"count" binding:"required,min=1,max=20" example:"5"`
+	Difficulty string `json:"difficulty" binding:"required,oneof=easy medium hard" example:"easy"`
+	Category   string `json:"category" binding:"required" example:"food"`
+	SourceLang string `json:"source_lang" binding:"required" example:"vi"`
+	TargetLang string `json:"target_lang" binding:"required" example:"en"`
+	// Instructions are passed to the model as is, e.g. "use the past tense"
+	Instructions string `json:"instructions" binding:"omitempty,max=500" example:"Situations at a coffee shop"`
+}
+
+// DraftUpdate edits a pending draft before approval. All fields are optional.
+type DraftUpdate struct {
+	Title                 *string    `json:"title,omitempty" bson:"title,omitempty" binding:"omitempty,min=1"`
+	Content               *string    `json:"content,omitempty" bson:"content,omitempty" binding:"omitempty,min=1"`
+	Difficulty            *string    `json:"difficulty,omitempty" bson:"difficulty,omitempty" binding:"omitempty,oneof=easy medium hard"`
+	Category              *string    `json:"category,omitempty" bson:"category,omitempty"`
+	ReferenceTranslations *[]string  `json:"reference_translations,omitempty" bson:"reference_translations,omitempty" binding:"omitempty,dive,required"`
+	UpdatedAt             *time.Time `json:"-" bson:"updated_at,omitempty"`
+}
+
+// HasUpdates returns true if at least one field is provided for update
+func (du DraftUpdate) HasUpdates() bool {
+	return du.Title != nil || du.Content != nil || du.Difficulty != nil ||
+		du.Category != nil || du.ReferenceTranslations != nil
+}
+
+// DraftFilter lists drafts; an empty status lists pending ones
+type DraftFilter struct {
+	SectionID string `form:"section_id"`
+	Status    string `form:"status" binding:"omitempty,oneof=pending approved discarded" example:"pending"`
+}
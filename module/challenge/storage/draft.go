@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"context"
+	"hub-service/common"
+	"hub-service/module/challenge/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Storage) CreateDrafts(ctx context.Context, drafts []model.ChallengeDraft) error {
+	if len(drafts) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(drafts))
+	for i := range drafts {
+		drafts[i].ID = primitive.NewObjectID()
+		docs[i] = drafts[i]
+	}
+
+	collection := s.db.MongoDB.GetCollection(model.DraftCollectionName)
+	_, err := collection.InsertMany(ctx, docs)
+	return err
+}
+
+func (s *Storage) GetDraft(ctx context.Context, id primitive.ObjectID) (*model.ChallengeDraft, error) {
+	collection := s.db.MongoDB.GetCollection(model.DraftCollectionName)
+
+	var draft model.ChallengeDraft
+	if err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&draft); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &draft, nil
+}
+
+func (s *Storage) ListDrafts(ctx context.Context, paging *common.Paging, sectionID *primitive.ObjectID, status string) ([]model.ChallengeDraft, error) {
+	collection := s.db.MongoDB.GetCollection(model.DraftCollectionName)
+
+	filter := bson.M{"status": status}
+	if sectionID != nil {
+		filter["section_id"] = *sectionID
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64((paging.Page - 1) * paging.Limit)).
+		SetLimit(int64(paging.Limit))
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	drafts := []model.ChallengeDraft{}
+	if err = cursor.All(ctx, &drafts); err != nil {
+		return nil, err
+	}
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	paging.Total = total
+
+	return drafts, nil
+}
+
+// UpdateDraft edits a draft while it is still pending; it returns false when it is not
+func (s *Storage) UpdateDraft(ctx context.Context, id primitive.ObjectID, data *model.DraftUpdate) (bool, error) {
+	now := time.Now()
+	data.UpdatedAt = &now
+
+	collection := s.db.MongoDB.GetCollection(model.DraftCollectionName)
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": model.DraftStatusPending},
+		bson.M{"$set": data},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// ClaimDraft approves a pending draft and returns it as it was approved, or nil when it was no
+// longer pending, so the challenge is published from exactly the content that was claimed
+func (s *Storage) ClaimDraft(ctx context.Context, id, reviewerID primitive.ObjectID) (*model.ChallengeDraft, error) {
+	collection := s.db.MongoDB.GetCollection(model.DraftCollectionName)
+
+	var draft model.ChallengeDraft
+	err := collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": id, "status": model.DraftStatusPending},
+		bson.M{"$set": bson.M{
+			"status":      model.DraftStatusApproved,
+			"reviewed_by": reviewerID,
+			"updated_at":  time.Now(),
+		}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&draft)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &draft, nil
+}
+
+// SetDraftStatus moves a draft from one status to another and returns false when it was not in from,
+// so two admins cannot approve the same draft twice
+func (s *Storage) SetDraftStatus(ctx context.Context, id primitive.ObjectID, from, to string, reviewerID primitive.ObjectID, challengeID *primitive.ObjectID) (bool, error) {
+	set := bson.M{
+		"status":      to,
+		"reviewed_by": reviewerID,
+		"updated_at":  time.Now(),
+	}
+	update := bson.M{"$set": set}
+	if challengeID != nil {
+		set["challenge_id"] = *challengeID
+	} else {
+		update["$unset"] = bson.M{"challenge_id": ""}
+	}
+
+	collection := s.db.MongoDB.GetCollection(model.DraftCollectionName)
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": id, "status": from}, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
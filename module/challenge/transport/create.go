@@ -3,6 +3,7 @@ package transport
 import (
 	"hub-service/common"
 	"hub-service/core/appctx"
+	"hub-service/infrastructure/external/deepl"
 	"hub-service/module/challenge/biz"
 	"hub-service/module/challenge/model"
 	"hub-service/module/challenge/storage"
@@ -13,7 +14,7 @@ import (
 
 // CreateChallenge godoc
 // @Summary Create a new challenge
-// @Description Create a new translation challenge and store it in the database. Only admin and super_admin can access this endpoint.
+// @Description Create a new translation challenge and store it in the database. Set generate_reference to store a DeepL translation as the machine reference. Only admin and super_admin can access this endpoint.
 // @Tags challenges
 // @Accept json
 // @Produce json
@@ -24,6 +25,8 @@ import (
 // @Failure 401 {object} common.AppError "Unauthorized"
 // @Failure 403 {object} common.AppError "Forbidden - Only admin and super_admin can access"
 // @Failure 500 {object} common.AppError "Internal server error"
+// @Failure 502 {object} common.AppError "DeepL request failed"
+// @Failure 503 {object} common.AppError "DeepL not configured"
 // @Router /api/challenges/create [post]
 func CreateChallenge(appCtx appctx.AppContext) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -32,8 +35,14 @@ func CreateChallenge(appCtx appctx.AppContext) gin.HandlerFunc {
 			panic(err)
 		}
 
+		// Keep the interface nil when DeepL is absent so the biz can report it
+		var translator biz.MachineTranslator
+		if t := deepl.NewTranslator(appCtx.GetDeeplClient()); t != nil {
+			translator = t
+		}
+
 		store := storage.NewStorage(appCtx.GetDatabase())
-		business := biz.NewCreateChallengeBiz(store)
+		business := biz.NewCreateChallengeBiz(store, translator)
 
 		if err := business.CreateChallenge(c.Request.Context(), &data); err != nil {
 			panic(err)
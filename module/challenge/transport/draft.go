@@ -0,0 +1,202 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/module/challenge/biz"
+	"hub-service/module/challenge/model"
+	"hub-service/module/challenge/storage"
+	scorebiz "hub-service/module/score/biz"
+	sectionstorage "hub-service/module/section/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GenerateDrafts godoc
+// @Summary Generate draft challenges with AI
+// @Description Ask the grading AI provider for up to count new challenges for a section at the given difficulty, category and language pair. The section's current challenges are sent along so they are not repeated. Drafts are stored as pending and stay invisible to learners until approved. Only admin and super_admin can access this endpoint.
+// @Tags challenges
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.GenerateDraftsRequest true "What to generate"
+// @Success 200 {object} common.Response{data=[]model.ChallengeDraft} "The stored drafts"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden - Only admin and super_admin can access"
+// @Failure 404 {object} common.AppError "Section not found"
+// @Failure 422 {object} common.AppError "Unsupported language pair"
+// @Failure 503 {object} common.AppError "AI provider unavailable"
+// @Router /api/challenges/drafts/generate [post]
+func GenerateDrafts(appCtx appctx.AppContext) gin.HandlerFunc {
+	pairs := scorebiz.SupportedLanguagePairs()
+
+	return func(c *gin.Context) {
+		var req model.GenerateDraftsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		adminID := c.MustGet("user_id").(primitive.ObjectID)
+
+		generator, err := scorebiz.NewJSONGenerator(appCtx.GetLLMClient())
+		if err != nil {
+			panic(err)
+		}
+
+		db := appCtx.GetDatabase()
+		business := biz.NewGenerateDraftsBiz(storage.NewStorage(db), sectionstorage.NewStorage(db), generator, pairs)
+		result, err := business.GenerateDrafts(c.Request.Context(), &req, adminID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// ListDrafts godoc
+// @Summary List draft challenges
+// @Description List AI-generated drafts, newest first. Only admin and super_admin can access this endpoint.
+// @Tags challenges
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Param section_id query string false "Only drafts of this section"
+// @Param status query string false "pending, approved or discarded" default(pending)
+// @Success 200 {object} common.Response{data=[]model.ChallengeDraft,meta=common.Paging} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden - Only admin and super_admin can access"
+// @Router /api/challenges/drafts [get]
+func ListDrafts(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var paging common.Paging
+		if err := c.ShouldBind(&paging); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+		paging.Fulfill()
+
+		var filter model.DraftFilter
+		if err := c.ShouldBindQuery(&filter); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		store := storage.NewStorage(appCtx.GetDatabase())
+		business := biz.NewReviewDraftBiz(store, biz.NewCreateChallengeBiz(store, nil))
+		result, err := business.ListDrafts(c.Request.Context(), &paging, &filter)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.NewSuccessResponse(result, paging, nil))
+	}
+}
+
+// UpdateDraft godoc
+// @Summary Edit a draft challenge
+// @Description Edit a pending draft before approving it. Only admin and super_admin can access this endpoint.
+// @Tags challenges
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Draft ID"
+// @Param request body model.DraftUpdate true "Fields to change"
+// @Success 200 {object} common.Response{data=model.ChallengeDraft} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden - Only admin and super_admin can access"
+// @Failure 404 {object} common.AppError "Draft not found"
+// @Failure 409 {object} common.AppError "Draft already reviewed"
+// @Router /api/challenges/drafts/{id} [patch]
+func UpdateDraft(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := draftID(c)
+
+		var data model.DraftUpdate
+		if err := c.ShouldBindJSON(&data); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		store := storage.NewStorage(appCtx.GetDatabase())
+		business := biz.NewReviewDraftBiz(store, biz.NewCreateChallengeBiz(store, nil))
+		result, err := business.UpdateDraft(c.Request.Context(), id, &data)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// ApproveDraft godoc
+// @Summary Approve a draft challenge
+// @Description Publish a pending draft as a challenge in its section. The response carries the new challenge_id. Only admin and super_admin can access this endpoint.
+// @Tags challenges
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Draft ID"
+// @Success 200 {object} common.Response{data=model.ChallengeDraft} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden - Only admin and super_admin can access"
+// @Failure 404 {object} common.AppError "Draft not found"
+// @Failure 409 {object} common.AppError "Draft already reviewed"
+// @Router /api/challenges/drafts/{id}/approve [post]
+func ApproveDraft(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := draftID(c)
+		adminID := c.MustGet("user_id").(primitive.ObjectID)
+
+		store := storage.NewStorage(appCtx.GetDatabase())
+		// Approved drafts are published without a machine reference; admins can add one later
+		business := biz.NewReviewDraftBiz(store, biz.NewCreateChallengeBiz(store, nil))
+		result, err := business.ApproveDraft(c.Request.Context(), id, adminID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// DiscardDraft godoc
+// @Summary Discard a draft challenge
+// @Description Reject a pending draft. It is kept with status discarded and never published. Only admin and super_admin can access this endpoint.
+// @Tags challenges
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Draft ID"
+// @Success 200 {object} common.Response{data=model.ChallengeDraft} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden - Only admin and super_admin can access"
+// @Failure 404 {object} common.AppError "Draft not found"
+// @Failure 409 {object} common.AppError "Draft already reviewed"
+// @Router /api/challenges/drafts/{id} [delete]
+func DiscardDraft(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := draftID(c)
+		adminID := c.MustGet("user_id").(primitive.ObjectID)
+
+		store := storage.NewStorage(appCtx.GetDatabase())
+		business := biz.NewReviewDraftBiz(store, biz.NewCreateChallengeBiz(store, nil))
+		result, err := business.DiscardDraft(c.Request.Context(), id, adminID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+func draftID(c *gin.Context) primitive.ObjectID {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		panic(common.ErrInvalidRequest(err))
+	}
+	return id
+}
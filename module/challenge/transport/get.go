@@ -3,6 +3,7 @@ package transport
 import (
 	"hub-service/common"
 	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
 	"hub-service/module/challenge/biz"
 	"hub-service/module/challenge/model"
 	"hub-service/module/challenge/storage"
@@ -16,7 +17,7 @@ import (
 
 // GetChallenge godoc
 // @Summary Get a challenge by ID
-// @Description Retrieve the details of a specific translation challenge by its unique ID. All authenticated users can access this endpoint.
+// @Description Retrieve the details of a specific translation challenge by its unique ID. Reference translations are included for admins and for learners who have already submitted an answer. All authenticated users can access this endpoint.
 // @Tags challenges
 // @Accept json
 // @Produce json
@@ -72,6 +73,8 @@ func GetChallenge(appCtx appctx.AppContext) gin.HandlerFunc {
 					Errors:          score.Errors,
 					Suggestions:     score.Suggestions,
 					OriginalContent: score.OriginalContent,
+					HumanReviewed:   score.HumanReviewed,
+					ReviewedAt:      score.ReviewedAt,
 				}
 				// Optional fields as requested
 				if score.BestScore > 0 {
@@ -82,6 +85,11 @@ func GetChallenge(appCtx appctx.AppContext) gin.HandlerFunc {
 			}
 		}
 
+		// Learners see the references once they have submitted an answer
+		if !auth.IsAdmin(c) && detail.UserScore == nil {
+			detail.HideReferences()
+		}
+
 		c.JSON(http.StatusOK, common.SimpleSuccessResponse(detail))
 	}
 }
@@ -3,6 +3,7 @@ package transport
 import (
 	"hub-service/common"
 	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
 	"hub-service/module/challenge/biz"
 	"hub-service/module/challenge/model"
 	"hub-service/module/challenge/storage"
@@ -57,6 +58,12 @@ func ListChallenge(appCtx appctx.AppContext) gin.HandlerFunc {
 			panic(err)
 		}
 
+		if !auth.IsAdmin(c) {
+			for i := range result {
+				result[i].HideReferences()
+			}
+		}
+
 		// Get current user ID from context
 		var userID primitive.ObjectID
 		if v, exists := c.Get("user_id"); exists {
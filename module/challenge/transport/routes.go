@@ -27,6 +27,13 @@ func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
 			adminProtected.POST("/create", CreateChallenge(appCtx))
 			adminProtected.PATCH("/:id", UpdateChallenge(appCtx))
 			adminProtected.DELETE("/:id", DeleteChallenge(appCtx))
+
+			// AI-generated drafts, invisible to learners until approved
+			adminProtected.POST("/drafts/generate", GenerateDrafts(appCtx))
+			adminProtected.GET("/drafts", ListDrafts(appCtx))
+			adminProtected.PATCH("/drafts/:id", UpdateDraft(appCtx))
+			adminProtected.POST("/drafts/:id/approve", ApproveDraft(appCtx))
+			adminProtected.DELETE("/drafts/:id", DiscardDraft(appCtx))
 		}
 	}
 }
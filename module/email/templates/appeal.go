@@ -0,0 +1,31 @@
+package templates
+
+import (
+	"log"
+)
+
+// AppealResultEmailData contains data for the appeal result email template
+type AppealResultEmailData struct {
+	Name          string
+	ItemLabel     string
+	Outcome       string
+	PreviousScore string
+	NewScore      string
+	Note          string
+	LoginUrl      string
+}
+
+// GetAppealResultEmailSubject returns the subject for the appeal result email
+func GetAppealResultEmailSubject() string {
+	return "Your score appeal has been reviewed"
+}
+
+// GetAppealResultEmailHTML returns the HTML body for the appeal result email
+func GetAppealResultEmailHTML(data AppealResultEmailData) string {
+	html, err := RenderTemplate("appeal_result", data)
+	if err != nil {
+		log.Printf("Error: Failed to render appeal_result template: %v", err)
+		return ""
+	}
+	return html
+}
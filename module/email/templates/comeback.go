@@ -0,0 +1,27 @@
+package templates
+
+import (
+	"log"
+)
+
+// ComebackEmailData contains data for the comeback email template
+type ComebackEmailData struct {
+	Name           string
+	ActionUrl      string
+	UnsubscribeUrl string
+}
+
+// GetComebackEmailSubject returns the subject for the comeback email
+func GetComebackEmailSubject() string {
+	return "We miss you at TransMaster!"
+}
+
+// GetComebackEmailHTML returns the HTML body for the comeback email
+func GetComebackEmailHTML(data ComebackEmailData) string {
+	html, err := RenderTemplate("comeback", data)
+	if err != nil {
+		log.Printf("Error: Failed to render comeback template: %v", err)
+		return ""
+	}
+	return html
+}
@@ -37,6 +37,14 @@ func loadTemplates() {
 			templates["comeback"] = comebackTmpl
 		}
 
+		// Load appeal result template
+		appealTmpl, err := template.ParseFS(templateFS, "html/appeal_result.html")
+		if err != nil {
+			log.Printf("Warning: Failed to load appeal_result.html template: %v", err)
+		} else {
+			templates["appeal_result"] = appealTmpl
+		}
+
 		log.Printf("Email templates loaded: %d templates", len(templates))
 	})
 }
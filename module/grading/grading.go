@@ -0,0 +1,148 @@
+// Package grading wires the grading provider and the submission listeners
+// shared by the challenge and passage submit endpoints.
+package grading
+
+import (
+	"context"
+	"hub-service/core/appctx"
+	"hub-service/infrastructure/external/deepl"
+	"hub-service/middleware/auth"
+	achievementbiz "hub-service/module/achievement/biz"
+	achievementstorage "hub-service/module/achievement/storage"
+	appealbiz "hub-service/module/appeal/biz"
+	appealstorage "hub-service/module/appeal/storage"
+	attemptbiz "hub-service/module/attempt/biz"
+	attemptstorage "hub-service/module/attempt/storage"
+	hintbiz "hub-service/module/hint/biz"
+	hintstorage "hub-service/module/hint/storage"
+	integritybiz "hub-service/module/integrity/biz"
+	leaderboardbiz "hub-service/module/leaderboard/biz"
+	leaderboardstorage "hub-service/module/leaderboard/storage"
+	progressbiz "hub-service/module/progress/biz"
+	promptbiz "hub-service/module/prompt/biz"
+	promptstorage "hub-service/module/prompt/storage"
+	reviewbiz "hub-service/module/review/biz"
+	reviewstorage "hub-service/module/review/storage"
+	scorebiz "hub-service/module/score/biz"
+	streakbiz "hub-service/module/streak/biz"
+	streakstorage "hub-service/module/streak/storage"
+	userstorage "hub-service/module/user/storage"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NewProvider returns the grading provider configured for this process. It grades with the
+// active registry prompt, is wrapped in the Redis result cache when Redis is available,
+// and checks reference translations before and after calling the model.
+func NewProvider(appCtx appctx.AppContext) (scorebiz.GradingProvider, error) {
+	prompts := promptbiz.NewRegistry(promptstorage.NewStorage(appCtx.GetDatabase()))
+
+	provider, err := scorebiz.NewGradingProvider(appCtx.GetLLMClient(), prompts)
+	if err != nil {
+		return nil, err
+	}
+
+	if rdb := appCtx.GetRedis(); rdb != nil {
+		if ttl := scorebiz.GradingCacheTTL(); ttl > 0 {
+			provider = scorebiz.NewCachedProvider(provider, rdb, ttl)
+		}
+	}
+
+	return scorebiz.NewReferenceProvider(provider), nil
+}
+
+// NewRegradeProvider returns an uncached grader backed by the named provider, used to get a
+// second opinion on a disputed grade
+func NewRegradeProvider(appCtx appctx.AppContext, provider string) (scorebiz.GradingProvider, error) {
+	prompts := promptbiz.NewRegistry(promptstorage.NewStorage(appCtx.GetDatabase()))
+	return scorebiz.NewSecondOpinionProvider(provider, prompts)
+}
+
+// HintLedger charges the hints a learner revealed to their next graded submission
+func HintLedger(appCtx appctx.AppContext) scorebiz.HintLedger {
+	return hintbiz.NewLedger(hintstorage.NewStorage(appCtx.GetDatabase()), hintbiz.HintPenalties())
+}
+
+// PasteDetector flags submissions that look like pasted machine translation. Content without a
+// stored machine reference is translated with DeepL when it is configured, once per content when
+// Redis is available.
+func PasteDetector(appCtx appctx.AppContext) scorebiz.PasteDetector {
+	var translator integritybiz.MachineTranslator
+	if t := deepl.NewTranslator(appCtx.GetDeeplClient()); t != nil {
+		translator = t
+		if rdb := appCtx.GetRedis(); rdb != nil {
+			translator = integritybiz.NewCachedTranslator(t, rdb)
+		}
+	}
+	return integritybiz.NewDetector(translator, integritybiz.SimilarityThreshold(), integritybiz.MaxCharsPerMinute())
+}
+
+// SubmissionListeners returns the hooks run after every graded submission
+func SubmissionListeners(appCtx appctx.AppContext) []scorebiz.SubmissionListener {
+	db := appCtx.GetDatabase()
+
+	listeners := []scorebiz.SubmissionListener{
+		attemptbiz.NewRecorder(attemptstorage.NewStorage(db)),
+		streakbiz.NewRecorder(streakstorage.NewStorage(db)),
+		reviewbiz.NewScheduler(reviewstorage.NewStorage(db), reviewbiz.EnrollBelow(), reviewbiz.EnrollMinErrors()),
+		appealbiz.NewExpirer(appealstorage.NewStorage(db)),
+	}
+	if rdb := appCtx.GetRedis(); rdb != nil {
+		listeners = append(listeners,
+			leaderboardbiz.NewRecorder(leaderboardstorage.NewStorage(db, rdb)),
+			progressbiz.NewInvalidator(rdb),
+		)
+	}
+
+	// Badges are checked last so their metrics include the attempt and streak just recorded
+	achievements := achievementstorage.NewStorage(db)
+	listeners = append(listeners, achievementbiz.NewEvaluator(achievements, achievements))
+	return listeners
+}
+
+// ScoreChangeListeners returns the hooks run after a grade is changed outside of a submission,
+// by a reviewer's override, an appeal outcome or an applied bulk re-grade
+func ScoreChangeListeners(appCtx appctx.AppContext) []scorebiz.ScoreChangeListener {
+	var listeners []scorebiz.ScoreChangeListener
+	if rdb := appCtx.GetRedis(); rdb != nil {
+		listeners = append(listeners,
+			leaderboardbiz.NewRecorder(leaderboardstorage.NewStorage(appCtx.GetDatabase(), rdb)),
+			progressbiz.NewInvalidator(rdb),
+		)
+	}
+	return listeners
+}
+
+// RequestContext returns the request context, marked to skip the grading cache
+// when an admin sends ?no_cache=true
+func RequestContext(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+	if c.Query("no_cache") != "true" {
+		return ctx
+	}
+
+	if auth.IsAdmin(c) {
+		return scorebiz.WithCacheBypass(ctx)
+	}
+	return ctx
+}
+
+// FeedbackLanguage returns the authenticated user's feedback language preference,
+// or "" so grading falls back to the content's source language
+func FeedbackLanguage(c *gin.Context, appCtx appctx.AppContext) string {
+	v, exists := c.Get("user_id")
+	if !exists {
+		return ""
+	}
+	userID, ok := v.(primitive.ObjectID)
+	if !ok {
+		return ""
+	}
+
+	user, err := userstorage.NewUserStorage(appCtx).GetByID(c.Request.Context(), userID)
+	if err != nil || user == nil {
+		return ""
+	}
+	return user.FeedbackLanguage
+}
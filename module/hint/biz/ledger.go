@@ -0,0 +1,46 @@
+package biz
+
+import (
+	"context"
+	"hub-service/module/hint/model"
+	scorebiz "hub-service/module/score/biz"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ConsumeHintStore interface {
+	ConsumeUsage(ctx context.Context, target *model.Target) (*model.HintUsage, error)
+	RestoreUsage(ctx context.Context, id primitive.ObjectID) error
+}
+
+// ledger charges the hints revealed on a challenge or sentence to the next submission
+type ledger struct {
+	store     ConsumeHintStore
+	penalties []float64
+}
+
+// NewLedger returns the scorebiz.HintLedger used by SubmitScore and SubmitSentenceTranslation.
+// penalties are the points charged per level, as read by HintPenalties.
+func NewLedger(store ConsumeHintStore, penalties []float64) *ledger {
+	return &ledger{store: store, penalties: penalties}
+}
+
+func (l *ledger) ConsumeHints(ctx context.Context, target *scorebiz.HintTarget) (*scorebiz.HintPenalty, error) {
+	usage, err := l.store.ConsumeUsage(ctx, &model.Target{
+		UserID:        target.UserID,
+		Kind:          target.Kind,
+		ChallengeID:   target.ChallengeID,
+		TranslationID: target.TranslationID,
+		SentenceIndex: target.SentenceIndex,
+	})
+	if err != nil || usage == nil {
+		return nil, err
+	}
+
+	level := len(usage.Hints)
+	return &scorebiz.HintPenalty{HintsUsed: level, Points: penaltyFor(l.penalties, level), UsageID: usage.ID}, nil
+}
+
+func (l *ledger) ReturnHints(ctx context.Context, penalty *scorebiz.HintPenalty) error {
+	return l.store.RestoreUsage(ctx, penalty.UsageID)
+}
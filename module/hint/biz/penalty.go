@@ -0,0 +1,46 @@
+package biz
+
+import (
+	"hub-service/module/hint/model"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// defaultHintPenalties are the points deducted once level 1, 2 or 3 has been revealed
+var defaultHintPenalties = []float64{5, 15, 30}
+
+// HintPenalties reads HINT_PENALTIES, the total points deducted from the next submission once
+// each level has been revealed, e.g. "5,15,30". The values must not decrease.
+func HintPenalties() []float64 {
+	raw := os.Getenv("HINT_PENALTIES")
+	if raw == "" {
+		return defaultHintPenalties
+	}
+
+	parts := strings.Split(raw, ",")
+	if len(parts) != model.MaxLevel {
+		log.Printf("Invalid HINT_PENALTIES %q, using %v", raw, defaultHintPenalties)
+		return defaultHintPenalties
+	}
+
+	penalties := make([]float64, 0, len(parts))
+	for i, part := range parts {
+		p, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || p < 0 || (i > 0 && p < penalties[i-1]) {
+			log.Printf("Invalid HINT_PENALTIES %q, using %v", raw, defaultHintPenalties)
+			return defaultHintPenalties
+		}
+		penalties = append(penalties, p)
+	}
+	return penalties
+}
+
+// penaltyFor returns the deduction once level hints have been revealed
+func penaltyFor(penalties []float64, level int) float64 {
+	if level < 1 {
+		return 0
+	}
+	return penalties[level-1]
+}
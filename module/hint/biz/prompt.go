@@ -0,0 +1,31 @@
+package biz
+
+// HintPrompt asks the model for one hint.
+// Arguments: source language, target language, original text, reference translation,
+// feedback language, what the hint must contain.
+var HintPrompt = `
+    You are a %[2]s teacher helping a %[1]s-speaking learner who is stuck translating a text into %[2]s.
+
+    Original %[1]s text: "%[3]s"
+    Reference translation (one acceptable answer): %[4]s
+
+    Write a hint: %[6]s
+
+    Return a JSON object with the following field:
+
+    {
+        "hint": "The hint"
+    }
+
+    Requirements:
+    - Write explanations in %[5]s; words and phrases of the answer stay in %[2]s.
+    - Never reveal more than asked. The learner must still do the translation.
+    - Do NOT return any markdown, explanation, or extra text. Only respond with the raw JSON object.
+`
+
+// hintInstructions describe each level to the model
+var hintInstructions = map[int]string{
+	1: "list the 2 to 4 key %[2]s words or phrases the translation needs, each with its meaning. Do not write any full sentence of the answer.",
+	2: "explain in one or two sentences how to build the %[2]s sentence: tense, word order and the key grammar point. Do not translate the text.",
+	3: "give roughly the first half of a correct %[2]s translation, ending with \"...\".",
+}
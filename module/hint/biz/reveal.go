@@ -0,0 +1,189 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"hub-service/common"
+	challengemodel "hub-service/module/challenge/model"
+	"hub-service/module/hint/model"
+	scorebiz "hub-service/module/score/biz"
+	translationmodel "hub-service/module/translation/model"
+	usagebiz "hub-service/module/usage/biz"
+	usagemodel "hub-service/module/usage/model"
+	"log"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	// ErrNoMoreHints is returned once every level has been revealed
+	ErrNoMoreHints = common.NewFullErrorResponse(
+		http.StatusConflict,
+		errors.New("no more hints"),
+		"Every hint has already been revealed",
+		"no more hints",
+		"ErrNoMoreHints",
+	)
+	// ErrHintUnavailable is returned when there is neither an AI provider nor a reference to write the hint from
+	ErrHintUnavailable = common.NewFullErrorResponse(
+		http.StatusUnprocessableEntity,
+		errors.New("hint unavailable"),
+		"No hint is available for this translation",
+		"hint unavailable",
+		"ErrHintUnavailable",
+	)
+)
+
+type RevealHintStore interface {
+	GetOpenUsage(ctx context.Context, target *model.Target) (*model.HintUsage, error)
+	AppendHint(ctx context.Context, target *model.Target, hint *model.Hint, revealed int) (bool, error)
+	GetCachedHint(ctx context.Context, key string) (*model.Hint, error)
+	SaveCachedHint(ctx context.Context, key string, hint *model.Hint) error
+}
+
+type ChallengeSource interface {
+	Get(ctx context.Context, id primitive.ObjectID) (*challengemodel.Challenge, error)
+}
+
+type SentenceSource interface {
+	GetTranslation(ctx context.Context, id primitive.ObjectID) (*translationmodel.Translation, error)
+	GetSentencesByTranslationID(ctx context.Context, translationID primitive.ObjectID) ([]translationmodel.TranslationSentence, error)
+}
+
+type revealHintBiz struct {
+	store         RevealHintStore
+	challenges    ChallengeSource
+	sentences     SentenceSource
+	generator     HintGenerator
+	languagePairs scorebiz.LanguagePairs
+	penalties     []float64
+}
+
+// NewRevealHintBiz writes hints with generator, or from references only when it is nil
+func NewRevealHintBiz(store RevealHintStore, challenges ChallengeSource, sentences SentenceSource, generator HintGenerator, languagePairs scorebiz.LanguagePairs, penalties []float64) *revealHintBiz {
+	return &revealHintBiz{
+		store:         store,
+		challenges:    challenges,
+		sentences:     sentences,
+		generator:     generator,
+		languagePairs: languagePairs,
+		penalties:     penalties,
+	}
+}
+
+// GetHints returns the hints revealed on the target since the learner's last submission
+func (biz *revealHintBiz) GetHints(ctx context.Context, target *model.Target) (*model.HintResponse, error) {
+	usage, err := biz.store.GetOpenUsage(ctx, target)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("Hint", err)
+	}
+	return biz.response(usage), nil
+}
+
+// RevealHint reveals the next level: key vocabulary, then structure, then a partial translation.
+// Hints are shared between learners through a cache, so each one is generated once per content.
+func (biz *revealHintBiz) RevealHint(ctx context.Context, target *model.Target, feedbackLanguage string) (*model.HintResponse, error) {
+	c, err := biz.content(ctx, target, feedbackLanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := biz.store.GetOpenUsage(ctx, target)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("Hint", err)
+	}
+	revealed := 0
+	if usage != nil {
+		revealed = len(usage.Hints)
+	}
+	if revealed >= model.MaxLevel {
+		return nil, ErrNoMoreHints
+	}
+	level := revealed + 1
+
+	key := cacheKey(c, level)
+	hint, err := biz.store.GetCachedHint(ctx, key)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("Hint", err)
+	}
+	if hint == nil {
+		hint, err = writeHint(usagebiz.WithCaller(ctx, usagemodel.FeatureHint, target.UserID), biz.generator, c, level)
+		if err != nil {
+			return nil, err
+		}
+		if err := biz.store.SaveCachedHint(ctx, key, hint); err != nil {
+			log.Printf("Failed to cache hint: %v", err)
+		}
+	}
+
+	// When a concurrent request revealed this level first, its hint is the one kept
+	if _, err := biz.store.AppendHint(ctx, target, hint, revealed); err != nil {
+		return nil, common.ErrCannotCreateEntity("Hint", err)
+	}
+
+	return biz.GetHints(ctx, target)
+}
+
+func (biz *revealHintBiz) content(ctx context.Context, target *model.Target, feedbackLanguage string) (*content, error) {
+	var c content
+	var sourceLang, targetLang string
+
+	if target.Kind == model.KindChallenge {
+		challenge, err := biz.challenges.Get(ctx, target.ChallengeID)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, common.ErrEntityNotFound("Challenge", common.RecordNotFound)
+			}
+			return nil, common.ErrCannotGetEntity("Challenge", err)
+		}
+		c.Original = challenge.Content
+		c.References = challenge.HintReferences()
+		sourceLang, targetLang = challenge.SourceLang, challenge.TargetLang
+	} else {
+		translation, err := biz.sentences.GetTranslation(ctx, target.TranslationID)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, common.ErrEntityNotFound("Translation", common.RecordNotFound)
+			}
+			return nil, common.ErrCannotGetEntity("Translation", err)
+		}
+		sentences, err := biz.sentences.GetSentencesByTranslationID(ctx, target.TranslationID)
+		if err != nil {
+			return nil, common.ErrCannotGetEntity("Translation", err)
+		}
+		if target.SentenceIndex < 0 || target.SentenceIndex >= len(sentences) {
+			return nil, common.ErrInvalidRequest(errors.New("sentence index out of range"))
+		}
+		sentence := sentences[target.SentenceIndex]
+		c.Original = sentence.Content
+		c.References = sentence.HintReferences()
+		sourceLang, targetLang = translation.SourceLang, translation.TargetLang
+	}
+
+	// Same language rules as grading, so hints exist wherever submissions do
+	gradeReq, err := biz.languagePairs.NewGradeRequest(c.Original, "", sourceLang, targetLang, feedbackLanguage)
+	if err != nil {
+		return nil, err
+	}
+	c.SourceLanguage = gradeReq.SourceLanguage
+	c.TargetLanguage = gradeReq.TargetLanguage
+	c.FeedbackLanguage = gradeReq.FeedbackLanguage
+	return &c, nil
+}
+
+func (biz *revealHintBiz) response(usage *model.HintUsage) *model.HintResponse {
+	resp := &model.HintResponse{Hints: []model.Hint{}, MaxLevel: model.MaxLevel}
+	if usage != nil {
+		resp.Hints = usage.Hints
+		resp.Level = len(usage.Hints)
+	}
+
+	resp.Penalty = penaltyFor(biz.penalties, resp.Level)
+	if resp.Level < model.MaxLevel {
+		next := penaltyFor(biz.penalties, resp.Level+1)
+		resp.NextPenalty = &next
+	}
+	return resp
+}
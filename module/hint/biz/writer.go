@@ -0,0 +1,178 @@
+package biz
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hub-service/module/hint/model"
+	scorebiz "hub-service/module/score/biz"
+	"log"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// HintGenerator is the grading backend asked for JSON, see scorebiz.NewJSONGenerator
+type HintGenerator interface {
+	GenerateJSON(ctx context.Context, prompt string) (string, error)
+}
+
+// content is what a hint is written about
+type content struct {
+	Original         string
+	SourceLanguage   string
+	TargetLanguage   string
+	FeedbackLanguage string
+	References       []string
+}
+
+var hintTypes = map[int]string{
+	model.LevelVocabulary: model.TypeVocabulary,
+	model.LevelStructure:  model.TypeStructure,
+	model.LevelPartial:    model.TypePartial,
+}
+
+// cacheKey addresses a hint by everything it depends on, so editing the content or its
+// references never serves a stale hint
+func cacheKey(c *content, level int) string {
+	parts := []string{
+		fmt.Sprint(level),
+		c.SourceLanguage,
+		c.TargetLanguage,
+		c.FeedbackLanguage,
+		strings.TrimSpace(c.Original),
+		strings.Join(c.References, "\x01"),
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// writeHint asks the model for the hint and falls back to the stored reference when the
+// model is not configured or fails. The partial translation comes from the reference when
+// there is one, since it is then exact and free.
+func writeHint(ctx context.Context, generator HintGenerator, c *content, level int) (*model.Hint, error) {
+	reference := ""
+	if len(c.References) > 0 {
+		reference = c.References[0]
+	}
+
+	if generator != nil && !(level == model.LevelPartial && reference != "") {
+		text, err := aiHint(ctx, generator, c, level)
+		if err == nil && text != "" {
+			return &model.Hint{Level: level, Type: hintTypes[level], Text: text, Source: model.SourceAI}, nil
+		}
+		if reference == "" {
+			if err == nil {
+				err = scorebiz.ErrAIResponseEmpty
+			}
+			return nil, err
+		}
+		log.Printf("AI hint failed, using the reference: %v", err)
+	}
+
+	if reference == "" {
+		return nil, ErrHintUnavailable
+	}
+	return &model.Hint{Level: level, Type: hintTypes[level], Text: referenceHint(reference, level), Source: model.SourceReference}, nil
+}
+
+func aiHint(ctx context.Context, generator HintGenerator, c *content, level int) (string, error) {
+	reference := "none"
+	if len(c.References) > 0 {
+		reference = fmt.Sprintf("%q", c.References[0])
+	}
+
+	source := scorebiz.LanguageName(c.SourceLanguage)
+	target := scorebiz.LanguageName(c.TargetLanguage)
+	instruction := fmt.Sprintf(hintInstructions[level], source, target)
+	prompt := fmt.Sprintf(HintPrompt, source, target, c.Original, reference, scorebiz.LanguageName(c.FeedbackLanguage), instruction)
+
+	body, err := generator.GenerateJSON(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+
+	var reply struct {
+		Hint string `json:"hint"`
+	}
+	if err := json.Unmarshal([]byte(body), &reply); err != nil {
+		return "", scorebiz.ErrAIResponseUnparsable
+	}
+	return strings.TrimSpace(reply.Hint), nil
+}
+
+// referenceHint derives the hint from a reference translation without calling the model
+func referenceHint(reference string, level int) string {
+	words := strings.Fields(reference)
+	if len(words) == 0 {
+		return ""
+	}
+
+	switch level {
+	case model.LevelVocabulary:
+		return "Key words: " + strings.Join(keyWords(words, 3), ", ")
+	case model.LevelStructure:
+		pattern := make([]string, len(words))
+		pattern[0] = words[0]
+		for i := 1; i < len(words); i++ {
+			pattern[i] = "___"
+		}
+		last := words[len(words)-1]
+		if r := []rune(last); len(words) > 1 && unicode.IsPunct(r[len(r)-1]) {
+			pattern[len(pattern)-1] += string(r[len(r)-1])
+		}
+		if len(words) == 1 {
+			return "1 word"
+		}
+		return fmt.Sprintf("%d words: %s", len(words), strings.Join(pattern, " "))
+	default:
+		// A one-word answer only gets its first letters
+		if len(words) == 1 {
+			r := []rune(strings.TrimFunc(words[0], unicode.IsPunct))
+			return string(r[:(len(r)+1)/2]) + "..."
+		}
+		half := (len(words) + 1) / 2
+		if half == len(words) {
+			half--
+		}
+		return strings.Join(words[:half], " ") + " ..."
+	}
+}
+
+// keyWords picks the n longest distinct words, which tend to carry the meaning, in sentence order
+func keyWords(words []string, n int) []string {
+	type candidate struct {
+		word  string
+		index int
+	}
+
+	seen := map[string]bool{}
+	candidates := []candidate{}
+	for i, w := range words {
+		w = strings.TrimFunc(w, unicode.IsPunct)
+		key := strings.ToLower(w)
+		if w == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		candidates = append(candidates, candidate{word: w, index: i})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return len([]rune(candidates[i].word)) > len([]rune(candidates[j].word))
+	})
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].index < candidates[j].index
+	})
+
+	picked := make([]string, len(candidates))
+	for i, c := range candidates {
+		picked[i] = c.word
+	}
+	return picked
+}
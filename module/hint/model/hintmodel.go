@@ -0,0 +1,90 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	UsageCollectionName = "hint_usages"
+	CacheCollectionName = "hint_cache"
+)
+
+// Hint levels, from the weakest to the strongest help
+const (
+	LevelVocabulary = 1
+	LevelStructure  = 2
+	LevelPartial    = 3
+	MaxLevel        = LevelPartial
+)
+
+// Hint types, one per level
+const (
+	TypeVocabulary = "vocabulary"
+	TypeStructure  = "structure"
+	TypePartial    = "partial_translation"
+)
+
+// Hint sources
+const (
+	SourceAI        = "ai"
+	SourceReference = "reference"
+)
+
+// Target kinds, matching the submission kinds
+const (
+	KindChallenge = "challenge"
+	KindSentence  = "sentence"
+)
+
+// Hint is one piece of help revealed to a learner
+type Hint struct {
+	Level  int    `json:"level" bson:"level" example:"1"`
+	Type   string `json:"type" bson:"type" example:"vocabulary"`
+	Text   string `json:"text" bson:"text" example:"coffee, iced, milk"`
+	Source string `json:"source" bson:"source" example:"ai"`
+}
+
+// Target is the challenge or passage sentence a learner asks hints for
+type Target struct {
+	UserID        primitive.ObjectID
+	Kind          string
+	ChallengeID   primitive.ObjectID
+	TranslationID primitive.ObjectID
+	SentenceIndex int
+}
+
+// HintUsage records the hints a learner revealed on a target since their last submission.
+// The next submission consumes it and is charged for the strongest level revealed.
+type HintUsage struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID        primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Kind          string             `json:"kind" bson:"kind"`
+	ChallengeID   primitive.ObjectID `json:"challenge_id,omitempty" bson:"challenge_id,omitempty"`
+	TranslationID primitive.ObjectID `json:"translation_id,omitempty" bson:"translation_id,omitempty"`
+	SentenceIndex int                `json:"sentence_index" bson:"sentence_index"`
+	Hints         []Hint             `json:"hints" bson:"hints"`
+	Consumed      bool               `json:"consumed" bson:"consumed"`
+	ConsumedAt    *time.Time         `json:"consumed_at,omitempty" bson:"consumed_at,omitempty"`
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// CachedHint shares a generated hint between learners of the same content and feedback language
+type CachedHint struct {
+	Key       string    `bson:"_id"`
+	Hint      Hint      `bson:"hint"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+// HintResponse lists the hints revealed so far and what they cost
+type HintResponse struct {
+	Hints    []Hint `json:"hints"`
+	Level    int    `json:"level" example:"1"`
+	MaxLevel int    `json:"max_level" example:"3"`
+	// Penalty is deducted from the next submission's score
+	Penalty float64 `json:"penalty" example:"5"`
+	// NextPenalty is the deduction once the next level is revealed, absent at the last level
+	NextPenalty *float64 `json:"next_penalty,omitempty" example:"15"`
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/hint/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Storage) GetCachedHint(ctx context.Context, key string) (*model.Hint, error) {
+	collection := s.db.MongoDB.GetCollection(model.CacheCollectionName)
+
+	var cached model.CachedHint
+	if err := collection.FindOne(ctx, bson.M{"_id": key}).Decode(&cached); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &cached.Hint, nil
+}
+
+// SaveCachedHint keeps the first hint stored under key
+func (s *Storage) SaveCachedHint(ctx context.Context, key string, hint *model.Hint) error {
+	collection := s.db.MongoDB.GetCollection(model.CacheCollectionName)
+
+	_, err := collection.UpdateOne(ctx,
+		bson.M{"_id": key},
+		bson.M{"$setOnInsert": bson.M{"hint": hint, "created_at": time.Now()}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/hint/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureIndexes allows a single unconsumed usage per challenge or passage sentence, so
+// concurrent first hints cannot open two
+func (s *Storage) EnsureIndexes(ctx context.Context) error {
+	collection := s.db.MongoDB.GetCollection(model.UsageCollectionName)
+
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "user_id", Value: 1},
+			{Key: "kind", Value: 1},
+			{Key: "challenge_id", Value: 1},
+			{Key: "translation_id", Value: 1},
+			{Key: "sentence_index", Value: 1},
+		},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"consumed": false}),
+	})
+	return err
+}
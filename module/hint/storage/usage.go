@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/hint/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// openFilter matches the unconsumed usage of a target
+func openFilter(target *model.Target) bson.M {
+	filter := bson.M{
+		"user_id":  target.UserID,
+		"kind":     target.Kind,
+		"consumed": false,
+	}
+	if target.Kind == model.KindChallenge {
+		filter["challenge_id"] = target.ChallengeID
+	} else {
+		filter["translation_id"] = target.TranslationID
+		filter["sentence_index"] = target.SentenceIndex
+	}
+	return filter
+}
+
+func (s *Storage) GetOpenUsage(ctx context.Context, target *model.Target) (*model.HintUsage, error) {
+	collection := s.db.MongoDB.GetCollection(model.UsageCollectionName)
+
+	var usage model.HintUsage
+	if err := collection.FindOne(ctx, openFilter(target)).Decode(&usage); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &usage, nil
+}
+
+// AppendHint adds the hint to the target's open usage, creating it for the first hint.
+// revealed is the number of hints the caller saw; it returns false when another request
+// revealed one in the meantime. The unique open-usage index keeps two concurrent first
+// hints from creating two usages.
+func (s *Storage) AppendHint(ctx context.Context, target *model.Target, hint *model.Hint, revealed int) (bool, error) {
+	collection := s.db.MongoDB.GetCollection(model.UsageCollectionName)
+	now := time.Now()
+
+	if revealed == 0 {
+		// The filter's fields are copied into the inserted usage; an existing one is left as is
+		insert := bson.M{
+			"hints":      []model.Hint{*hint},
+			"created_at": now,
+			"updated_at": now,
+		}
+		if target.Kind == model.KindChallenge {
+			insert["sentence_index"] = 0
+		}
+		result, err := collection.UpdateOne(ctx, openFilter(target),
+			bson.M{"$setOnInsert": insert},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return result.UpsertedCount > 0, nil
+	}
+
+	filter := openFilter(target)
+	filter["hints"] = bson.M{"$size": revealed}
+
+	result, err := collection.UpdateOne(ctx, filter, bson.M{
+		"$push": bson.M{"hints": hint},
+		"$set":  bson.M{"updated_at": now},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// ConsumeUsage closes the target's open usage and returns it, or nil when no hint was revealed
+func (s *Storage) ConsumeUsage(ctx context.Context, target *model.Target) (*model.HintUsage, error) {
+	collection := s.db.MongoDB.GetCollection(model.UsageCollectionName)
+	now := time.Now()
+
+	var usage model.HintUsage
+	err := collection.FindOneAndUpdate(ctx, openFilter(target),
+		bson.M{"$set": bson.M{"consumed": true, "consumed_at": now, "updated_at": now}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&usage)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &usage, nil
+}
+
+// RestoreUsage reopens a consumed usage whose submission failed to save. The unique open-usage
+// index refuses it when the learner already revealed a new hint on the same target.
+func (s *Storage) RestoreUsage(ctx context.Context, id primitive.ObjectID) error {
+	collection := s.db.MongoDB.GetCollection(model.UsageCollectionName)
+
+	_, err := collection.UpdateOne(ctx,
+		bson.M{"_id": id, "consumed": true},
+		bson.M{
+			"$set":   bson.M{"consumed": false, "updated_at": time.Now()},
+			"$unset": bson.M{"consumed_at": ""},
+		},
+	)
+	return err
+}
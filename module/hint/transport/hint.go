@@ -0,0 +1,162 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	challengestorage "hub-service/module/challenge/storage"
+	"hub-service/module/grading"
+	"hub-service/module/hint/biz"
+	"hub-service/module/hint/model"
+	"hub-service/module/hint/storage"
+	scorebiz "hub-service/module/score/biz"
+	translationstorage "hub-service/module/translation/storage"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetChallengeHints godoc
+// @Summary Get my hints for a challenge
+// @Description The hints revealed on the challenge since the caller's last submission, and the penalty their next submission will be charged.
+// @Tags hints
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Challenge ID"
+// @Success 200 {object} common.Response{data=model.HintResponse} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Router /api/hints/challenges/{id} [get]
+func GetChallengeHints(appCtx appctx.AppContext) gin.HandlerFunc {
+	pairs, penalties := scorebiz.SupportedLanguagePairs(), biz.HintPenalties()
+
+	return func(c *gin.Context) {
+		target := challengeTarget(c)
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(hintResponse(c, appCtx, pairs, penalties, target, false)))
+	}
+}
+
+// RevealChallengeHint godoc
+// @Summary Reveal the next hint for a challenge
+// @Description Reveal the next, stronger hint: key vocabulary, then sentence structure, then a partial translation. Hints are written by the AI provider or derived from the stored reference. The strongest level revealed is deducted from the next submission's score (HINT_PENALTIES, 5, 15 and 30 points by default).
+// @Tags hints
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Challenge ID"
+// @Success 200 {object} common.Response{data=model.HintResponse} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 404 {object} common.AppError "Challenge not found"
+// @Failure 409 {object} common.AppError "Every hint already revealed"
+// @Failure 422 {object} common.AppError "No hint available"
+// @Router /api/hints/challenges/{id} [post]
+func RevealChallengeHint(appCtx appctx.AppContext) gin.HandlerFunc {
+	pairs, penalties := scorebiz.SupportedLanguagePairs(), biz.HintPenalties()
+
+	return func(c *gin.Context) {
+		target := challengeTarget(c)
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(hintResponse(c, appCtx, pairs, penalties, target, true)))
+	}
+}
+
+// GetSentenceHints godoc
+// @Summary Get my hints for a passage sentence
+// @Description The hints revealed on the sentence since the caller's last submission, and the penalty their next submission will be charged.
+// @Tags hints
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Translation ID"
+// @Param sentence_index path int true "Sentence index"
+// @Success 200 {object} common.Response{data=model.HintResponse} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Router /api/hints/translations/{id}/sentences/{sentence_index} [get]
+func GetSentenceHints(appCtx appctx.AppContext) gin.HandlerFunc {
+	pairs, penalties := scorebiz.SupportedLanguagePairs(), biz.HintPenalties()
+
+	return func(c *gin.Context) {
+		target := sentenceTarget(c)
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(hintResponse(c, appCtx, pairs, penalties, target, false)))
+	}
+}
+
+// RevealSentenceHint godoc
+// @Summary Reveal the next hint for a passage sentence
+// @Description Reveal the next, stronger hint on a passage sentence. Works like the challenge hint endpoint.
+// @Tags hints
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Translation ID"
+// @Param sentence_index path int true "Sentence index"
+// @Success 200 {object} common.Response{data=model.HintResponse} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 404 {object} common.AppError "Translation not found"
+// @Failure 409 {object} common.AppError "Every hint already revealed"
+// @Failure 422 {object} common.AppError "No hint available"
+// @Router /api/hints/translations/{id}/sentences/{sentence_index} [post]
+func RevealSentenceHint(appCtx appctx.AppContext) gin.HandlerFunc {
+	pairs, penalties := scorebiz.SupportedLanguagePairs(), biz.HintPenalties()
+
+	return func(c *gin.Context) {
+		target := sentenceTarget(c)
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(hintResponse(c, appCtx, pairs, penalties, target, true)))
+	}
+}
+
+func challengeTarget(c *gin.Context) *model.Target {
+	challengeID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		panic(common.ErrInvalidRequest(err))
+	}
+
+	return &model.Target{
+		UserID:      c.MustGet("user_id").(primitive.ObjectID),
+		Kind:        model.KindChallenge,
+		ChallengeID: challengeID,
+	}
+}
+
+func sentenceTarget(c *gin.Context) *model.Target {
+	translationID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		panic(common.ErrInvalidRequest(err))
+	}
+	sentenceIndex, err := strconv.Atoi(c.Param("sentence_index"))
+	if err != nil {
+		panic(common.ErrInvalidRequest(err))
+	}
+
+	return &model.Target{
+		UserID:        c.MustGet("user_id").(primitive.ObjectID),
+		Kind:          model.KindSentence,
+		TranslationID: translationID,
+		SentenceIndex: sentenceIndex,
+	}
+}
+
+// hintResponse lists the target's hints, revealing the next one first when reveal is set
+func hintResponse(c *gin.Context, appCtx appctx.AppContext, pairs scorebiz.LanguagePairs, penalties []float64, target *model.Target, reveal bool) *model.HintResponse {
+	db := appCtx.GetDatabase()
+
+	// Without a configured AI provider hints come from the stored references
+	var generator biz.HintGenerator
+	if g, err := scorebiz.NewJSONGenerator(appCtx.GetLLMClient()); err == nil {
+		generator = g
+	}
+
+	business := biz.NewRevealHintBiz(storage.NewStorage(db), challengestorage.NewStorage(db), translationstorage.NewStorage(db), generator, pairs, penalties)
+
+	var result *model.HintResponse
+	var err error
+	if reveal {
+		result, err = business.RevealHint(c.Request.Context(), target, grading.FeedbackLanguage(c, appCtx))
+	} else {
+		result, err = business.GetHints(c.Request.Context(), target)
+	}
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
@@ -0,0 +1,20 @@
+package transport
+
+import (
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+	"hub-service/middleware/quota"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
+	hints := g.Group("/hints")
+	hints.Use(auth.AuthMiddleware(appCtx))
+	{
+		hints.GET("/challenges/:id", GetChallengeHints(appCtx))
+		hints.POST("/challenges/:id", quota.RequireAIQuota(appCtx), RevealChallengeHint(appCtx))
+		hints.GET("/translations/:id/sentences/:sentence_index", GetSentenceHints(appCtx))
+		hints.POST("/translations/:id/sentences/:sentence_index", quota.RequireAIQuota(appCtx), RevealSentenceHint(appCtx))
+	}
+}
@@ -0,0 +1,46 @@
+// Package indexes creates the MongoDB indexes the storages rely on, e.g. to keep records unique
+// under concurrent writes. It runs once when the service starts.
+package indexes
+
+import (
+	"context"
+	"hub-service/core/appctx"
+	achievementstorage "hub-service/module/achievement/storage"
+	appealstorage "hub-service/module/appeal/storage"
+	hintstorage "hub-service/module/hint/storage"
+	promptstorage "hub-service/module/prompt/storage"
+	streakstorage "hub-service/module/streak/storage"
+	"log"
+	"time"
+)
+
+const ensureTimeout = time.Minute
+
+type indexer interface {
+	EnsureIndexes(ctx context.Context) error
+}
+
+// Ensure creates the missing indexes. Failures are logged and do not stop the service:
+// a unique index cannot be built while the collection still holds duplicates.
+func Ensure(appCtx appctx.AppContext) {
+	ctx, cancel := context.WithTimeout(context.Background(), ensureTimeout)
+	defer cancel()
+
+	db := appCtx.GetDatabase()
+	storages := []struct {
+		name  string
+		store indexer
+	}{
+		{"prompts", promptstorage.NewStorage(db)},
+		{"appeals", appealstorage.NewStorage(db)},
+		{"hint usages", hintstorage.NewStorage(db)},
+		{"streaks", streakstorage.NewStorage(db)},
+		{"badges", achievementstorage.NewStorage(db)},
+	}
+
+	for _, s := range storages {
+		if err := s.store.EnsureIndexes(ctx); err != nil {
+			log.Printf("Creating %s indexes failed: %v", s.name, err)
+		}
+	}
+}
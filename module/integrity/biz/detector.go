@@ -0,0 +1,113 @@
+package biz
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	scorebiz "hub-service/module/score/biz"
+	scoremodel "hub-service/module/score/model"
+	"hub-service/utils/similarity"
+)
+
+const (
+	// Short answers converge on the same wording, so they are not compared with the machine reference
+	minCheckedWords = 4
+	// Share of the translation that must arrive by paste for the attempt to count as pasted
+	pastedShare = 0.5
+	// Similarity from which a match is flagged even when the telemetry looks like typing, since
+	// retyping the machine translation word for word is still copying it
+	nearExactSimilarity = 98.0
+)
+
+// MachineTranslator translates content that has no stored machine reference; nil when DeepL is not configured
+type MachineTranslator interface {
+	TranslateMany(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error)
+}
+
+type detector struct {
+	translator MachineTranslator
+	threshold  float64
+	maxCPM     int
+}
+
+// NewDetector returns the scorebiz.PasteDetector. An attempt is flagged when it matches the
+// machine translation and the telemetry is missing or shows pasting or impossibly fast typing,
+// when it is a near-exact copy of the machine translation whatever the telemetry says, or when
+// the telemetry shows both pasting and impossibly fast typing.
+func NewDetector(translator MachineTranslator, threshold float64, maxCPM int) *detector {
+	return &detector{translator: translator, threshold: threshold, maxCPM: maxCPM}
+}
+
+func (d *detector) CheckPaste(ctx context.Context, check *scorebiz.PasteCheck) *scoremodel.PasteFlag {
+	length := utf8.RuneCountInString(strings.TrimSpace(check.UserTranslation))
+	if length == 0 {
+		return nil
+	}
+
+	var pasted, tooFast bool
+	if t := check.Telemetry; t != nil {
+		pasted = float64(t.PastedChars) >= pastedShare*float64(length)
+		tooFast = d.maxCPM > 0 && t.TypingTimeMs < int64(length)*60000/int64(d.maxCPM)
+	}
+
+	var reference string
+	var machineSimilarity float64
+	var machineMatch, nearExact bool
+	if len(strings.Fields(check.UserTranslation)) >= minCheckedWords {
+		reference = d.machineReference(ctx, check)
+		if result := similarity.Compare(check.UserTranslation, []string{reference}); result != nil {
+			machineSimilarity = result.Score
+			machineMatch = result.Score >= d.threshold
+			nearExact = result.Score >= nearExactSimilarity
+		}
+	}
+
+	suspicious := check.Telemetry == nil || pasted || tooFast
+	if !(machineMatch && (suspicious || nearExact)) && !(pasted && tooFast) {
+		return nil
+	}
+
+	var reasons []string
+	if machineMatch {
+		reasons = append(reasons, scoremodel.PasteReasonMachineMatch)
+	}
+	if pasted {
+		reasons = append(reasons, scoremodel.PasteReasonPasted)
+	}
+	if tooFast {
+		reasons = append(reasons, scoremodel.PasteReasonTypingSpeed)
+	}
+
+	return &scoremodel.PasteFlag{
+		Status:            scoremodel.PasteStatusPending,
+		Reasons:           reasons,
+		MachineSimilarity: machineSimilarity,
+		MachineReference:  reference,
+		UserTranslation:   check.UserTranslation,
+		AttemptNumber:     check.AttemptNumber,
+		Telemetry:         check.Telemetry,
+		FlaggedAt:         time.Now(),
+	}
+}
+
+// machineReference returns the stored machine translation, or asks DeepL for one, through the
+// Redis cache when there is one.
+// It returns "" when neither is available.
+func (d *detector) machineReference(ctx context.Context, check *scorebiz.PasteCheck) string {
+	if check.MachineTranslation != "" || d.translator == nil {
+		return check.MachineTranslation
+	}
+
+	translations, err := d.translator.TranslateMany(ctx, []string{check.Content}, check.SourceLang, check.TargetLang)
+	if err != nil {
+		log.Printf("Paste check machine translation failed: %v", err)
+		return ""
+	}
+	if len(translations) == 0 {
+		return ""
+	}
+	return translations[0]
+}
@@ -0,0 +1,186 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"hub-service/common"
+	"hub-service/module/integrity/model"
+	scorebiz "hub-service/module/score/biz"
+	scoremodel "hub-service/module/score/model"
+	translationmodel "hub-service/module/translation/model"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrFlagNotPending means the score has no paste flag awaiting review
+var ErrFlagNotPending = common.NewFullErrorResponse(
+	http.StatusConflict,
+	errors.New("no pending paste flag"),
+	"This score has no paste flag awaiting review",
+	"no pending paste flag",
+	"ErrFlagNotPending",
+)
+
+type ChallengeScoreStore interface {
+	GetScoreByID(ctx context.Context, id primitive.ObjectID) (*scoremodel.Score, error)
+	ListFlaggedScores(ctx context.Context, filter *scoremodel.PasteFlagFilter, paging *common.Paging) ([]scoremodel.Score, error)
+	ReviewPasteFlag(ctx context.Context, id primitive.ObjectID, status string, reviewerID primitive.ObjectID, note string) (bool, error)
+}
+
+type SentenceScoreStore interface {
+	GetUserScoreByID(ctx context.Context, id primitive.ObjectID) (*translationmodel.UserTranslationScore, error)
+	ListFlaggedUserScores(ctx context.Context, filter *scoremodel.PasteFlagFilter, paging *common.Paging) ([]translationmodel.UserTranslationScore, error)
+	ReviewUserScorePasteFlag(ctx context.Context, id primitive.ObjectID, status string, reviewerID primitive.ObjectID, note string) (bool, error)
+}
+
+type reviewFlagBiz struct {
+	challengeStore ChallengeScoreStore
+	sentenceStore  SentenceScoreStore
+	listeners      []scorebiz.ScoreChangeListener
+}
+
+// NewReviewFlagBiz notifies listeners, e.g. the leaderboards, when a cleared flag lets a best score count
+func NewReviewFlagBiz(challengeStore ChallengeScoreStore, sentenceStore SentenceScoreStore, listeners ...scorebiz.ScoreChangeListener) *reviewFlagBiz {
+	return &reviewFlagBiz{challengeStore: challengeStore, sentenceStore: sentenceStore, listeners: listeners}
+}
+
+// ListFlags returns one kind of flagged score, most recently flagged first
+func (biz *reviewFlagBiz) ListFlags(ctx context.Context, filter *model.FlagFilter, paging *common.Paging) ([]model.FlaggedScore, error) {
+	if filter.Status == "" {
+		filter.Status = scoremodel.PasteStatusPending
+	}
+	switch filter.Status {
+	case scoremodel.PasteStatusPending, scoremodel.PasteStatusCleared, scoremodel.PasteStatusConfirmed:
+	default:
+		return nil, common.ErrInvalidRequest(errors.New("status must be pending, cleared or confirmed"))
+	}
+	storeFilter := &scoremodel.PasteFlagFilter{Status: filter.Status, UserID: filter.UserID}
+
+	switch filter.Kind {
+	case "", model.KindChallenge:
+		scores, err := biz.challengeStore.ListFlaggedScores(ctx, storeFilter, paging)
+		if err != nil {
+			return nil, common.ErrCannotListEntity("Score", err)
+		}
+		result := make([]model.FlaggedScore, 0, len(scores))
+		for i := range scores {
+			result = append(result, challengeFlag(&scores[i]))
+		}
+		return result, nil
+	case model.KindSentence:
+		scores, err := biz.sentenceStore.ListFlaggedUserScores(ctx, storeFilter, paging)
+		if err != nil {
+			return nil, common.ErrCannotListEntity("Score", err)
+		}
+		result := make([]model.FlaggedScore, 0, len(scores))
+		for i := range scores {
+			result = append(result, sentenceFlag(&scores[i]))
+		}
+		return result, nil
+	}
+	return nil, common.ErrInvalidRequest(errors.New("kind must be challenge or sentence"))
+}
+
+// ReviewChallengeFlag resolves the pending paste flag of a challenge score
+func (biz *reviewFlagBiz) ReviewChallengeFlag(ctx context.Context, scoreID primitive.ObjectID, req *model.ReviewFlagRequest, reviewerID primitive.ObjectID) (*model.FlaggedScore, error) {
+	resolved, err := biz.challengeStore.ReviewPasteFlag(ctx, scoreID, req.Status, reviewerID, req.Note)
+	if err != nil {
+		return nil, common.ErrCannotUpdateEntity("Score", err)
+	}
+
+	score, err := biz.challengeStore.GetScoreByID(ctx, scoreID)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("Score", err)
+	}
+	if score == nil {
+		return nil, common.ErrEntityNotFound("Score", common.RecordNotFound)
+	}
+	if !resolved {
+		return nil, ErrFlagNotPending
+	}
+
+	flagged := challengeFlag(score)
+	biz.notifyCleared(ctx, &flagged, score.PasteFlag)
+	return &flagged, nil
+}
+
+// ReviewSentenceFlag resolves the pending paste flag of a passage sentence score
+func (biz *reviewFlagBiz) ReviewSentenceFlag(ctx context.Context, scoreID primitive.ObjectID, req *model.ReviewFlagRequest, reviewerID primitive.ObjectID) (*model.FlaggedScore, error) {
+	resolved, err := biz.sentenceStore.ReviewUserScorePasteFlag(ctx, scoreID, req.Status, reviewerID, req.Note)
+	if err != nil {
+		return nil, common.ErrCannotUpdateEntity("Score", err)
+	}
+
+	score, err := biz.sentenceStore.GetUserScoreByID(ctx, scoreID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, common.ErrEntityNotFound("Score", common.RecordNotFound)
+		}
+		return nil, common.ErrCannotGetEntity("Score", err)
+	}
+	if !resolved {
+		return nil, ErrFlagNotPending
+	}
+
+	flagged := sentenceFlag(score)
+	biz.notifyCleared(ctx, &flagged, score.PasteFlag)
+	return &flagged, nil
+}
+
+// notifyCleared reports a cleared flag as the whole best score starting to count; a pending flag kept
+// it out of every total
+func (biz *reviewFlagBiz) notifyCleared(ctx context.Context, flagged *model.FlaggedScore, flag *scoremodel.PasteFlag) {
+	if !flag.CountsTowardTotals() {
+		return
+	}
+	scorebiz.NotifyScoreChange(ctx, biz.listeners, &scorebiz.ScoreChange{
+		Kind:          flagged.Kind,
+		UserID:        flagged.UserID,
+		ChallengeID:   flagged.ChallengeID,
+		TranslationID: flagged.TranslationID,
+		SentenceIndex: flagged.SentenceIndex,
+		PreviousBest:  0,
+		BestScore:     flagged.BestScore,
+		PasteFlag:     flag,
+		ChangedAt:     time.Now(),
+	})
+}
+
+func challengeFlag(score *scoremodel.Score) model.FlaggedScore {
+	flagged := model.FlaggedScore{
+		Kind:            model.KindChallenge,
+		ScoreID:         score.ID,
+		UserID:          score.UserID,
+		ChallengeID:     score.ChallengeID,
+		OriginalContent: score.OriginalContent,
+		UserTranslation: score.UserTranslation,
+		Score:           score.Score,
+		BestScore:       score.BestScore,
+		AttemptCount:    score.AttemptCount,
+	}
+	if score.PasteFlag != nil {
+		flagged.Flag = *score.PasteFlag
+	}
+	return flagged
+}
+
+func sentenceFlag(score *translationmodel.UserTranslationScore) model.FlaggedScore {
+	flagged := model.FlaggedScore{
+		Kind:            model.KindSentence,
+		ScoreID:         score.ID,
+		UserID:          score.UserID,
+		TranslationID:   score.TranslationID,
+		SentenceIndex:   score.SentenceIndex,
+		UserTranslation: score.UserTranslation,
+		Score:           score.Score,
+		BestScore:       score.BestScore,
+		AttemptCount:    score.AttemptCount,
+	}
+	if score.PasteFlag != nil {
+		flagged.Flag = *score.PasteFlag
+	}
+	return flagged
+}
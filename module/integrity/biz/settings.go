@@ -0,0 +1,44 @@
+package biz
+
+import (
+	"log"
+	"os"
+	"strconv"
+)
+
+const (
+	defaultSimilarityThreshold = 90.0
+	defaultMaxCharsPerMinute   = 600
+)
+
+// SimilarityThreshold reads PASTE_SIMILARITY_THRESHOLD, the similarity to the machine translation
+// (0-100) from which an attempt counts as a machine match
+func SimilarityThreshold() float64 {
+	raw := os.Getenv("PASTE_SIMILARITY_THRESHOLD")
+	if raw == "" {
+		return defaultSimilarityThreshold
+	}
+
+	threshold, err := strconv.ParseFloat(raw, 64)
+	if err != nil || threshold <= 0 || threshold > 100 {
+		log.Printf("Invalid PASTE_SIMILARITY_THRESHOLD %q, using %v", raw, defaultSimilarityThreshold)
+		return defaultSimilarityThreshold
+	}
+	return threshold
+}
+
+// MaxCharsPerMinute reads PASTE_MAX_CHARS_PER_MINUTE, the fastest plausible typing speed.
+// 0 disables the typing speed check.
+func MaxCharsPerMinute() int {
+	raw := os.Getenv("PASTE_MAX_CHARS_PER_MINUTE")
+	if raw == "" {
+		return defaultMaxCharsPerMinute
+	}
+
+	cpm, err := strconv.Atoi(raw)
+	if err != nil || cpm < 0 {
+		log.Printf("Invalid PASTE_MAX_CHARS_PER_MINUTE %q, using %d", raw, defaultMaxCharsPerMinute)
+		return defaultMaxCharsPerMinute
+	}
+	return cpm
+}
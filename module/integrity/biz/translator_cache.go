@@ -0,0 +1,80 @@
+package biz
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"strings"
+	"time"
+)
+
+const (
+	machineReferenceKeyPrefix = "integrity:machine_reference:"
+	// Content rarely changes once published, and a changed text hashes to a new key
+	machineReferenceTTL = 30 * 24 * time.Hour
+)
+
+// ReferenceCache is the subset of redis.RedisClient used to keep machine references
+type ReferenceCache interface {
+	Get(key string) (string, error)
+	SetWithExpiry(key string, value interface{}, expiration time.Duration) error
+}
+
+// cachedTranslator keeps DeepL's translation of each content in Redis, so content without a stored
+// machine reference is translated once rather than on every submission
+type cachedTranslator struct {
+	next  MachineTranslator
+	cache ReferenceCache
+}
+
+// NewCachedTranslator puts a content-addressed cache in front of next
+func NewCachedTranslator(next MachineTranslator, cache ReferenceCache) MachineTranslator {
+	return &cachedTranslator{next: next, cache: cache}
+}
+
+func (t *cachedTranslator) TranslateMany(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	translations := make([]string, len(texts))
+	keys := make([]string, len(texts))
+	var missing []string
+	var missingAt []int
+	for i, text := range texts {
+		keys[i] = referenceKey(text, sourceLang, targetLang)
+		cached, err := t.cache.Get(keys[i])
+		if err != nil {
+			log.Printf("Machine reference cache read failed: %v", err)
+		}
+		if cached != "" {
+			translations[i] = cached
+			continue
+		}
+		missing = append(missing, text)
+		missingAt = append(missingAt, i)
+	}
+	if len(missing) == 0 {
+		return translations, nil
+	}
+
+	fresh, err := t.next.TranslateMany(ctx, missing, sourceLang, targetLang)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range missingAt {
+		if j >= len(fresh) {
+			break
+		}
+		translations[i] = fresh[j]
+		if fresh[j] == "" {
+			continue
+		}
+		if err := t.cache.SetWithExpiry(keys[i], fresh[j], machineReferenceTTL); err != nil {
+			log.Printf("Machine reference cache write failed: %v", err)
+		}
+	}
+	return translations, nil
+}
+
+func referenceKey(text, sourceLang, targetLang string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{sourceLang, targetLang, strings.TrimSpace(text)}, "\x00")))
+	return machineReferenceKeyPrefix + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	scoremodel "hub-service/module/score/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Flagged score kinds, matching the attempt kinds
+const (
+	KindChallenge = "challenge"
+	KindSentence  = "sentence"
+)
+
+// FlaggedScore is a challenge or sentence score waiting for, or after, a paste review.
+// UserTranslation is the learner's latest attempt; the flagged one is kept on the flag.
+type FlaggedScore struct {
+	Kind            string               `json:"kind"`
+	ScoreID         primitive.ObjectID   `json:"score_id"`
+	UserID          primitive.ObjectID   `json:"user_id"`
+	ChallengeID     primitive.ObjectID   `json:"challenge_id,omitempty"`
+	TranslationID   primitive.ObjectID   `json:"translation_id,omitempty"`
+	SentenceIndex   int                  `json:"sentence_index"`
+	OriginalContent string               `json:"original_content,omitempty"`
+	UserTranslation string               `json:"user_translation"`
+	Score           float64              `json:"score"`
+	BestScore       float64              `json:"best_score"`
+	AttemptCount    int                  `json:"attempt_count"`
+	Flag            scoremodel.PasteFlag `json:"flag"`
+}
+
+// FlagFilter narrows the review list. Kind defaults to challenge and Status to pending.
+type FlagFilter struct {
+	Kind   string
+	Status string
+	UserID primitive.ObjectID
+}
+
+// ReviewFlagRequest resolves a pending paste flag. Cleared scores count toward the learner's totals
+// again; confirmed ones stay excluded.
+type ReviewFlagRequest struct {
+	Status string `json:"status" binding:"required,oneof=cleared confirmed" example:"cleared"`
+	Note   string `json:"note" example:"Learner typed it; the sentence has one natural translation"`
+}
@@ -0,0 +1,156 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/module/grading"
+	"hub-service/module/integrity/biz"
+	"hub-service/module/integrity/model"
+	scorestorage "hub-service/module/score/storage"
+	translationstorage "hub-service/module/translation/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ListFlags godoc
+// @Summary List scores flagged as pasted machine translation
+// @Description Scores whose attempt matched the machine translation or whose typing telemetry looked pasted, most recently flagged first. Pending and confirmed scores are left out of the learner's score summary, the leaderboards and badges. Admin only.
+// @Tags integrity
+// @Produce json
+// @Security BearerAuth
+// @Param kind query string false "challenge or sentence" default(challenge)
+// @Param status query string false "pending, cleared or confirmed" default(pending)
+// @Param user_id query string false "Learner ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Success 200 {object} common.Response{data=[]model.FlaggedScore,meta=common.Paging} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Router /api/integrity/flags [get]
+func ListFlags(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter := &model.FlagFilter{
+			Kind:   c.Query("kind"),
+			Status: c.Query("status"),
+			UserID: optionalObjectID(c, "user_id"),
+		}
+
+		var paging common.Paging
+		if err := c.ShouldBind(&paging); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+		paging.Fulfill()
+
+		db := appCtx.GetDatabase()
+		business := biz.NewReviewFlagBiz(scorestorage.NewStorage(db), translationstorage.NewStorage(db))
+
+		result, err := business.ListFlags(c.Request.Context(), filter, &paging)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.NewSuccessResponse(result, paging, nil))
+	}
+}
+
+// ReviewChallengeFlag godoc
+// @Summary Review a flagged challenge score
+// @Description Resolve a pending paste flag. A cleared score counts toward the learner's totals and the leaderboards again; a confirmed one stays excluded. Use the override endpoints to change the grade itself. Admin only.
+// @Tags integrity
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param score_id path string true "Score ID"
+// @Param request body model.ReviewFlagRequest true "Verdict"
+// @Success 200 {object} common.Response{data=model.FlaggedScore} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Failure 404 {object} common.AppError "Score not found"
+// @Failure 409 {object} common.AppError "No pending paste flag"
+// @Router /api/integrity/flags/scores/{score_id} [post]
+func ReviewChallengeFlag(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scoreID, req := bindReview(c)
+		reviewerID := c.MustGet("user_id").(primitive.ObjectID)
+
+		db := appCtx.GetDatabase()
+		business := biz.NewReviewFlagBiz(
+			scorestorage.NewStorage(db),
+			translationstorage.NewStorage(db),
+			grading.ScoreChangeListeners(appCtx)...,
+		)
+
+		result, err := business.ReviewChallengeFlag(c.Request.Context(), scoreID, req, reviewerID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// ReviewSentenceFlag godoc
+// @Summary Review a flagged passage sentence score
+// @Description Resolve a pending paste flag on one sentence score. Admin only.
+// @Tags integrity
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param score_id path string true "User translation score ID"
+// @Param request body model.ReviewFlagRequest true "Verdict"
+// @Success 200 {object} common.Response{data=model.FlaggedScore} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Failure 404 {object} common.AppError "Score not found"
+// @Failure 409 {object} common.AppError "No pending paste flag"
+// @Router /api/integrity/flags/sentence-scores/{score_id} [post]
+func ReviewSentenceFlag(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scoreID, req := bindReview(c)
+		reviewerID := c.MustGet("user_id").(primitive.ObjectID)
+
+		db := appCtx.GetDatabase()
+		business := biz.NewReviewFlagBiz(
+			scorestorage.NewStorage(db),
+			translationstorage.NewStorage(db),
+			grading.ScoreChangeListeners(appCtx)...,
+		)
+
+		result, err := business.ReviewSentenceFlag(c.Request.Context(), scoreID, req, reviewerID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+func bindReview(c *gin.Context) (primitive.ObjectID, *model.ReviewFlagRequest) {
+	scoreID, err := primitive.ObjectIDFromHex(c.Param("score_id"))
+	if err != nil {
+		panic(common.ErrInvalidRequest(err))
+	}
+
+	var req model.ReviewFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		panic(common.ErrInvalidRequest(err))
+	}
+	return scoreID, &req
+}
+
+func optionalObjectID(c *gin.Context, key string) primitive.ObjectID {
+	value := c.Query(key)
+	if value == "" {
+		return primitive.NilObjectID
+	}
+	id, err := primitive.ObjectIDFromHex(value)
+	if err != nil {
+		panic(common.ErrInvalidRequest(err))
+	}
+	return id
+}
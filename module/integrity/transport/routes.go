@@ -0,0 +1,20 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
+	flags := g.Group("/integrity/flags")
+	flags.Use(auth.AuthMiddleware(appCtx))
+	flags.Use(auth.RequireRoles(common.RoleAdmin, common.RoleSuperAdmin))
+	{
+		flags.GET("", ListFlags(appCtx))
+		flags.POST("/scores/:score_id", ReviewChallengeFlag(appCtx))
+		flags.POST("/sentence-scores/:score_id", ReviewSentenceFlag(appCtx))
+	}
+}
@@ -0,0 +1,133 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"hub-service/common"
+	"hub-service/module/leaderboard/model"
+	usermodel "hub-service/module/user/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrUnknownScope = common.NewErrorResponse(
+		errors.New("unknown leaderboard scope"),
+		"Leaderboard scope must be global, section, weekly or monthly",
+		"unknown leaderboard scope",
+		"ErrUnknownScope",
+	)
+	ErrInvalidPeriod = common.NewErrorResponse(
+		errors.New("invalid leaderboard period"),
+		"Period must look like 2026-W42 for weekly boards or 2026-10 for monthly boards",
+		"invalid leaderboard period",
+		"ErrInvalidPeriod",
+	)
+)
+
+type GetLeaderboardStore interface {
+	Top(ctx context.Context, board model.Board, limit int) ([]model.Entry, error)
+	Rank(ctx context.Context, board model.Board, userID primitive.ObjectID) (*model.Entry, error)
+}
+
+type UserStore interface {
+	GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]usermodel.User, error)
+}
+
+type getLeaderboardBiz struct {
+	store GetLeaderboardStore
+	users UserStore
+}
+
+func NewGetLeaderboardBiz(store GetLeaderboardStore, users UserStore) *getLeaderboardBiz {
+	return &getLeaderboardBiz{store: store, users: users}
+}
+
+// GetLeaderboard returns the top of a board and, when userID is set and ranked, the caller's own entry
+func (biz *getLeaderboardBiz) GetLeaderboard(ctx context.Context, scope string, query *model.LeaderboardQuery, userID primitive.ObjectID) (*model.Leaderboard, error) {
+	result := &model.Leaderboard{Scope: scope}
+
+	board := model.Board{Scope: scope}
+	switch scope {
+	case model.ScopeGlobal:
+	case model.ScopeSection:
+		sectionID, err := primitive.ObjectIDFromHex(query.SectionID)
+		if err != nil {
+			return nil, common.ErrInvalidRequest(errors.New("section_id is required for section leaderboards"))
+		}
+		board.ID = sectionID.Hex()
+		result.SectionID = board.ID
+	case model.ScopeWeekly, model.ScopeMonthly:
+		p, err := periodFor(scope, query.Period, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		board = p.board(scope)
+		result.Period = p.label
+	default:
+		return nil, ErrUnknownScope
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = model.DefaultLimit
+	}
+	if limit > model.MaxLimit {
+		limit = model.MaxLimit
+	}
+
+	top, err := biz.store.Top(ctx, board, limit)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("Leaderboard", err)
+	}
+	result.Top = top
+
+	if !userID.IsZero() {
+		me, err := biz.store.Rank(ctx, board, userID)
+		if err != nil {
+			return nil, common.ErrCannotGetEntity("Leaderboard", err)
+		}
+		result.Me = me
+	}
+
+	if err := biz.attachNames(ctx, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (biz *getLeaderboardBiz) attachNames(ctx context.Context, result *model.Leaderboard) error {
+	ids := make([]primitive.ObjectID, 0, len(result.Top)+1)
+	for _, entry := range result.Top {
+		ids = append(ids, entry.UserID)
+	}
+	if result.Me != nil {
+		ids = append(ids, result.Me.UserID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	users, err := biz.users.GetByIDs(ctx, ids)
+	if err != nil {
+		return common.ErrCannotGetEntity("User", err)
+	}
+
+	byID := make(map[primitive.ObjectID]usermodel.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+
+	for i := range result.Top {
+		user := byID[result.Top[i].UserID]
+		result.Top[i].Name = user.Name
+		result.Top[i].Avatar = user.Avatar
+	}
+	if result.Me != nil {
+		user := byID[result.Me.UserID]
+		result.Me.Name = user.Name
+		result.Me.Avatar = user.Avatar
+	}
+	return nil
+}
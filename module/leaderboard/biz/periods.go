@@ -0,0 +1,74 @@
+package biz
+
+import (
+	"fmt"
+	"hub-service/module/leaderboard/model"
+	"time"
+)
+
+// periodRetention is how long a weekly or monthly board stays readable after its period ends
+const periodRetention = 90 * 24 * time.Hour
+
+// period is one week or month, in UTC
+type period struct {
+	label string
+	start time.Time
+	end   time.Time
+}
+
+func (p period) board(scope string) model.Board {
+	return model.Board{Scope: scope, ID: p.label, ExpiresAt: p.end.Add(periodRetention)}
+}
+
+func weekOf(t time.Time) period {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	// ISO weeks start on Monday
+	offset := (int(day.Weekday()) + 6) % 7
+	start := day.AddDate(0, 0, -offset)
+
+	year, week := start.ISOWeek()
+	return period{
+		label: fmt.Sprintf("%d-W%02d", year, week),
+		start: start,
+		end:   start.AddDate(0, 0, 7),
+	}
+}
+
+func monthOf(t time.Time) period {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return period{
+		label: start.Format("2006-01"),
+		start: start,
+		end:   start.AddDate(0, 1, 0),
+	}
+}
+
+// periodFor returns the period of a weekly or monthly scope named by label, or the current one when label is empty
+func periodFor(scope, label string, now time.Time) (period, error) {
+	if scope == model.ScopeMonthly {
+		if label == "" {
+			return monthOf(now), nil
+		}
+		t, err := time.Parse("2006-01", label)
+		if err != nil {
+			return period{}, ErrInvalidPeriod
+		}
+		return monthOf(t), nil
+	}
+
+	if label == "" {
+		return weekOf(now), nil
+	}
+	var year, week int
+	if _, err := fmt.Sscanf(label, "%d-W%d", &year, &week); err != nil || week < 1 || week > 53 {
+		return period{}, ErrInvalidPeriod
+	}
+	// January 4th is always in ISO week 1
+	p := weekOf(time.Date(year, 1, 4, 0, 0, 0, 0, time.UTC).AddDate(0, 0, (week-1)*7))
+	if p.label != label {
+		return period{}, ErrInvalidPeriod
+	}
+	return p, nil
+}
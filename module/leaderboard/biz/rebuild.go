@@ -0,0 +1,124 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"hub-service/common"
+	"hub-service/module/leaderboard/model"
+	"log"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RebuildStore interface {
+	GlobalTotals(ctx context.Context) (map[primitive.ObjectID]float64, error)
+	SectionTotals(ctx context.Context) (map[primitive.ObjectID]map[primitive.ObjectID]float64, error)
+	ImprovementTotals(ctx context.Context, from, to time.Time) (map[primitive.ObjectID]float64, error)
+	Replace(ctx context.Context, board model.Board, scores map[primitive.ObjectID]float64) error
+	Exists(ctx context.Context, board model.Board) (bool, error)
+	BeginRebuild(ctx context.Context) (bool, error)
+	EndRebuild(ctx context.Context) error
+}
+
+var ErrRebuildRunning = common.NewFullErrorResponse(
+	http.StatusConflict,
+	errors.New("leaderboard rebuild running"),
+	"A leaderboard rebuild is already running",
+	"leaderboard rebuild running",
+	"ErrRebuildRunning",
+)
+
+type rebuildLeaderboardBiz struct {
+	store RebuildStore
+}
+
+func NewRebuildLeaderboardBiz(store RebuildStore) *rebuildLeaderboardBiz {
+	return &rebuildLeaderboardBiz{store: store}
+}
+
+// Rebuild recomputes the global and section boards and the current and previous weekly and
+// monthly boards from MongoDB. It also picks up best scores changed outside of submissions,
+// such as admin overrides. Submissions made while it runs are journaled and added to the
+// rebuilt boards; only one rebuild runs at a time.
+func (biz *rebuildLeaderboardBiz) Rebuild(ctx context.Context) (*model.RebuildResult, error) {
+	started := time.Now()
+	result := &model.RebuildResult{}
+
+	begun, err := biz.store.BeginRebuild(ctx)
+	if err != nil {
+		return nil, common.ErrCannotUpdateEntity("Leaderboard", err)
+	}
+	if !begun {
+		return nil, ErrRebuildRunning
+	}
+	defer func() {
+		if err := biz.store.EndRebuild(ctx); err != nil {
+			log.Printf("Ending leaderboard rebuild failed: %v", err)
+		}
+	}()
+
+	replace := func(board model.Board, scores map[primitive.ObjectID]float64) error {
+		if err := biz.store.Replace(ctx, board, scores); err != nil {
+			return common.ErrCannotUpdateEntity("Leaderboard", err)
+		}
+		result.Boards++
+		result.Entries += len(scores)
+		return nil
+	}
+
+	global, err := biz.store.GlobalTotals(ctx)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("Leaderboard", err)
+	}
+	if err := replace(model.Board{Scope: model.ScopeGlobal}, global); err != nil {
+		return nil, err
+	}
+
+	sections, err := biz.store.SectionTotals(ctx)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("Leaderboard", err)
+	}
+	for sectionID, scores := range sections {
+		if err := replace(model.Board{Scope: model.ScopeSection, ID: sectionID.Hex()}, scores); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	thisWeek, thisMonth := weekOf(now), monthOf(now)
+	periods := []struct {
+		scope string
+		p     period
+	}{
+		{model.ScopeWeekly, thisWeek},
+		{model.ScopeWeekly, weekOf(thisWeek.start.AddDate(0, 0, -1))},
+		{model.ScopeMonthly, thisMonth},
+		{model.ScopeMonthly, monthOf(thisMonth.start.AddDate(0, 0, -1))},
+	}
+	for _, item := range periods {
+		scores, err := biz.store.ImprovementTotals(ctx, item.p.start, item.p.end)
+		if err != nil {
+			return nil, common.ErrCannotGetEntity("Leaderboard", err)
+		}
+		if err := replace(item.p.board(item.scope), scores); err != nil {
+			return nil, err
+		}
+	}
+
+	result.Elapsed = time.Since(started).Round(time.Millisecond).String()
+	return result, nil
+}
+
+// RebuildIfMissing rebuilds when the global board is gone, e.g. after Redis lost its data.
+// It reports whether a rebuild ran.
+func (biz *rebuildLeaderboardBiz) RebuildIfMissing(ctx context.Context) (bool, error) {
+	exists, err := biz.store.Exists(ctx, model.Board{Scope: model.ScopeGlobal})
+	if err != nil || exists {
+		return false, err
+	}
+
+	_, err = biz.Rebuild(ctx)
+	return err == nil, err
+}
@@ -0,0 +1,82 @@
+package biz
+
+import (
+	"context"
+	"hub-service/module/leaderboard/model"
+	scorebiz "hub-service/module/score/biz"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AddScoreStore interface {
+	AddScore(ctx context.Context, boards []model.Board, userID primitive.ObjectID, delta float64) error
+	ChallengeSection(ctx context.Context, challengeID primitive.ObjectID) (primitive.ObjectID, error)
+}
+
+// recorder raises the learner's leaderboard scores when a submission improves a best score, and
+// moves them by the difference when a reviewer or a re-grade changes one. Best scores with a pending
+// or confirmed paste flag are kept off the boards until a reviewer clears the flag.
+type recorder struct {
+	store AddScoreStore
+}
+
+// NewRecorder returns a SubmissionListener and ScoreChangeListener that keeps the leaderboards up to date
+func NewRecorder(store AddScoreStore) *recorder {
+	return &recorder{store: store}
+}
+
+func (r *recorder) OnSubmission(ctx context.Context, event *scorebiz.SubmissionEvent) error {
+	if !event.PasteFlag.CountsTowardTotals() {
+		// A newly flagged score takes back what its earlier best added to the all-time boards
+		if !event.PreviousPasteFlag.CountsTowardTotals() || event.PreviousBest <= 0 {
+			return nil
+		}
+		return r.store.AddScore(ctx, allTimeBoards(event.SectionID), event.UserID, -event.PreviousBest)
+	}
+
+	delta := event.BestScore - event.PreviousBest
+	if !event.IsNewBest || delta <= 0 {
+		return nil
+	}
+
+	boards := []model.Board{
+		{Scope: model.ScopeGlobal},
+		weekOf(event.SubmittedAt).board(model.ScopeWeekly),
+		monthOf(event.SubmittedAt).board(model.ScopeMonthly),
+	}
+	if !event.SectionID.IsZero() {
+		boards = append(boards, model.Board{Scope: model.ScopeSection, ID: event.SectionID.Hex()})
+	}
+
+	return r.store.AddScore(ctx, boards, event.UserID, delta)
+}
+
+// OnScoreChange applies a changed best score to the all-time boards. The weekly and monthly boards
+// only count improvements made in their period, so a later correction leaves them alone.
+func (r *recorder) OnScoreChange(ctx context.Context, change *scorebiz.ScoreChange) error {
+	delta := change.BestScore - change.PreviousBest
+	if delta == 0 || !change.PasteFlag.CountsTowardTotals() {
+		return nil
+	}
+
+	var sectionID primitive.ObjectID
+	if change.Kind == scorebiz.SubmissionKindChallenge {
+		var err error
+		sectionID, err = r.store.ChallengeSection(ctx, change.ChallengeID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.store.AddScore(ctx, allTimeBoards(sectionID), change.UserID, delta)
+}
+
+// allTimeBoards lists the boards that hold best scores for good: the global board and the
+// section board, when there is a section
+func allTimeBoards(sectionID primitive.ObjectID) []model.Board {
+	boards := []model.Board{{Scope: model.ScopeGlobal}}
+	if !sectionID.IsZero() {
+		boards = append(boards, model.Board{Scope: model.ScopeSection, ID: sectionID.Hex()})
+	}
+	return boards
+}
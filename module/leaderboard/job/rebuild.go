@@ -0,0 +1,35 @@
+// Package job rebuilds the leaderboards in the background when the service starts
+package job
+
+import (
+	"context"
+	"hub-service/core/appctx"
+	"hub-service/module/leaderboard/biz"
+	"hub-service/module/leaderboard/storage"
+	"log"
+	"time"
+)
+
+const rebuildTimeout = 5 * time.Minute
+
+// RebuildIfMissing restores the leaderboards from MongoDB when Redis has lost them.
+// It is a no-op without Redis and is meant to run in its own goroutine.
+func RebuildIfMissing(appCtx appctx.AppContext) {
+	rdb := appCtx.GetRedis()
+	if rdb == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), rebuildTimeout)
+	defer cancel()
+
+	business := biz.NewRebuildLeaderboardBiz(storage.NewStorage(appCtx.GetDatabase(), rdb))
+	rebuilt, err := business.RebuildIfMissing(ctx)
+	if err != nil {
+		log.Printf("Leaderboard rebuild failed: %v", err)
+		return
+	}
+	if rebuilt {
+		log.Println("Leaderboards rebuilt from MongoDB")
+	}
+}
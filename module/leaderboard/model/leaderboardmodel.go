@@ -0,0 +1,62 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Leaderboard scopes. Global and section boards rank the sum of best scores;
+// weekly and monthly boards rank how much learners raised their best scores within the period.
+const (
+	ScopeGlobal  = "global"
+	ScopeSection = "section"
+	ScopeWeekly  = "weekly"
+	ScopeMonthly = "monthly"
+)
+
+const (
+	DefaultLimit = 10
+	MaxLimit     = 100
+)
+
+// Board identifies one sorted set: a scope plus the section ID or period label it is for.
+// Period boards expire some time after the period ends.
+type Board struct {
+	Scope     string
+	ID        string
+	ExpiresAt time.Time
+}
+
+// Entry is one ranked learner; Rank starts at 1
+type Entry struct {
+	Rank   int64              `json:"rank"`
+	UserID primitive.ObjectID `json:"user_id"`
+	Name   string             `json:"name"`
+	Avatar string             `json:"avatar,omitempty"`
+	Score  float64            `json:"score"`
+}
+
+// Leaderboard is the top of a board plus the caller's own position when they are ranked
+type Leaderboard struct {
+	Scope     string  `json:"scope"`
+	SectionID string  `json:"section_id,omitempty"`
+	Period    string  `json:"period,omitempty"`
+	Top       []Entry `json:"top"`
+	Me        *Entry  `json:"me,omitempty"`
+}
+
+// LeaderboardQuery selects a board. Period defaults to the current week or month,
+// e.g. "2026-W42" or "2026-10".
+type LeaderboardQuery struct {
+	SectionID string `form:"section_id"`
+	Period    string `form:"period"`
+	Limit     int    `form:"limit"`
+}
+
+// RebuildResult summarizes a rebuild from MongoDB
+type RebuildResult struct {
+	Boards  int    `json:"boards"`
+	Entries int    `json:"entries"`
+	Elapsed string `json:"elapsed"`
+}
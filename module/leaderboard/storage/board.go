@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"hub-service/module/leaderboard/model"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	replaceBatchSize = 500
+
+	// rebuildKey marks a rebuild in progress; it expires in case the rebuild dies
+	rebuildKey = keyPrefix + "rebuild"
+	// replacedKey lists the boards the running rebuild has already replaced
+	replacedKey = rebuildKey + ":replaced"
+	rebuildTTL  = 10 * time.Minute
+)
+
+// addScoreScript increments the boards and, while a rebuild is running, journals the increment
+// for every board not replaced yet, so Replace can add it to totals read before it happened.
+// KEYS: rebuild marker, replaced boards, then the boards.
+// ARGV: delta, member, journal TTL in seconds, then each board's expiry (0 for none).
+var addScoreScript = goredis.NewScript(`
+local rebuilding = redis.call('EXISTS', KEYS[1]) == 1
+for i = 3, #KEYS do
+	local key = KEYS[i]
+	redis.call('ZINCRBY', key, ARGV[1], ARGV[2])
+	local expireAt = tonumber(ARGV[i + 1])
+	if expireAt > 0 then
+		redis.call('EXPIREAT', key, expireAt)
+	end
+	if rebuilding and redis.call('SISMEMBER', KEYS[2], key) == 0 then
+		local pending = key .. ':pending'
+		redis.call('ZINCRBY', pending, ARGV[1], ARGV[2])
+		redis.call('EXPIRE', pending, ARGV[3])
+	end
+end
+return 1
+`)
+
+// AddScore adds delta to the user's score on every board atomically
+func (s *Storage) AddScore(ctx context.Context, boards []model.Board, userID primitive.ObjectID, delta float64) error {
+	keys := []string{rebuildKey, replacedKey}
+	args := []interface{}{delta, userID.Hex(), int64(rebuildTTL.Seconds())}
+	for _, board := range boards {
+		keys = append(keys, boardKey(board))
+		var expireAt int64
+		if !board.ExpiresAt.IsZero() {
+			expireAt = board.ExpiresAt.Unix()
+		}
+		args = append(args, expireAt)
+	}
+	return addScoreScript.Run(ctx, s.rdb.GetClient(), keys, args...).Err()
+}
+
+// BeginRebuild starts journaling increments for Replace. It reports false when another rebuild
+// is already running.
+func (s *Storage) BeginRebuild(ctx context.Context) (bool, error) {
+	client := s.rdb.GetClient()
+	begun, err := client.SetNX(ctx, rebuildKey, time.Now().Unix(), rebuildTTL).Result()
+	if err != nil || !begun {
+		return false, err
+	}
+	// Left over when an earlier rebuild died before EndRebuild
+	return true, client.Del(ctx, replacedKey).Err()
+}
+
+// EndRebuild stops journaling increments
+func (s *Storage) EndRebuild(ctx context.Context) error {
+	return s.rdb.GetClient().Del(ctx, rebuildKey, replacedKey).Err()
+}
+
+// Top returns the highest scores of a board, best first, without names
+func (s *Storage) Top(ctx context.Context, board model.Board, limit int) ([]model.Entry, error) {
+	members, err := s.rdb.GetClient().ZRevRangeWithScores(ctx, boardKey(board), 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]model.Entry, 0, len(members))
+	for i, member := range members {
+		userID, err := primitive.ObjectIDFromHex(member.Member.(string))
+		if err != nil {
+			continue
+		}
+		entries = append(entries, model.Entry{
+			Rank:   int64(i + 1),
+			UserID: userID,
+			Score:  member.Score,
+		})
+	}
+	return entries, nil
+}
+
+// Rank returns the user's position on a board, or nil when they are not on it
+func (s *Storage) Rank(ctx context.Context, board model.Board, userID primitive.ObjectID) (*model.Entry, error) {
+	client := s.rdb.GetClient()
+	key := boardKey(board)
+
+	rank, err := client.ZRevRank(ctx, key, userID.Hex()).Result()
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	score, err := client.ZScore(ctx, key, userID.Hex()).Result()
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &model.Entry{Rank: rank + 1, UserID: userID, Score: score}, nil
+}
+
+// Exists reports whether a board has any entries
+func (s *Storage) Exists(ctx context.Context, board model.Board) (bool, error) {
+	return s.rdb.Exists(boardKey(board))
+}
+
+// Replace swaps a board for the given scores. The new set is built under a temporary key and
+// merged with the increments journaled since BeginRebuild in one transaction, so readers never
+// see a half-built board and submissions made while the totals were read are kept.
+func (s *Storage) Replace(ctx context.Context, board model.Board, scores map[primitive.ObjectID]float64) error {
+	client := s.rdb.GetClient()
+	key := boardKey(board)
+	tmpKey := key + ":rebuild"
+	pendingKey := key + ":pending"
+
+	if err := client.Del(ctx, tmpKey).Err(); err != nil {
+		return err
+	}
+
+	batch := make([]goredis.Z, 0, replaceBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := client.ZAdd(ctx, tmpKey, batch...).Err()
+		batch = batch[:0]
+		return err
+	}
+	for userID, score := range scores {
+		if score <= 0 {
+			continue
+		}
+		batch = append(batch, goredis.Z{Score: score, Member: userID.Hex()})
+		if len(batch) == replaceBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	// An empty union deletes the board
+	pipe := client.TxPipeline()
+	pipe.ZUnionStore(ctx, key, &goredis.ZStore{Keys: []string{tmpKey, pendingKey}})
+	pipe.Del(ctx, tmpKey, pendingKey)
+	pipe.SAdd(ctx, replacedKey, key)
+	if !board.ExpiresAt.IsZero() {
+		pipe.ExpireAt(ctx, key, board.ExpiresAt)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"hub-service/infrastructure/database/database"
+	"hub-service/infrastructure/database/redis"
+	"hub-service/module/leaderboard/model"
+)
+
+const keyPrefix = "leaderboard:"
+
+// Storage keeps the boards in Redis sorted sets and reads the totals they are rebuilt from in MongoDB
+type Storage struct {
+	db  *database.Database
+	rdb *redis.RedisClient
+}
+
+func NewStorage(db *database.Database, rdb *redis.RedisClient) *Storage {
+	return &Storage{db: db, rdb: rdb}
+}
+
+func boardKey(board model.Board) string {
+	if board.ID == "" {
+		return keyPrefix + board.Scope
+	}
+	return keyPrefix + board.Scope + ":" + board.ID
+}
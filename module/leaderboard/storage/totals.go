@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	attemptmodel "hub-service/module/attempt/model"
+	challengemodel "hub-service/module/challenge/model"
+	scoremodel "hub-service/module/score/model"
+	translationmodel "hub-service/module/translation/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type userTotal struct {
+	UserID primitive.ObjectID `bson:"_id"`
+	Total  float64            `bson:"total"`
+}
+
+// countedScores leaves out best scores with a pending or confirmed paste flag, as the recorder does
+var countedScores = bson.M{"$match": bson.M{
+	"paste_flag.status": bson.M{"$nin": []string{scoremodel.PasteStatusPending, scoremodel.PasteStatusConfirmed}},
+}}
+
+// GlobalTotals sums every user's counted best scores over challenges and passage sentences
+func (s *Storage) GlobalTotals(ctx context.Context) (map[primitive.ObjectID]float64, error) {
+	pipeline := []bson.M{
+		countedScores,
+		{"$group": bson.M{
+			"_id":   "$user_id",
+			"total": bson.M{"$sum": "$best_score"},
+		}},
+	}
+
+	totals := map[primitive.ObjectID]float64{}
+	for _, collection := range []string{scoremodel.CollectionName, translationmodel.UserTranslationScoreCollectionName} {
+		if err := s.sumInto(ctx, collection, pipeline, totals); err != nil {
+			return nil, err
+		}
+	}
+	return totals, nil
+}
+
+// SectionTotals sums every user's counted best challenge scores per section
+func (s *Storage) SectionTotals(ctx context.Context) (map[primitive.ObjectID]map[primitive.ObjectID]float64, error) {
+	collection := s.db.MongoDB.GetCollection(scoremodel.CollectionName)
+
+	pipeline := []bson.M{
+		countedScores,
+		{"$lookup": bson.M{
+			"from":         challengemodel.CollectionName,
+			"localField":   "challenge_id",
+			"foreignField": "_id",
+			"as":           "challenge",
+		}},
+		{"$unwind": "$challenge"},
+		{"$group": bson.M{
+			"_id": bson.M{
+				"section_id": "$challenge.section_id",
+				"user_id":    "$user_id",
+			},
+			"total": bson.M{"$sum": "$best_score"},
+		}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		ID struct {
+			SectionID primitive.ObjectID `bson:"section_id"`
+			UserID    primitive.ObjectID `bson:"user_id"`
+		} `bson:"_id"`
+		Total float64 `bson:"total"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	totals := map[primitive.ObjectID]map[primitive.ObjectID]float64{}
+	for _, r := range results {
+		if r.ID.SectionID.IsZero() {
+			continue
+		}
+		if totals[r.ID.SectionID] == nil {
+			totals[r.ID.SectionID] = map[primitive.ObjectID]float64{}
+		}
+		totals[r.ID.SectionID][r.ID.UserID] = r.Total
+	}
+	return totals, nil
+}
+
+// ImprovementTotals sums how much each user raised their best scores between from and to,
+// using the attempt history
+func (s *Storage) ImprovementTotals(ctx context.Context, from, to time.Time) (map[primitive.ObjectID]float64, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"is_new_best": true,
+			"created_at":  bson.M{"$gte": from, "$lt": to},
+		}},
+		{"$group": bson.M{
+			"_id":   "$user_id",
+			"total": bson.M{"$sum": bson.M{"$subtract": []string{"$score", "$previous_best"}}},
+		}},
+	}
+
+	totals := map[primitive.ObjectID]float64{}
+	if err := s.sumInto(ctx, attemptmodel.CollectionName, pipeline, totals); err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+func (s *Storage) sumInto(ctx context.Context, collectionName string, pipeline []bson.M, totals map[primitive.ObjectID]float64) error {
+	cursor, err := s.db.MongoDB.GetCollection(collectionName).Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var results []userTotal
+	if err = cursor.All(ctx, &results); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		totals[r.UserID] += r.Total
+	}
+	return nil
+}
+
+// ChallengeSection returns the section a challenge belongs to, or a zero ID when it has none
+func (s *Storage) ChallengeSection(ctx context.Context, challengeID primitive.ObjectID) (primitive.ObjectID, error) {
+	collection := s.db.MongoDB.GetCollection(challengemodel.CollectionName)
+
+	var challenge struct {
+		SectionID primitive.ObjectID `bson:"section_id"`
+	}
+	opts := options.FindOne().SetProjection(bson.M{"section_id": 1})
+	if err := collection.FindOne(ctx, bson.M{"_id": challengeID}, opts).Decode(&challenge); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return primitive.NilObjectID, nil
+		}
+		return primitive.NilObjectID, err
+	}
+	return challenge.SectionID, nil
+}
@@ -0,0 +1,81 @@
+package transport
+
+import (
+	"errors"
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/module/leaderboard/biz"
+	"hub-service/module/leaderboard/model"
+	"hub-service/module/leaderboard/storage"
+	userstorage "hub-service/module/user/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetLeaderboard godoc
+// @Summary Get a leaderboard
+// @Description Top learners of a board plus the caller's own rank. Global and section boards rank the sum of best scores; weekly and monthly boards rank how much best scores were raised in the period.
+// @Tags leaderboards
+// @Produce json
+// @Security BearerAuth
+// @Param scope path string true "global, section, weekly or monthly"
+// @Param section_id query string false "Section ID (section scope)"
+// @Param period query string false "Period, e.g. 2026-W42 or 2026-10 (defaults to the current one)"
+// @Param limit query int false "Number of top entries" default(10)
+// @Success 200 {object} common.Response{data=model.Leaderboard} "Success"
+// @Failure 400 {object} common.AppError "Bad request or Redis not configured"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Router /api/leaderboards/{scope} [get]
+func GetLeaderboard(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query model.LeaderboardQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		userID := c.MustGet("user_id").(primitive.ObjectID)
+
+		business := biz.NewGetLeaderboardBiz(leaderboardStorage(appCtx), userstorage.NewUserStorage(appCtx))
+		result, err := business.GetLeaderboard(c.Request.Context(), c.Param("scope"), &query, userID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// RebuildLeaderboards godoc
+// @Summary Rebuild leaderboards from MongoDB
+// @Description Recompute the global, section and recent weekly and monthly boards from stored scores and attempt history, e.g. after Redis lost its data or scores were overridden. Admin only.
+// @Tags leaderboards
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} common.Response{data=model.RebuildResult} "Success"
+// @Failure 400 {object} common.AppError "Redis not configured"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Failure 409 {object} common.AppError "Rebuild already running"
+// @Router /api/leaderboards/rebuild [post]
+func RebuildLeaderboards(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		business := biz.NewRebuildLeaderboardBiz(leaderboardStorage(appCtx))
+
+		result, err := business.Rebuild(c.Request.Context())
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+func leaderboardStorage(appCtx appctx.AppContext) *storage.Storage {
+	rdb := appCtx.GetRedis()
+	if rdb == nil {
+		panic(common.ErrInvalidRequest(errors.New("redis is not configured")))
+	}
+	return storage.NewStorage(appCtx.GetDatabase(), rdb)
+}
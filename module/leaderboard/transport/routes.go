@@ -0,0 +1,18 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
+	leaderboards := g.Group("/leaderboards")
+	leaderboards.Use(auth.AuthMiddleware(appCtx))
+	{
+		leaderboards.GET("/:scope", GetLeaderboard(appCtx))
+		leaderboards.POST("/rebuild", auth.RequireRoles(common.RoleAdmin, common.RoleSuperAdmin), RebuildLeaderboards(appCtx))
+	}
+}
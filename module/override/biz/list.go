@@ -0,0 +1,27 @@
+package biz
+
+import (
+	"context"
+	"hub-service/common"
+	"hub-service/module/override/model"
+)
+
+type ListOverrideStore interface {
+	List(ctx context.Context, filter *model.OverrideFilter, paging *common.Paging) ([]model.ScoreOverride, error)
+}
+
+type listOverrideBiz struct {
+	store ListOverrideStore
+}
+
+func NewListOverrideBiz(store ListOverrideStore) *listOverrideBiz {
+	return &listOverrideBiz{store: store}
+}
+
+func (biz *listOverrideBiz) ListOverrides(ctx context.Context, filter *model.OverrideFilter, paging *common.Paging) ([]model.ScoreOverride, error) {
+	result, err := biz.store.List(ctx, filter, paging)
+	if err != nil {
+		return nil, common.ErrCannotListEntity("ScoreOverride", err)
+	}
+	return result, nil
+}
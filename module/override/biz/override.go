@@ -0,0 +1,254 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"hub-service/common"
+	attemptmodel "hub-service/module/attempt/model"
+	"hub-service/module/override/model"
+	scorebiz "hub-service/module/score/biz"
+	scoremodel "hub-service/module/score/model"
+	translationmodel "hub-service/module/translation/model"
+	"log"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrScoreChanged means the learner submitted again after the graded attempt under review
+var ErrScoreChanged = common.NewFullErrorResponse(
+	http.StatusConflict,
+	errors.New("score changed since review was requested"),
+	"The learner has submitted a new translation since this grade was disputed",
+	"score changed since review was requested",
+	"ErrScoreChanged",
+)
+
+type OverrideStore interface {
+	Create(ctx context.Context, data *model.ScoreOverride) error
+}
+
+type ChallengeScoreStore interface {
+	GetScoreByID(ctx context.Context, id primitive.ObjectID) (*scoremodel.Score, error)
+	OverrideScore(ctx context.Context, id primitive.ObjectID, attemptCount int, data *scoremodel.ScoreUpdate) (bool, error)
+}
+
+type SentenceScoreStore interface {
+	GetUserScoreByID(ctx context.Context, id primitive.ObjectID) (*translationmodel.UserTranslationScore, error)
+	OverrideUserScore(ctx context.Context, id primitive.ObjectID, attemptCount int, score, bestScore float64, feedback string, reviewedAt time.Time) (bool, error)
+}
+
+type AttemptHistoryStore interface {
+	BestScoreBefore(ctx context.Context, filter *attemptmodel.AttemptFilter, attemptNumber int) (*float64, error)
+	CorrectScore(ctx context.Context, filter *attemptmodel.AttemptFilter, attemptNumber int, score float64, correctedAt time.Time) error
+}
+
+type overrideScoreBiz struct {
+	overrideStore  OverrideStore
+	challengeStore ChallengeScoreStore
+	sentenceStore  SentenceScoreStore
+	attemptStore   AttemptHistoryStore
+	listeners      []scorebiz.ScoreChangeListener
+}
+
+// NewOverrideScoreBiz notifies listeners, e.g. the leaderboards and the progress cache, once a grade is overridden
+func NewOverrideScoreBiz(overrideStore OverrideStore, challengeStore ChallengeScoreStore, sentenceStore SentenceScoreStore, attemptStore AttemptHistoryStore, listeners ...scorebiz.ScoreChangeListener) *overrideScoreBiz {
+	return &overrideScoreBiz{
+		overrideStore:  overrideStore,
+		challengeStore: challengeStore,
+		sentenceStore:  sentenceStore,
+		attemptStore:   attemptStore,
+		listeners:      listeners,
+	}
+}
+
+// OverrideChallengeScore replaces the latest grade of a challenge score and records who changed it
+func (biz *overrideScoreBiz) OverrideChallengeScore(ctx context.Context, scoreID primitive.ObjectID, req *model.OverrideScoreRequest, reviewerID primitive.ObjectID) (*model.ScoreOverride, error) {
+	score, err := biz.challengeStore.GetScoreByID(ctx, scoreID)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("Score", err)
+	}
+	if score == nil {
+		return nil, common.ErrEntityNotFound("Score", common.RecordNotFound)
+	}
+
+	if req.AttemptNumber != 0 && req.AttemptNumber != score.AttemptCount {
+		return nil, ErrScoreChanged
+	}
+
+	filter := &attemptmodel.AttemptFilter{
+		UserID:      score.UserID,
+		Kind:        attemptmodel.KindChallenge,
+		ChallengeID: score.ChallengeID,
+	}
+	bestScore, err := biz.recomputeBest(ctx, filter, score.AttemptCount, score.Score, score.BestScore, *req.Score)
+	if err != nil {
+		return nil, err
+	}
+
+	feedback := req.Feedback
+	if feedback == "" {
+		feedback = score.Feedback
+	}
+
+	now := time.Now()
+	record := &model.ScoreOverride{
+		Kind:              model.KindChallenge,
+		ScoreID:           score.ID,
+		UserID:            score.UserID,
+		ChallengeID:       score.ChallengeID,
+		AttemptNumber:     score.AttemptCount,
+		PreviousScore:     score.Score,
+		PreviousBestScore: score.BestScore,
+		PreviousFeedback:  score.Feedback,
+		Score:             *req.Score,
+		BestScore:         bestScore,
+		Feedback:          feedback,
+		Reason:            req.Reason,
+		Source:            source(req),
+		AppealID:          req.AppealID,
+		ReviewerID:        reviewerID,
+		CreatedAt:         now,
+	}
+
+	humanReviewed := true
+	update := &scoremodel.ScoreUpdate{
+		Score:         req.Score,
+		Feedback:      &feedback,
+		BestScore:     &bestScore,
+		HumanReviewed: &humanReviewed,
+		ReviewedAt:    &now,
+	}
+	return biz.apply(ctx, record, score.PasteFlag, func() (bool, error) {
+		return biz.challengeStore.OverrideScore(ctx, score.ID, score.AttemptCount, update)
+	})
+}
+
+// OverrideSentenceScore replaces the latest grade of a passage sentence score and records who changed it
+func (biz *overrideScoreBiz) OverrideSentenceScore(ctx context.Context, scoreID primitive.ObjectID, req *model.OverrideScoreRequest, reviewerID primitive.ObjectID) (*model.ScoreOverride, error) {
+	score, err := biz.sentenceStore.GetUserScoreByID(ctx, scoreID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, common.ErrEntityNotFound("Score", common.RecordNotFound)
+		}
+		return nil, common.ErrCannotGetEntity("Score", err)
+	}
+
+	if req.AttemptNumber != 0 && req.AttemptNumber != score.AttemptCount {
+		return nil, ErrScoreChanged
+	}
+
+	filter := &attemptmodel.AttemptFilter{
+		UserID:        score.UserID,
+		Kind:          attemptmodel.KindSentence,
+		TranslationID: score.TranslationID,
+		SentenceIndex: score.SentenceIndex,
+	}
+	bestScore, err := biz.recomputeBest(ctx, filter, score.AttemptCount, score.Score, score.BestScore, *req.Score)
+	if err != nil {
+		return nil, err
+	}
+
+	feedback := req.Feedback
+	if feedback == "" {
+		feedback = score.Feedback
+	}
+
+	now := time.Now()
+	record := &model.ScoreOverride{
+		Kind:              model.KindSentence,
+		ScoreID:           score.ID,
+		UserID:            score.UserID,
+		TranslationID:     score.TranslationID,
+		SentenceIndex:     score.SentenceIndex,
+		AttemptNumber:     score.AttemptCount,
+		PreviousScore:     score.Score,
+		PreviousBestScore: score.BestScore,
+		PreviousFeedback:  score.Feedback,
+		Score:             *req.Score,
+		BestScore:         bestScore,
+		Feedback:          feedback,
+		Reason:            req.Reason,
+		Source:            source(req),
+		AppealID:          req.AppealID,
+		ReviewerID:        reviewerID,
+		CreatedAt:         now,
+	}
+	return biz.apply(ctx, record, score.PasteFlag, func() (bool, error) {
+		return biz.sentenceStore.OverrideUserScore(ctx, score.ID, score.AttemptCount, *req.Score, bestScore, feedback, now)
+	})
+}
+
+// recomputeBest works out the best score once the latest attempt is regraded.
+// Earlier attempts come from the attempt history; records older than the history fall back to
+// keeping the stored best when it did not come from the latest attempt.
+func (biz *overrideScoreBiz) recomputeBest(ctx context.Context, filter *attemptmodel.AttemptFilter, attemptCount int, oldScore, oldBest, newScore float64) (float64, error) {
+	earlierBest, err := biz.attemptStore.BestScoreBefore(ctx, filter, attemptCount)
+	if err != nil {
+		return 0, common.ErrCannotGetEntity("Attempt", err)
+	}
+
+	best := newScore
+	switch {
+	case earlierBest != nil:
+		if *earlierBest > best {
+			best = *earlierBest
+		}
+	case attemptCount > 1 && oldBest > oldScore:
+		if oldBest > best {
+			best = oldBest
+		}
+	}
+	return best, nil
+}
+
+func source(req *model.OverrideScoreRequest) string {
+	if req.Source == "" {
+		return model.SourceManual
+	}
+	return req.Source
+}
+
+// apply changes the score only while the reviewed attempt is still the latest one, then appends
+// the audit record. The trail is never rewritten: when the insert fails the error is returned so the
+// override can be retried, and writing the same grade again is harmless.
+func (biz *overrideScoreBiz) apply(ctx context.Context, record *model.ScoreOverride, pasteFlag *scoremodel.PasteFlag, updateScore func() (bool, error)) (*model.ScoreOverride, error) {
+	updated, err := updateScore()
+	if err != nil {
+		return nil, common.ErrCannotUpdateEntity("Score", err)
+	}
+	if !updated {
+		return nil, ErrScoreChanged
+	}
+
+	// Later overrides and re-grades work out the best score from the attempts, so they must see this grade
+	filter := &attemptmodel.AttemptFilter{
+		UserID:        record.UserID,
+		Kind:          record.Kind,
+		ChallengeID:   record.ChallengeID,
+		TranslationID: record.TranslationID,
+		SentenceIndex: record.SentenceIndex,
+	}
+	if err := biz.attemptStore.CorrectScore(ctx, filter, record.AttemptNumber, record.Score, record.CreatedAt); err != nil {
+		log.Printf("Correcting attempt %d of score %s failed: %v", record.AttemptNumber, record.ScoreID.Hex(), err)
+	}
+
+	scorebiz.NotifyScoreChange(ctx, biz.listeners, &scorebiz.ScoreChange{
+		Kind:          record.Kind,
+		UserID:        record.UserID,
+		ChallengeID:   record.ChallengeID,
+		TranslationID: record.TranslationID,
+		SentenceIndex: record.SentenceIndex,
+		PreviousBest:  record.PreviousBestScore,
+		BestScore:     record.BestScore,
+		PasteFlag:     pasteFlag,
+		ChangedAt:     record.CreatedAt,
+	})
+
+	if err := biz.overrideStore.Create(ctx, record); err != nil {
+		return nil, common.ErrCannotCreateEntity("ScoreOverride", err)
+	}
+	return record, nil
+}
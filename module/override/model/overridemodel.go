@@ -0,0 +1,69 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const CollectionName = "score_overrides"
+
+// Override kinds, matching the attempt kinds
+const (
+	KindChallenge = "challenge"
+	KindSentence  = "sentence"
+)
+
+// Override sources
+const (
+	SourceManual  = "manual"
+	SourceAppeal  = "appeal"
+	SourceRegrade = "regrade"
+)
+
+// ScoreOverride is an immutable audit record of a reviewer replacing an AI grade.
+// It is only ever inserted, so the collection is the full history of human corrections.
+type ScoreOverride struct {
+	ID                primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Kind              string             `json:"kind" bson:"kind"`
+	ScoreID           primitive.ObjectID `json:"score_id" bson:"score_id"`
+	UserID            primitive.ObjectID `json:"user_id" bson:"user_id"`
+	ChallengeID       primitive.ObjectID `json:"challenge_id,omitempty" bson:"challenge_id,omitempty"`
+	TranslationID     primitive.ObjectID `json:"translation_id,omitempty" bson:"translation_id,omitempty"`
+	SentenceIndex     int                `json:"sentence_index" bson:"sentence_index"`
+	AttemptNumber     int                `json:"attempt_number" bson:"attempt_number"`
+	PreviousScore     float64            `json:"previous_score" bson:"previous_score"`
+	PreviousBestScore float64            `json:"previous_best_score" bson:"previous_best_score"`
+	PreviousFeedback  string             `json:"previous_feedback" bson:"previous_feedback"`
+	Score             float64            `json:"score" bson:"score"`
+	BestScore         float64            `json:"best_score" bson:"best_score"`
+	Feedback          string             `json:"feedback" bson:"feedback"`
+	Reason            string             `json:"reason" bson:"reason"`
+	Source            string             `json:"source" bson:"source"`
+	AppealID          primitive.ObjectID `json:"appeal_id,omitempty" bson:"appeal_id,omitempty"`
+	ReviewerID        primitive.ObjectID `json:"reviewer_id" bson:"reviewer_id"`
+	CreatedAt         time.Time          `json:"created_at" bson:"created_at"`
+}
+
+func (ScoreOverride) TableName() string {
+	return CollectionName
+}
+
+// OverrideScoreRequest replaces the latest grade of a score record. Feedback is kept when empty.
+// The hidden fields are set by the appeal workflow: AttemptNumber, when non-zero, must still be
+// the record's latest attempt so a grade the learner has since replaced is not overridden.
+type OverrideScoreRequest struct {
+	Score         *float64           `json:"score" binding:"required,min=0,max=100" example:"85"`
+	Feedback      string             `json:"feedback"`
+	Reason        string             `json:"reason" binding:"required" example:"Synonym was marked as a mistranslation"`
+	Source        string             `json:"-"`
+	AppealID      primitive.ObjectID `json:"-"`
+	AttemptNumber int                `json:"-"`
+}
+
+// OverrideFilter narrows the audit list; zero values match everything
+type OverrideFilter struct {
+	UserID  primitive.ObjectID
+	Kind    string
+	ScoreID primitive.ObjectID
+}
@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/override/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Create appends an audit record; overrides are never updated
+func (s *Storage) Create(ctx context.Context, data *model.ScoreOverride) error {
+	if data.ID.IsZero() {
+		data.ID = primitive.NewObjectID()
+	}
+
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+	_, err := collection.InsertOne(ctx, data)
+	return err
+}
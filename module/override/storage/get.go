@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"hub-service/common"
+	"hub-service/module/override/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Storage) List(ctx context.Context, filter *model.OverrideFilter, paging *common.Paging) ([]model.ScoreOverride, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	query := bson.M{}
+	if !filter.UserID.IsZero() {
+		query["user_id"] = filter.UserID
+	}
+	if filter.Kind != "" {
+		query["kind"] = filter.Kind
+	}
+	if !filter.ScoreID.IsZero() {
+		query["score_id"] = filter.ScoreID
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSkip(int64((paging.Page - 1) * paging.Limit))
+	findOptions.SetLimit(int64(paging.Limit))
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	overrides := []model.ScoreOverride{}
+	if err = cursor.All(ctx, &overrides); err != nil {
+		return nil, err
+	}
+
+	total, err := collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	paging.Total = total
+
+	return overrides, nil
+}
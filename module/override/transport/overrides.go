@@ -0,0 +1,158 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	attemptstorage "hub-service/module/attempt/storage"
+	"hub-service/module/grading"
+	"hub-service/module/override/biz"
+	"hub-service/module/override/model"
+	"hub-service/module/override/storage"
+	scorestorage "hub-service/module/score/storage"
+	translationstorage "hub-service/module/translation/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OverrideChallengeScore godoc
+// @Summary Override a challenge score
+// @Description Replace the latest AI grade of a challenge score with a reviewer's score and feedback. The best score is recomputed from the attempt history, the learner sees the result as human reviewed, and an audit record is kept. Admin only.
+// @Tags overrides
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param score_id path string true "Score ID"
+// @Param request body model.OverrideScoreRequest true "New score and reason"
+// @Success 200 {object} common.Response{data=model.ScoreOverride} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Failure 404 {object} common.AppError "Score not found"
+// @Router /api/overrides/scores/{score_id} [post]
+func OverrideChallengeScore(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scoreID, req := bindOverride(c)
+		reviewerID := c.MustGet("user_id").(primitive.ObjectID)
+
+		db := appCtx.GetDatabase()
+		business := biz.NewOverrideScoreBiz(
+			storage.NewStorage(db),
+			scorestorage.NewStorage(db),
+			translationstorage.NewStorage(db),
+			attemptstorage.NewStorage(db),
+			grading.ScoreChangeListeners(appCtx)...,
+		)
+		result, err := business.OverrideChallengeScore(c.Request.Context(), scoreID, req, reviewerID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// OverrideSentenceScore godoc
+// @Summary Override a passage sentence score
+// @Description Replace the latest AI grade of one sentence score with a reviewer's score and feedback. The best score is recomputed from the attempt history, the learner sees the result as human reviewed, and an audit record is kept. Admin only.
+// @Tags overrides
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param score_id path string true "User translation score ID"
+// @Param request body model.OverrideScoreRequest true "New score and reason"
+// @Success 200 {object} common.Response{data=model.ScoreOverride} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Failure 404 {object} common.AppError "Score not found"
+// @Router /api/overrides/sentence-scores/{score_id} [post]
+func OverrideSentenceScore(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scoreID, req := bindOverride(c)
+		reviewerID := c.MustGet("user_id").(primitive.ObjectID)
+
+		db := appCtx.GetDatabase()
+		business := biz.NewOverrideScoreBiz(
+			storage.NewStorage(db),
+			scorestorage.NewStorage(db),
+			translationstorage.NewStorage(db),
+			attemptstorage.NewStorage(db),
+			grading.ScoreChangeListeners(appCtx)...,
+		)
+		result, err := business.OverrideSentenceScore(c.Request.Context(), scoreID, req, reviewerID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// ListOverrides godoc
+// @Summary List score overrides
+// @Description Audit trail of human score overrides, newest first. Admin only.
+// @Tags overrides
+// @Produce json
+// @Security BearerAuth
+// @Param user_id query string false "Learner ID"
+// @Param kind query string false "challenge or sentence"
+// @Param score_id query string false "Score ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Success 200 {object} common.Response{data=[]model.ScoreOverride,meta=common.Paging} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Router /api/overrides [get]
+func ListOverrides(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter := &model.OverrideFilter{
+			UserID:  optionalObjectID(c, "user_id"),
+			Kind:    c.Query("kind"),
+			ScoreID: optionalObjectID(c, "score_id"),
+		}
+
+		var paging common.Paging
+		if err := c.ShouldBind(&paging); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+		paging.Fulfill()
+
+		store := storage.NewStorage(appCtx.GetDatabase())
+		business := biz.NewListOverrideBiz(store)
+
+		result, err := business.ListOverrides(c.Request.Context(), filter, &paging)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.NewSuccessResponse(result, paging, nil))
+	}
+}
+
+func bindOverride(c *gin.Context) (primitive.ObjectID, *model.OverrideScoreRequest) {
+	scoreID, err := primitive.ObjectIDFromHex(c.Param("score_id"))
+	if err != nil {
+		panic(common.ErrInvalidRequest(err))
+	}
+
+	var req model.OverrideScoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		panic(common.ErrInvalidRequest(err))
+	}
+	return scoreID, &req
+}
+
+func optionalObjectID(c *gin.Context, key string) primitive.ObjectID {
+	value := c.Query(key)
+	if value == "" {
+		return primitive.NilObjectID
+	}
+	id, err := primitive.ObjectIDFromHex(value)
+	if err != nil {
+		panic(common.ErrInvalidRequest(err))
+	}
+	return id
+}
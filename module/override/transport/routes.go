@@ -0,0 +1,20 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
+	overrides := g.Group("/overrides")
+	overrides.Use(auth.AuthMiddleware(appCtx))
+	overrides.Use(auth.RequireRoles(common.RoleAdmin, common.RoleSuperAdmin))
+	{
+		overrides.GET("", ListOverrides(appCtx))
+		overrides.POST("/scores/:score_id", OverrideChallengeScore(appCtx))
+		overrides.POST("/sentence-scores/:score_id", OverrideSentenceScore(appCtx))
+	}
+}
@@ -0,0 +1,33 @@
+package biz
+
+import (
+	"context"
+	scorebiz "hub-service/module/score/biz"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// invalidator bumps the learner's progress version so cached series are rebuilt
+type invalidator struct {
+	cache ProgressCache
+}
+
+// NewInvalidator returns a SubmissionListener and ScoreChangeListener that expires the
+// learner's cached progress
+func NewInvalidator(cache ProgressCache) *invalidator {
+	return &invalidator{cache: cache}
+}
+
+// Invalidate expires every cached series of the learner
+func (i *invalidator) Invalidate(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := i.cache.Incr(progressVersionPrefix + userID.Hex())
+	return err
+}
+
+func (i *invalidator) OnSubmission(ctx context.Context, event *scorebiz.SubmissionEvent) error {
+	return i.Invalidate(ctx, event.UserID)
+}
+
+func (i *invalidator) OnScoreChange(ctx context.Context, change *scorebiz.ScoreChange) error {
+	return i.Invalidate(ctx, change.UserID)
+}
@@ -0,0 +1,261 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hub-service/common"
+	attemptmodel "hub-service/module/attempt/model"
+	"hub-service/module/progress/model"
+	"log"
+	"math"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	dateLayout              = "2006-01-02"
+	progressVersionPrefix   = "progress:version:"
+	defaultProgressCacheTTL = time.Hour
+)
+
+type ProgressStore interface {
+	AggregateAttempts(ctx context.Context, userID primitive.ObjectID, granularity, timezone string, from, to time.Time) ([]model.BucketStats, error)
+}
+
+// ProgressCache is the subset of the Redis client used to cache series
+type ProgressCache interface {
+	Get(key string) (string, error)
+	SetWithExpiry(key string, value interface{}, expiration time.Duration) error
+	Incr(key string) (int64, error)
+}
+
+// ProgressCacheTTL reads PROGRESS_CACHE_TTL (a Go duration); zero or negative disables caching
+func ProgressCacheTTL() time.Duration {
+	raw := os.Getenv("PROGRESS_CACHE_TTL")
+	if raw == "" {
+		return defaultProgressCacheTTL
+	}
+	ttl, err := time.ParseDuration(raw)
+	if err != nil {
+		log.Printf("Invalid PROGRESS_CACHE_TTL %q, using %s", raw, defaultProgressCacheTTL)
+		return defaultProgressCacheTTL
+	}
+	return ttl
+}
+
+type progressBiz struct {
+	store ProgressStore
+	cache ProgressCache
+	ttl   time.Duration
+}
+
+// NewProgressBiz builds the progress series; cache may be nil to always aggregate
+func NewProgressBiz(store ProgressStore, cache ProgressCache) *progressBiz {
+	return &progressBiz{store: store, cache: cache, ttl: ProgressCacheTTL()}
+}
+
+// GetProgress returns the learner's attempts, average score, new best scores and points
+// earned per day, week or month, for challenges and passages separately and combined
+func (biz *progressBiz) GetProgress(ctx context.Context, userID primitive.ObjectID, query *model.ProgressQuery) (*model.ProgressSeries, error) {
+	granularity := query.Granularity
+	if granularity == "" {
+		granularity = model.GranularityWeek
+	}
+
+	timezone := query.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, common.ErrInvalidRequest(fmt.Errorf("invalid timezone: %w", err))
+	}
+
+	from, to, err := seriesWindow(query, granularity, loc, time.Now())
+	if err != nil {
+		return nil, common.ErrInvalidRequest(err)
+	}
+
+	starts := periodStarts(from, to, granularity)
+	if len(starts) > model.MaxPoints {
+		return nil, common.ErrInvalidRequest(fmt.Errorf("range covers more than %d %ss", model.MaxPoints, granularity))
+	}
+
+	key := ""
+	if biz.cache != nil && biz.ttl > 0 {
+		key = biz.cacheKey(userID, granularity, timezone, from, to)
+		if cached, err := biz.cache.Get(key); err != nil {
+			log.Printf("Progress cache read failed: %v", err)
+		} else if cached != "" {
+			var series model.ProgressSeries
+			if err := json.Unmarshal([]byte(cached), &series); err == nil {
+				return &series, nil
+			}
+		}
+	}
+
+	stats, err := biz.store.AggregateAttempts(ctx, userID, granularity, timezone, from, to)
+	if err != nil {
+		return nil, common.ErrCannotListEntity("Attempt", err)
+	}
+
+	series := &model.ProgressSeries{
+		UserID:      userID,
+		Granularity: granularity,
+		Timezone:    timezone,
+		From:        from,
+		To:          to,
+		Points:      buildPoints(starts, granularity, stats),
+	}
+
+	if key != "" {
+		if payload, err := json.Marshal(series); err == nil {
+			if err := biz.cache.SetWithExpiry(key, payload, biz.ttl); err != nil {
+				log.Printf("Progress cache write failed: %v", err)
+			}
+		}
+	}
+
+	return series, nil
+}
+
+// cacheKey includes the user's progress version, which every submission bumps,
+// so a cached series is never served once it is out of date
+func (biz *progressBiz) cacheKey(userID primitive.ObjectID, granularity, timezone string, from, to time.Time) string {
+	version, err := biz.cache.Get(progressVersionPrefix + userID.Hex())
+	if err != nil || version == "" {
+		version = "0"
+	}
+	return fmt.Sprintf("progress:%s:%s:%s:%s:%s:%s",
+		userID.Hex(), version, granularity, timezone, from.Format(dateLayout), to.Format(dateLayout))
+}
+
+// seriesWindow resolves the query to [from, to) in loc. from is moved back to the start
+// of its period so the first bucket is complete. Without dates the window covers the last
+// 30 days, 12 weeks or 12 months.
+func seriesWindow(query *model.ProgressQuery, granularity string, loc *time.Location, now time.Time) (time.Time, time.Time, error) {
+	local := now.In(loc)
+	to := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+	if query.To != "" {
+		t, err := time.ParseInLocation(dateLayout, query.To, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to date: %w", err)
+		}
+		to = t.AddDate(0, 0, 1)
+	}
+
+	var from time.Time
+	if query.From != "" {
+		t, err := time.ParseInLocation(dateLayout, query.From, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from date: %w", err)
+		}
+		from = t
+	} else {
+		last := to.AddDate(0, 0, -1)
+		switch granularity {
+		case model.GranularityDay:
+			from = last.AddDate(0, 0, -29)
+		case model.GranularityMonth:
+			from = last.AddDate(0, -11, 0)
+		default:
+			from = last.AddDate(0, 0, -7*11)
+		}
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, errors.New("from must not be after to")
+	}
+	return periodStart(from, granularity), to, nil
+}
+
+func periodStart(t time.Time, granularity string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch granularity {
+	case model.GranularityMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	case model.GranularityWeek:
+		// ISO weeks start on Monday
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	}
+	return day
+}
+
+func periodStarts(from, to time.Time, granularity string) []time.Time {
+	starts := []time.Time{}
+	for start := from; start.Before(to) && len(starts) <= model.MaxPoints; {
+		starts = append(starts, start)
+		switch granularity {
+		case model.GranularityMonth:
+			start = start.AddDate(0, 1, 0)
+		case model.GranularityWeek:
+			start = start.AddDate(0, 0, 7)
+		default:
+			start = start.AddDate(0, 0, 1)
+		}
+	}
+	return starts
+}
+
+// periodLabel matches the $dateToString formats used by the storage aggregation
+func periodLabel(start time.Time, granularity string) string {
+	switch granularity {
+	case model.GranularityMonth:
+		return start.Format("2006-01")
+	case model.GranularityWeek:
+		year, week := start.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	}
+	return start.Format(dateLayout)
+}
+
+func buildPoints(starts []time.Time, granularity string, stats []model.BucketStats) []model.ProgressPoint {
+	byPeriod := map[string]map[string]model.BucketStats{}
+	for _, s := range stats {
+		if byPeriod[s.Period] == nil {
+			byPeriod[s.Period] = map[string]model.BucketStats{}
+		}
+		byPeriod[s.Period][s.Kind] = s
+	}
+
+	points := make([]model.ProgressPoint, 0, len(starts))
+	for _, start := range starts {
+		label := periodLabel(start, granularity)
+		challenges := byPeriod[label][attemptmodel.KindChallenge]
+		sentences := byPeriod[label][attemptmodel.KindSentence]
+
+		points = append(points, model.ProgressPoint{
+			Period:     label,
+			Start:      start,
+			Challenges: kindProgress(challenges),
+			Passages:   kindProgress(sentences),
+			Total: kindProgress(model.BucketStats{
+				Attempts:     challenges.Attempts + sentences.Attempts,
+				ScoreSum:     challenges.ScoreSum + sentences.ScoreSum,
+				NewBests:     challenges.NewBests + sentences.NewBests,
+				PointsGained: challenges.PointsGained + sentences.PointsGained,
+			}),
+		})
+	}
+	return points
+}
+
+func kindProgress(s model.BucketStats) model.KindProgress {
+	progress := model.KindProgress{
+		Attempts:         s.Attempts,
+		BestScoresGained: s.NewBests,
+		PointsEarned:     round2(s.PointsGained),
+	}
+	if s.Attempts > 0 {
+		progress.AverageScore = round2(s.ScoreSum / float64(s.Attempts))
+	}
+	return progress
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Bucket granularities
+const (
+	GranularityDay   = "day"
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+)
+
+// MaxPoints caps the number of buckets in one series
+const MaxPoints = 366
+
+// ProgressQuery selects the series. Dates are days in the requested timezone, e.g. 2026-10-01.
+type ProgressQuery struct {
+	Granularity string `form:"granularity" binding:"omitempty,oneof=day week month" example:"week"`
+	From        string `form:"from" example:"2026-07-01"`
+	To          string `form:"to" example:"2026-10-17"`
+	Timezone    string `form:"timezone" example:"Asia/Ho_Chi_Minh"`
+}
+
+// BucketStats is the aggregated attempt history of one kind in one bucket
+type BucketStats struct {
+	Period       string  `bson:"period"`
+	Kind         string  `bson:"kind"`
+	Attempts     int     `bson:"attempts"`
+	ScoreSum     float64 `bson:"score_sum"`
+	NewBests     int     `bson:"new_bests"`
+	PointsGained float64 `bson:"points_gained"`
+}
+
+// KindProgress is what the learner did in one bucket
+type KindProgress struct {
+	Attempts         int     `json:"attempts"`
+	AverageScore     float64 `json:"average_score" example:"72.5"`
+	BestScoresGained int     `json:"best_scores_gained"`
+	PointsEarned     float64 `json:"points_earned" example:"35"`
+}
+
+// ProgressPoint is one bucket of the series. Points earned is how much the learner's
+// best scores rose, so it adds up to the growth of their total score.
+type ProgressPoint struct {
+	Period     string       `json:"period" example:"2026-W42"`
+	Start      time.Time    `json:"start"`
+	Challenges KindProgress `json:"challenges"`
+	Passages   KindProgress `json:"passages"`
+	Total      KindProgress `json:"total"`
+}
+
+// ProgressSeries is the learner's progress over time, one point per bucket including empty ones
+type ProgressSeries struct {
+	UserID      primitive.ObjectID `json:"user_id"`
+	Granularity string             `json:"granularity"`
+	Timezone    string             `json:"timezone"`
+	From        time.Time          `json:"from"`
+	To          time.Time          `json:"to"`
+	Points      []ProgressPoint    `json:"points"`
+}
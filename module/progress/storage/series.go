@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+	attemptmodel "hub-service/module/attempt/model"
+	"hub-service/module/progress/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// periodFormats are $dateToString formats matching the labels built by the biz layer
+var periodFormats = map[string]string{
+	model.GranularityDay:   "%Y-%m-%d",
+	model.GranularityWeek:  "%G-W%V",
+	model.GranularityMonth: "%Y-%m",
+}
+
+// AggregateAttempts buckets the user's attempts made in [from, to) by period in timezone and by kind.
+// Overrides, appeals and applied re-grades correct the attempt's score, so the series follows them;
+// whether a corrected attempt set a new best is worked out again from its corrected score.
+func (s *Storage) AggregateAttempts(ctx context.Context, userID primitive.ObjectID, granularity, timezone string, from, to time.Time) ([]model.BucketStats, error) {
+	collection := s.db.MongoDB.GetCollection(attemptmodel.CollectionName)
+
+	isNewBest := bson.M{"$cond": []interface{}{
+		bson.M{"$ifNull": []interface{}{"$corrected_at", false}},
+		bson.M{"$gt": []string{"$score", "$previous_best"}},
+		"$is_new_best",
+	}}
+
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"user_id":    userID,
+			"created_at": bson.M{"$gte": from, "$lt": to},
+		}},
+		{"$group": bson.M{
+			"_id": bson.M{
+				"period": bson.M{"$dateToString": bson.M{
+					"format":   periodFormats[granularity],
+					"date":     "$created_at",
+					"timezone": timezone,
+				}},
+				"kind": "$kind",
+			},
+			"attempts":  bson.M{"$sum": 1},
+			"score_sum": bson.M{"$sum": "$score"},
+			"new_bests": bson.M{"$sum": bson.M{"$cond": []interface{}{isNewBest, 1, 0}}},
+			"points_gained": bson.M{"$sum": bson.M{"$cond": []interface{}{
+				isNewBest,
+				bson.M{"$max": []interface{}{0, bson.M{"$subtract": []string{"$score", "$previous_best"}}}},
+				0,
+			}}},
+		}},
+		{"$project": bson.M{
+			"_id":           0,
+			"period":        "$_id.period",
+			"kind":          "$_id.kind",
+			"attempts":      1,
+			"score_sum":     1,
+			"new_bests":     1,
+			"points_gained": 1,
+		}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	stats := []model.BucketStats{}
+	if err = cursor.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
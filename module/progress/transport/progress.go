@@ -0,0 +1,51 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/module/progress/biz"
+	"hub-service/module/progress/model"
+	"hub-service/module/progress/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetMyProgress godoc
+// @Summary Get my progress over time
+// @Description Attempts, average score, best scores gained and points earned per day, week or month, for challenges, passages and both combined. Periods without attempts are included with zeros so the series can be drawn as a learning curve.
+// @Tags progress
+// @Produce json
+// @Security BearerAuth
+// @Param granularity query string false "day, week or month" default(week)
+// @Param from query string false "First day, e.g. 2026-07-01 (defaults to 30 days, 12 weeks or 12 months before to)"
+// @Param to query string false "Last day, inclusive (defaults to today)"
+// @Param timezone query string false "IANA timezone used for bucketing, e.g. Asia/Ho_Chi_Minh" default(UTC)
+// @Success 200 {object} common.Response{data=model.ProgressSeries} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Router /api/progress/me [get]
+func GetMyProgress(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("user_id").(primitive.ObjectID)
+
+		var query model.ProgressQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		var cache biz.ProgressCache
+		if rdb := appCtx.GetRedis(); rdb != nil {
+			cache = rdb
+		}
+
+		business := biz.NewProgressBiz(storage.NewStorage(appCtx.GetDatabase()), cache)
+		result, err := business.GetProgress(c.Request.Context(), userID, &query)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
@@ -0,0 +1,16 @@
+package transport
+
+import (
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
+	progress := g.Group("/progress")
+	progress.Use(auth.AuthMiddleware(appCtx))
+	{
+		progress.GET("/me", GetMyProgress(appCtx))
+	}
+}
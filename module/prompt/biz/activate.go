@@ -0,0 +1,40 @@
+package biz
+
+import (
+	"context"
+	"hub-service/common"
+	"hub-service/module/prompt/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ActivatePromptStore interface {
+	Get(ctx context.Context, id primitive.ObjectID) (*model.Prompt, error)
+	Activate(ctx context.Context, prompt *model.Prompt) error
+}
+
+type activatePromptBiz struct {
+	store ActivatePromptStore
+}
+
+func NewActivatePromptBiz(store ActivatePromptStore) *activatePromptBiz {
+	return &activatePromptBiz{store: store}
+}
+
+// ActivatePrompt switches grading to this version; previous versions stay stored for rollback
+func (biz *activatePromptBiz) ActivatePrompt(ctx context.Context, id primitive.ObjectID) (*model.Prompt, error) {
+	prompt, err := biz.store.Get(ctx, id)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("Prompt", err)
+	}
+	if prompt == nil {
+		return nil, common.ErrEntityNotFound("Prompt", common.RecordNotFound)
+	}
+
+	if err := biz.store.Activate(ctx, prompt); err != nil {
+		return nil, common.ErrCannotUpdateEntity("Prompt", err)
+	}
+
+	invalidateActive(prompt.Name)
+	return prompt, nil
+}
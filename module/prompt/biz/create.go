@@ -0,0 +1,68 @@
+package biz
+
+import (
+	"context"
+	"hub-service/common"
+	"hub-service/module/prompt/model"
+	scorebiz "hub-service/module/score/biz"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const maxCreateRetries = 3
+
+type CreatePromptStore interface {
+	GetLatest(ctx context.Context, name string) (*model.Prompt, error)
+	Create(ctx context.Context, data *model.Prompt) error
+}
+
+type createPromptBiz struct {
+	store CreatePromptStore
+}
+
+func NewCreatePromptBiz(store CreatePromptStore) *createPromptBiz {
+	return &createPromptBiz{store: store}
+}
+
+// CreatePrompt stores the template as the next, inactive version of its name
+func (biz *createPromptBiz) CreatePrompt(ctx context.Context, req *model.CreatePromptRequest, createdBy primitive.ObjectID) (*model.Prompt, error) {
+	name := strings.ToLower(strings.TrimSpace(req.Name))
+
+	variables, err := scorebiz.ValidatePromptTemplate(req.Template)
+	if err != nil {
+		return nil, common.ErrInvalidRequest(err)
+	}
+
+	// The (name, version) index rejects a version taken by a concurrent create; take the next one
+	for try := 0; ; try++ {
+		latest, err := biz.store.GetLatest(ctx, name)
+		if err != nil {
+			return nil, common.ErrDB(err)
+		}
+
+		version := 1
+		if latest != nil {
+			version = latest.Version + 1
+		}
+
+		prompt := &model.Prompt{
+			Name:        name,
+			Version:     version,
+			Template:    req.Template,
+			Variables:   variables,
+			Description: req.Description,
+			CreatedBy:   createdBy,
+			CreatedAt:   time.Now(),
+		}
+		err = biz.store.Create(ctx, prompt)
+		if err == nil {
+			return prompt, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) || try >= maxCreateRetries {
+			return nil, common.ErrCannotCreateEntity("Prompt", err)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package biz
+
+import (
+	"context"
+	"hub-service/common"
+	"hub-service/module/prompt/model"
+)
+
+type ListPromptStore interface {
+	ListVersions(ctx context.Context, name string) ([]model.Prompt, error)
+}
+
+type listPromptBiz struct {
+	store ListPromptStore
+}
+
+func NewListPromptBiz(store ListPromptStore) *listPromptBiz {
+	return &listPromptBiz{store: store}
+}
+
+func (biz *listPromptBiz) ListPrompts(ctx context.Context, name string) ([]model.Prompt, error) {
+	result, err := biz.store.ListVersions(ctx, name)
+	if err != nil {
+		return nil, common.ErrCannotListEntity("Prompt", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,102 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"hub-service/common"
+	"hub-service/infrastructure/external/llm"
+	"hub-service/module/prompt/model"
+	scorebiz "hub-service/module/score/biz"
+	usagebiz "hub-service/module/usage/biz"
+	usagemodel "hub-service/module/usage/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// draftPromptVersion labels unsaved templates run in the playground
+const draftPromptVersion = "draft"
+
+type PlaygroundStore interface {
+	Get(ctx context.Context, id primitive.ObjectID) (*model.Prompt, error)
+}
+
+type playgroundBiz struct {
+	store         PlaygroundStore
+	client        llm.Client
+	languagePairs scorebiz.LanguagePairs
+}
+
+func NewPlaygroundBiz(store PlaygroundStore, client llm.Client, languagePairs scorebiz.LanguagePairs) *playgroundBiz {
+	return &playgroundBiz{store: store, client: client, languagePairs: languagePairs}
+}
+
+// Run grades every sample with the chosen template. Results are never persisted and
+// bypass the grading cache, so admins see exactly what the draft produces.
+func (biz *playgroundBiz) Run(ctx context.Context, req *model.PlaygroundRequest) (*model.PlaygroundResponse, error) {
+	tmpl, err := biz.resolveTemplate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	grader, err := scorebiz.NewPromptedGrader(biz.client, tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = usagebiz.WithCaller(ctx, usagemodel.FeaturePlayground, primitive.NilObjectID)
+
+	response := &model.PlaygroundResponse{
+		PromptVersion: tmpl.Version,
+		Results:       make([]model.PlaygroundResult, 0, len(req.Samples)),
+	}
+	for _, sample := range req.Samples {
+		started := time.Now()
+		var analysis *scorebiz.GrammarAnalysis
+		gradeReq, err := biz.languagePairs.NewGradeRequest(sample.OriginalText, sample.UserTranslation, sample.SourceLanguage, sample.TargetLanguage, sample.FeedbackLanguage)
+		if err == nil {
+			analysis, err = grader.AnalyzeGrammar(ctx, gradeReq)
+		}
+
+		result := model.PlaygroundResult{
+			Sample:    sample,
+			LatencyMs: time.Since(started).Milliseconds(),
+		}
+		if err != nil {
+			result.Error = err.Error()
+			if appErr, ok := err.(*common.AppError); ok {
+				result.Error = appErr.Key + ": " + appErr.Message
+			}
+		} else {
+			result.Analysis = analysis
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	return response, nil
+}
+
+func (biz *playgroundBiz) resolveTemplate(ctx context.Context, req *model.PlaygroundRequest) (*scorebiz.PromptTemplate, error) {
+	if req.PromptID != "" {
+		id, err := primitive.ObjectIDFromHex(req.PromptID)
+		if err != nil {
+			return nil, common.ErrInvalidRequest(err)
+		}
+		prompt, err := biz.store.Get(ctx, id)
+		if err != nil {
+			return nil, common.ErrCannotGetEntity("Prompt", err)
+		}
+		if prompt == nil {
+			return nil, common.ErrEntityNotFound("Prompt", common.RecordNotFound)
+		}
+		return &scorebiz.PromptTemplate{Version: prompt.VersionLabel(), Text: prompt.Template}, nil
+	}
+
+	if req.Template == "" {
+		return nil, common.ErrInvalidRequest(errors.New("either prompt_id or template is required"))
+	}
+	if _, err := scorebiz.ValidatePromptTemplate(req.Template); err != nil {
+		return nil, common.ErrInvalidRequest(err)
+	}
+	return &scorebiz.PromptTemplate{Version: draftPromptVersion, Text: req.Template}, nil
+}
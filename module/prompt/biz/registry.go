@@ -0,0 +1,68 @@
+package biz
+
+import (
+	"context"
+	"hub-service/module/prompt/model"
+	scorebiz "hub-service/module/score/biz"
+	"sync"
+	"time"
+)
+
+// activeCacheTTL bounds how long other instances keep grading with a previously active prompt
+const activeCacheTTL = 30 * time.Second
+
+type ActivePromptStore interface {
+	GetActive(ctx context.Context, name string) (*model.Prompt, error)
+}
+
+type cachedPrompt struct {
+	template  *scorebiz.PromptTemplate
+	expiresAt time.Time
+}
+
+var (
+	activeCacheMu sync.Mutex
+	activeCache   = map[string]cachedPrompt{}
+)
+
+// registry serves active prompts to graders; it implements scorebiz.PromptSource
+type registry struct {
+	store ActivePromptStore
+}
+
+func NewRegistry(store ActivePromptStore) *registry {
+	return &registry{store: store}
+}
+
+// ActivePrompt returns the active version of name, or nil so graders fall back to the builtin prompt
+func (r *registry) ActivePrompt(ctx context.Context, name string) (*scorebiz.PromptTemplate, error) {
+	activeCacheMu.Lock()
+	cached, ok := activeCache[name]
+	activeCacheMu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.template, nil
+	}
+
+	prompt, err := r.store.GetActive(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var tmpl *scorebiz.PromptTemplate
+	if prompt != nil {
+		tmpl = &scorebiz.PromptTemplate{Version: prompt.VersionLabel(), Text: prompt.Template}
+	}
+
+	activeCacheMu.Lock()
+	activeCache[name] = cachedPrompt{template: tmpl, expiresAt: time.Now().Add(activeCacheTTL)}
+	activeCacheMu.Unlock()
+
+	return tmpl, nil
+}
+
+// invalidateActive drops the cached active prompt so this instance picks up a change immediately
+func invalidateActive(name string) {
+	activeCacheMu.Lock()
+	delete(activeCache, name)
+	activeCacheMu.Unlock()
+}
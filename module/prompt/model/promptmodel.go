@@ -0,0 +1,71 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const CollectionName = "prompts"
+
+// Prompt is one immutable version of a named prompt template.
+// Templates reference variables as {{name}}; at most one version per name is active.
+type Prompt struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name        string             `json:"name" bson:"name"`
+	Version     int                `json:"version" bson:"version"`
+	Template    string             `json:"template" bson:"template"`
+	Variables   []string           `json:"variables" bson:"variables"`
+	Description string             `json:"description" bson:"description"`
+	IsActive    bool               `json:"is_active" bson:"is_active"`
+	CreatedBy   primitive.ObjectID `json:"created_by" bson:"created_by"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	ActivatedAt *time.Time         `json:"activated_at,omitempty" bson:"activated_at,omitempty"`
+}
+
+func (Prompt) TableName() string {
+	return CollectionName
+}
+
+// VersionLabel is the label stored on score records, e.g. "grammar-v3"
+func (p *Prompt) VersionLabel() string {
+	return fmt.Sprintf("%s-v%d", p.Name, p.Version)
+}
+
+// CreatePromptRequest adds a new inactive version of a prompt
+type CreatePromptRequest struct {
+	Name        string `json:"name" binding:"required" example:"grammar"`
+	Template    string `json:"template" binding:"required"`
+	Description string `json:"description"`
+}
+
+// PlaygroundSample is one input to grade with a draft prompt
+type PlaygroundSample struct {
+	OriginalText     string `json:"original_text" binding:"required"`
+	UserTranslation  string `json:"user_translation" binding:"required"`
+	SourceLanguage   string `json:"source_language" binding:"required" example:"vi"`
+	TargetLanguage   string `json:"target_language" binding:"required" example:"en"`
+	FeedbackLanguage string `json:"feedback_language" example:"vi"`
+}
+
+// PlaygroundRequest runs either a stored version (prompt_id) or an unsaved template against samples
+type PlaygroundRequest struct {
+	PromptID string             `json:"prompt_id"`
+	Template string             `json:"template"`
+	Samples  []PlaygroundSample `json:"samples" binding:"required,min=1,max=10,dive"`
+}
+
+// PlaygroundResult is the outcome of grading one sample
+type PlaygroundResult struct {
+	Sample    PlaygroundSample `json:"sample"`
+	Analysis  interface{}      `json:"analysis,omitempty"`
+	Error     string           `json:"error,omitempty"`
+	LatencyMs int64            `json:"latency_ms"`
+}
+
+// PlaygroundResponse reports the prompt that was run and one result per sample
+type PlaygroundResponse struct {
+	PromptVersion string             `json:"prompt_version"`
+	Results       []PlaygroundResult `json:"results"`
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/prompt/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Storage) Get(ctx context.Context, id primitive.ObjectID) (*model.Prompt, error) {
+	return s.findOne(ctx, bson.M{"_id": id})
+}
+
+// GetActive returns the active version of a prompt, or nil when none is active.
+// While an activation is switching off the previous version, the latest activated one wins.
+func (s *Storage) GetActive(ctx context.Context, name string) (*model.Prompt, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "activated_at", Value: -1}})
+	return s.findOne(ctx, bson.M{"name": name, "is_active": true}, opts)
+}
+
+// GetLatest returns the highest version of a prompt, or nil when it has none
+func (s *Storage) GetLatest(ctx context.Context, name string) (*model.Prompt, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+	return s.findOne(ctx, bson.M{"name": name}, opts)
+}
+
+// ListVersions returns every version of a prompt, newest first
+func (s *Storage) ListVersions(ctx context.Context, name string) ([]model.Prompt, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	filter := bson.M{}
+	if name != "" {
+		filter["name"] = name
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "version", Value: -1}})
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	prompts := []model.Prompt{}
+	if err := cursor.All(ctx, &prompts); err != nil {
+		return nil, err
+	}
+	return prompts, nil
+}
+
+func (s *Storage) findOne(ctx context.Context, filter bson.M, opts ...*options.FindOneOptions) (*model.Prompt, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	var prompt model.Prompt
+	err := collection.FindOne(ctx, filter, opts...).Decode(&prompt)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &prompt, nil
+}
@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/prompt/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureIndexes makes each version number unique per prompt name, so concurrent creates
+// cannot both store the same version
+func (s *Storage) EnsureIndexes(ctx context.Context) error {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "name", Value: 1}, {Key: "version", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
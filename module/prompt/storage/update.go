@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/prompt/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Activate makes prompt the active version of its name. The activation itself is a single
+// write: GetActive serves the most recently activated version, so concurrent activations
+// resolve to the later one even before the older versions are switched off.
+func (s *Storage) Activate(ctx context.Context, prompt *model.Prompt) error {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	now := time.Now()
+	if _, err := collection.UpdateOne(ctx,
+		bson.M{"_id": prompt.ID},
+		bson.M{"$set": bson.M{"is_active": true, "activated_at": now}},
+	); err != nil {
+		return err
+	}
+
+	// Only versions activated earlier are switched off, so a later activation is never undone
+	if _, err := collection.UpdateMany(ctx,
+		bson.M{"name": prompt.Name, "is_active": true, "_id": bson.M{"$ne": prompt.ID}, "activated_at": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"is_active": false}},
+	); err != nil {
+		return err
+	}
+
+	prompt.IsActive = true
+	prompt.ActivatedAt = &now
+	return nil
+}
@@ -0,0 +1,138 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/module/prompt/biz"
+	"hub-service/module/prompt/model"
+	"hub-service/module/prompt/storage"
+	scorebiz "hub-service/module/score/biz"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CreatePrompt godoc
+// @Summary Create a prompt version
+// @Description Store a template as the next inactive version of a prompt. Templates must use {{original_text}} and {{user_translation}}, and may use {{source_language}}, {{target_language}}, {{feedback_language}} (language names, e.g. English) and {{reference_translation}} (the content's first reference translation, else its machine translation, else "(none provided)"). Any other variable is rejected. Admin only.
+// @Tags prompts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.CreatePromptRequest true "Prompt template"
+// @Success 200 {object} common.Response{data=model.Prompt} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Router /api/prompts [post]
+func CreatePrompt(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req model.CreatePromptRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		userID := c.MustGet("user_id").(primitive.ObjectID)
+
+		store := storage.NewStorage(appCtx.GetDatabase())
+		business := biz.NewCreatePromptBiz(store)
+
+		result, err := business.CreatePrompt(c.Request.Context(), &req, userID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// ListPrompts godoc
+// @Summary List prompt versions
+// @Description List every stored version, optionally filtered by prompt name. Admin only.
+// @Tags prompts
+// @Produce json
+// @Security BearerAuth
+// @Param name query string false "Prompt name" example(grammar)
+// @Success 200 {object} common.Response{data=[]model.Prompt} "Success"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Router /api/prompts [get]
+func ListPrompts(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		store := storage.NewStorage(appCtx.GetDatabase())
+		business := biz.NewListPromptBiz(store)
+
+		result, err := business.ListPrompts(c.Request.Context(), c.Query("name"))
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// ActivatePrompt godoc
+// @Summary Activate a prompt version
+// @Description Make this version the one used for grading; other versions of the same name are deactivated. Admin only.
+// @Tags prompts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Prompt ID"
+// @Success 200 {object} common.Response{data=model.Prompt} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Router /api/prompts/{id}/activate [post]
+func ActivatePrompt(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := primitive.ObjectIDFromHex(c.Param("id"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		store := storage.NewStorage(appCtx.GetDatabase())
+		business := biz.NewActivatePromptBiz(store)
+
+		result, err := business.ActivatePrompt(c.Request.Context(), id)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// RunPlayground godoc
+// @Summary Try a prompt against sample inputs
+// @Description Grade up to 10 samples with a stored version (prompt_id) or an unsaved template, without saving anything. Admin only.
+// @Tags prompts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.PlaygroundRequest true "Prompt and samples"
+// @Success 200 {object} common.Response{data=model.PlaygroundResponse} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Router /api/prompts/playground [post]
+func RunPlayground(appCtx appctx.AppContext) gin.HandlerFunc {
+	pairs := scorebiz.SupportedLanguagePairs()
+
+	return func(c *gin.Context) {
+		var req model.PlaygroundRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		store := storage.NewStorage(appCtx.GetDatabase())
+		business := biz.NewPlaygroundBiz(store, appCtx.GetLLMClient(), pairs)
+
+		result, err := business.Run(c.Request.Context(), &req)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
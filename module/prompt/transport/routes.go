@@ -0,0 +1,21 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
+	prompts := g.Group("/prompts")
+	prompts.Use(auth.AuthMiddleware(appCtx))
+	prompts.Use(auth.RequireRoles(common.RoleAdmin, common.RoleSuperAdmin))
+	{
+		prompts.GET("", ListPrompts(appCtx))
+		prompts.POST("", CreatePrompt(appCtx))
+		prompts.POST("/:id/activate", ActivatePrompt(appCtx))
+		prompts.POST("/playground", RunPlayground(appCtx))
+	}
+}
@@ -0,0 +1,143 @@
+package biz
+
+import (
+	"errors"
+	"fmt"
+	"hub-service/common"
+	"hub-service/module/quota/model"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultLimits apply when QUOTA_<ROLE>_PER_MINUTE / QUOTA_<ROLE>_PER_DAY are not set
+var defaultLimits = map[string]model.Limits{
+	model.RoleAnonymous:   {PerMinute: 5, PerDay: 30},
+	common.RoleClient:     {PerMinute: 10, PerDay: 200},
+	common.RoleAdmin:      {PerMinute: 0, PerDay: 0},
+	common.RoleSuperAdmin: {PerMinute: 0, PerDay: 0},
+}
+
+var ErrQuotaExceeded = common.NewFullErrorResponse(
+	http.StatusTooManyRequests,
+	errors.New("ai quota exceeded"),
+	"You have used up your AI grading quota, please try again later",
+	"ai quota exceeded",
+	"ErrQuotaExceeded",
+)
+
+type QuotaStore interface {
+	Increment(subject string, minute, day time.Time) (int64, int64, error)
+	Release(subject string, minute, day time.Time) error
+	Get(subject string, minute, day time.Time) (int64, int64, error)
+	Reset(subject string, minute, day time.Time) error
+}
+
+type quotaBiz struct {
+	store QuotaStore
+	now   func() time.Time
+}
+
+func NewQuotaBiz(store QuotaStore) *quotaBiz {
+	return &quotaBiz{store: store, now: time.Now}
+}
+
+// LimitsForRole reads the per-role limits from the environment
+func LimitsForRole(role string) model.Limits {
+	limits, ok := defaultLimits[role]
+	if !ok {
+		limits = defaultLimits[common.RoleClient]
+	}
+
+	envRole := strings.ToUpper(role)
+	limits.PerMinute = envLimit("QUOTA_"+envRole+"_PER_MINUTE", limits.PerMinute)
+	limits.PerDay = envLimit("QUOTA_"+envRole+"_PER_DAY", limits.PerDay)
+	return limits
+}
+
+func envLimit(key string, fallback int64) int64 {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || value < 0 {
+		log.Printf("Invalid %s %q, using %d", key, raw, fallback)
+		return fallback
+	}
+	return value
+}
+
+// Subject builds the Redis subject for a user ID or an IP address
+func Subject(kind, id string) string {
+	return fmt.Sprintf("%s:%s", kind, id)
+}
+
+// Consume counts one AI call for subject. When a window is exceeded the call is
+// not counted and the returned usage carries the time until it resets.
+func (biz *quotaBiz) Consume(subject, role string) (*model.Usage, error) {
+	now := biz.now().UTC()
+	limits := LimitsForRole(role)
+
+	minuteUsed, dayUsed, err := biz.store.Increment(subject, now, now)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := buildUsage(subject, role, limits, minuteUsed, dayUsed, now)
+	if !usage.Exceeded() {
+		return usage, nil
+	}
+
+	if err := biz.store.Release(subject, now, now); err != nil {
+		log.Printf("Failed to release quota for %s: %v", subject, err)
+	}
+	return usage, ErrQuotaExceeded
+}
+
+// GetUsage reports the current windows without consuming quota
+func (biz *quotaBiz) GetUsage(subject, role string) (*model.Usage, error) {
+	now := biz.now().UTC()
+
+	minuteUsed, dayUsed, err := biz.store.Get(subject, now, now)
+	if err != nil {
+		return nil, common.ErrDB(err)
+	}
+
+	return buildUsage(subject, role, LimitsForRole(role), minuteUsed, dayUsed, now), nil
+}
+
+// ResetUsage clears the current minute and day windows of subject
+func (biz *quotaBiz) ResetUsage(subject string) error {
+	now := biz.now().UTC()
+	if err := biz.store.Reset(subject, now, now); err != nil {
+		return common.ErrDB(err)
+	}
+	return nil
+}
+
+func buildUsage(subject, role string, limits model.Limits, minuteUsed, dayUsed int64, now time.Time) *model.Usage {
+	minuteStart := now.Truncate(time.Minute)
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	return &model.Usage{
+		Subject: subject,
+		Role:    role,
+		Minute:  window(minuteUsed, limits.PerMinute, minuteStart.Add(time.Minute)),
+		Day:     window(dayUsed, limits.PerDay, dayStart.AddDate(0, 0, 1)),
+	}
+}
+
+func window(used, limit int64, resetsAt time.Time) model.Window {
+	remaining := int64(-1) // unlimited
+	if limit > 0 {
+		remaining = limit - used
+		if remaining < 0 {
+			remaining = 0
+		}
+	}
+	return model.Window{Used: used, Limit: limit, Remaining: remaining, ResetsAt: resetsAt}
+}
@@ -0,0 +1,56 @@
+package model
+
+import "time"
+
+// RoleAnonymous is the quota role for unauthenticated callers, who are limited per IP
+const RoleAnonymous = "anonymous"
+
+// Subject prefixes used in Redis keys and admin APIs
+const (
+	SubjectUser = "user"
+	SubjectIP   = "ip"
+)
+
+// Limits caps AI calls per fixed minute and per UTC day; 0 means unlimited
+type Limits struct {
+	PerMinute int64 `json:"per_minute"`
+	PerDay    int64 `json:"per_day"`
+}
+
+// Window is the usage of one fixed window
+type Window struct {
+	Used      int64     `json:"used"`
+	Limit     int64     `json:"limit"`
+	Remaining int64     `json:"remaining"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
+
+// Usage is the quota state of one user or IP
+type Usage struct {
+	Subject string `json:"subject" example:"user:62b4c3789196e8a159933552"`
+	Role    string `json:"role"`
+	Minute  Window `json:"minute"`
+	Day     Window `json:"day"`
+}
+
+// Exceeded reports whether either window is over its limit
+func (u *Usage) Exceeded() bool {
+	return overLimit(u.Minute) || overLimit(u.Day)
+}
+
+// RetryAfter is the time until every exceeded window has reset
+func (u *Usage) RetryAfter(now time.Time) time.Duration {
+	var wait time.Duration
+	for _, w := range []Window{u.Minute, u.Day} {
+		if overLimit(w) {
+			if d := w.ResetsAt.Sub(now); d > wait {
+				wait = d
+			}
+		}
+	}
+	return wait
+}
+
+func overLimit(w Window) bool {
+	return w.Limit > 0 && w.Used > w.Limit
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"strconv"
+	"time"
+)
+
+// Increment adds one call to both windows and returns the new counts
+func (s *Storage) Increment(subject string, minute, day time.Time) (int64, int64, error) {
+	client := s.rdb.GetClient()
+	ctx := s.rdb.GetContext()
+
+	mKey := minuteKey(subject, minute)
+	dKey := dayKey(subject, day)
+
+	pipe := client.TxPipeline()
+	minuteCount := pipe.Incr(ctx, mKey)
+	pipe.Expire(ctx, mKey, 2*time.Minute)
+	dayCount := pipe.Incr(ctx, dKey)
+	pipe.Expire(ctx, dKey, 25*time.Hour)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, 0, err
+	}
+
+	return minuteCount.Val(), dayCount.Val(), nil
+}
+
+// Release takes back a call that was rejected, so only granted calls count
+func (s *Storage) Release(subject string, minute, day time.Time) error {
+	client := s.rdb.GetClient()
+	ctx := s.rdb.GetContext()
+
+	pipe := client.TxPipeline()
+	pipe.Decr(ctx, minuteKey(subject, minute))
+	pipe.Decr(ctx, dayKey(subject, day))
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Get returns the current counts without changing them
+func (s *Storage) Get(subject string, minute, day time.Time) (int64, int64, error) {
+	minuteCount, err := s.readCounter(minuteKey(subject, minute))
+	if err != nil {
+		return 0, 0, err
+	}
+	dayCount, err := s.readCounter(dayKey(subject, day))
+	if err != nil {
+		return 0, 0, err
+	}
+	return minuteCount, dayCount, nil
+}
+
+// Reset clears both windows for a subject
+func (s *Storage) Reset(subject string, minute, day time.Time) error {
+	return s.rdb.GetClient().Del(s.rdb.GetContext(), minuteKey(subject, minute), dayKey(subject, day)).Err()
+}
+
+func (s *Storage) readCounter(key string) (int64, error) {
+	raw, err := s.rdb.Get(key)
+	if err != nil || raw == "" {
+		return 0, err
+	}
+	return strconv.ParseInt(raw, 10, 64)
+}
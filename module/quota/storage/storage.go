@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"fmt"
+	"hub-service/infrastructure/database/redis"
+	"time"
+)
+
+const keyPrefix = "quota:"
+
+// Storage keeps fixed-window counters in Redis
+type Storage struct {
+	rdb *redis.RedisClient
+}
+
+func NewStorage(rdb *redis.RedisClient) *Storage {
+	return &Storage{rdb: rdb}
+}
+
+func minuteKey(subject string, window time.Time) string {
+	return fmt.Sprintf("%s%s:m:%s", keyPrefix, subject, window.Format("200601021504"))
+}
+
+func dayKey(subject string, window time.Time) string {
+	return fmt.Sprintf("%s%s:d:%s", keyPrefix, subject, window.Format("20060102"))
+}
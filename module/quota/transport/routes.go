@@ -0,0 +1,21 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
+	quotas := g.Group("/quotas")
+	quotas.Use(auth.AuthMiddleware(appCtx))
+	quotas.Use(auth.RequireRoles(common.RoleAdmin, common.RoleSuperAdmin))
+	{
+		quotas.GET("/users/:user_id", GetUserQuota(appCtx))
+		quotas.DELETE("/users/:user_id", ResetUserQuota(appCtx))
+		quotas.GET("/ips/:ip", GetIPQuota(appCtx))
+		quotas.DELETE("/ips/:ip", ResetIPQuota(appCtx))
+	}
+}
@@ -0,0 +1,149 @@
+package transport
+
+import (
+	"errors"
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/module/quota/biz"
+	"hub-service/module/quota/model"
+	"hub-service/module/quota/storage"
+	userstorage "hub-service/module/user/storage"
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetUserQuota godoc
+// @Summary Get a user's AI quota usage
+// @Description Current minute and daily AI usage of a user against the limits of their role. Admin only.
+// @Tags quotas
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path string true "User ID"
+// @Success 200 {object} common.Response{data=model.Usage} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Router /api/quotas/users/{user_id} [get]
+func GetUserQuota(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := primitive.ObjectIDFromHex(c.Param("user_id"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		user, err := userstorage.NewUserStorage(appCtx).GetByID(c.Request.Context(), userID)
+		if err != nil {
+			panic(common.ErrDB(err))
+		}
+		if user == nil {
+			panic(common.ErrEntityNotFound("User", common.RecordNotFound))
+		}
+
+		business := biz.NewQuotaBiz(quotaStorage(appCtx))
+		result, err := business.GetUsage(biz.Subject(model.SubjectUser, userID.Hex()), user.Role)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// ResetUserQuota godoc
+// @Summary Reset a user's AI quota usage
+// @Description Clears the current minute and daily windows of a user. Admin only.
+// @Tags quotas
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path string true "User ID"
+// @Success 200 {object} common.Response{data=bool} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Router /api/quotas/users/{user_id} [delete]
+func ResetUserQuota(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := primitive.ObjectIDFromHex(c.Param("user_id"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		business := biz.NewQuotaBiz(quotaStorage(appCtx))
+		if err := business.ResetUsage(biz.Subject(model.SubjectUser, userID.Hex())); err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(true))
+	}
+}
+
+// GetIPQuota godoc
+// @Summary Get an IP's anonymous AI quota usage
+// @Description Current minute and daily usage of anonymous AI calls from an IP address. Admin only.
+// @Tags quotas
+// @Produce json
+// @Security BearerAuth
+// @Param ip path string true "IP address"
+// @Success 200 {object} common.Response{data=model.Usage} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Router /api/quotas/ips/{ip} [get]
+func GetIPQuota(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ip := parseIP(c.Param("ip"))
+
+		business := biz.NewQuotaBiz(quotaStorage(appCtx))
+		result, err := business.GetUsage(biz.Subject(model.SubjectIP, ip), model.RoleAnonymous)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// ResetIPQuota godoc
+// @Summary Reset an IP's anonymous AI quota usage
+// @Description Clears the current minute and daily windows of an IP address. Admin only.
+// @Tags quotas
+// @Produce json
+// @Security BearerAuth
+// @Param ip path string true "IP address"
+// @Success 200 {object} common.Response{data=bool} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Router /api/quotas/ips/{ip} [delete]
+func ResetIPQuota(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ip := parseIP(c.Param("ip"))
+
+		business := biz.NewQuotaBiz(quotaStorage(appCtx))
+		if err := business.ResetUsage(biz.Subject(model.SubjectIP, ip)); err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(true))
+	}
+}
+
+func quotaStorage(appCtx appctx.AppContext) *storage.Storage {
+	rdb := appCtx.GetRedis()
+	if rdb == nil {
+		panic(common.ErrInvalidRequest(errors.New("redis is not configured")))
+	}
+	return storage.NewStorage(rdb)
+}
+
+// parseIP normalizes the address so it matches gin's ClientIP format
+func parseIP(raw string) string {
+	ip := net.ParseIP(raw)
+	if ip == nil {
+		panic(common.ErrInvalidRequest(errors.New("invalid ip address")))
+	}
+	return ip.String()
+}
@@ -0,0 +1,133 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"hub-service/common"
+	"hub-service/module/regrade/model"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrSelectionRequired guards against re-grading the whole history by accident
+var ErrSelectionRequired = common.NewFullErrorResponse(
+	http.StatusBadRequest,
+	errors.New("regrade selection required"),
+	"Choose a kind, challenge, section, passage, date range or prompt version, or set all",
+	"regrade selection required",
+	"ErrSelectionRequired",
+)
+
+// ErrNothingToRegrade means the selection matches no stored attempts
+var ErrNothingToRegrade = common.NewFullErrorResponse(
+	http.StatusUnprocessableEntity,
+	errors.New("no attempts match the selection"),
+	"No stored attempts match the selection",
+	"no attempts match the selection",
+	"ErrNothingToRegrade",
+)
+
+type CreateRunStore interface {
+	CountAttempts(ctx context.Context, selection *model.Selection) (int64, error)
+	CreateRun(ctx context.Context, run *model.RegradeRun) error
+}
+
+type createRunBiz struct {
+	store CreateRunStore
+}
+
+func NewCreateRunBiz(store CreateRunStore) *createRunBiz {
+	return &createRunBiz{store: store}
+}
+
+// CreateRun queues a re-grade of the selected attempts for the runner
+func (biz *createRunBiz) CreateRun(ctx context.Context, req *model.CreateRunRequest, adminID primitive.ObjectID) (*model.RegradeRun, error) {
+	selection, err := parseSelection(req)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := biz.store.CountAttempts(ctx, selection)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("Attempt", err)
+	}
+	if total == 0 {
+		return nil, ErrNothingToRegrade
+	}
+	if req.Limit > 0 && int64(req.Limit) < total {
+		total = int64(req.Limit)
+	}
+
+	now := time.Now()
+	run := &model.RegradeRun{
+		Status:    model.StatusQueued,
+		Selection: *selection,
+		Apply:     req.Apply,
+		Limit:     req.Limit,
+		Total:     total,
+		CreatedBy: adminID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := biz.store.CreateRun(ctx, run); err != nil {
+		return nil, common.ErrCannotCreateEntity("RegradeRun", err)
+	}
+	return run, nil
+}
+
+// parseSelection validates the request and infers the kind from the content it names
+func parseSelection(req *model.CreateRunRequest) (*model.Selection, error) {
+	selection := &model.Selection{
+		Kind:          req.Kind,
+		From:          req.From,
+		To:            req.To,
+		PromptVersion: req.PromptVersion,
+	}
+
+	var err error
+	if selection.ChallengeID, err = optionalID(req.ChallengeID); err != nil {
+		return nil, err
+	}
+	if selection.SectionID, err = optionalID(req.SectionID); err != nil {
+		return nil, err
+	}
+	if selection.TranslationID, err = optionalID(req.TranslationID); err != nil {
+		return nil, err
+	}
+
+	challengeOnly := !selection.ChallengeID.IsZero() || !selection.SectionID.IsZero()
+	sentenceOnly := !selection.TranslationID.IsZero()
+	switch {
+	case challengeOnly && sentenceOnly,
+		challengeOnly && selection.Kind == model.KindSentence,
+		sentenceOnly && selection.Kind == model.KindChallenge:
+		return nil, common.ErrInvalidRequest(errors.New("challenge and section select challenge attempts, translation selects sentence attempts"))
+	case challengeOnly:
+		selection.Kind = model.KindChallenge
+	case sentenceOnly:
+		selection.Kind = model.KindSentence
+	}
+
+	if selection.From != nil && selection.To != nil && !selection.From.Before(*selection.To) {
+		return nil, common.ErrInvalidRequest(errors.New("from must be before to"))
+	}
+
+	narrowed := selection.Kind != "" || selection.From != nil || selection.To != nil || selection.PromptVersion != ""
+	if !narrowed && !req.All {
+		return nil, ErrSelectionRequired
+	}
+	return selection, nil
+}
+
+func optionalID(value string) (primitive.ObjectID, error) {
+	if value == "" {
+		return primitive.NilObjectID, nil
+	}
+	id, err := primitive.ObjectIDFromHex(value)
+	if err != nil {
+		return primitive.NilObjectID, common.ErrInvalidRequest(err)
+	}
+	return id, nil
+}
@@ -0,0 +1,508 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	attemptmodel "hub-service/module/attempt/model"
+	challengemodel "hub-service/module/challenge/model"
+	"hub-service/module/regrade/model"
+	scorebiz "hub-service/module/score/biz"
+	translationmodel "hub-service/module/translation/model"
+	usagebiz "hub-service/module/usage/biz"
+	usagemodel "hub-service/module/usage/model"
+	usermodel "hub-service/module/user/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// Lease is how long a claimed run stays with its runner between checkpoints
+	Lease = 5 * time.Minute
+
+	batchSize = 50
+	// A run stops after this many grading failures in a row, e.g. when the provider is down
+	maxConsecutiveFailures = 5
+)
+
+// errContentGone marks attempts whose challenge, passage or sentence was deleted since they were graded
+var errContentGone = errors.New("no longer exists")
+
+type ProcessRunStore interface {
+	NextAttempts(ctx context.Context, selection *model.Selection, after primitive.ObjectID, limit int) ([]attemptmodel.Attempt, error)
+	ScoreFor(ctx context.Context, attempt *attemptmodel.Attempt) (*model.ScoreState, error)
+	ApplyGrade(ctx context.Context, kind string, scoreID primitive.ObjectID, attemptNumber int, grade *model.AppliedGrade) (bool, error)
+	SaveResults(ctx context.Context, results []model.RegradeResult) error
+	SaveCheckpoint(ctx context.Context, id primitive.ObjectID, checkpoint *model.Checkpoint) (bool, error)
+	RenewLease(ctx context.Context, id primitive.ObjectID, leaseUntil time.Time) (bool, error)
+	FinishRun(ctx context.Context, id primitive.ObjectID, status string, runErr string) error
+}
+
+type AttemptHistoryStore interface {
+	BestScoreBefore(ctx context.Context, filter *attemptmodel.AttemptFilter, attemptNumber int) (*float64, error)
+	CorrectScore(ctx context.Context, filter *attemptmodel.AttemptFilter, attemptNumber int, score float64, correctedAt time.Time) error
+}
+
+type ChallengeStore interface {
+	Get(ctx context.Context, id primitive.ObjectID) (*challengemodel.Challenge, error)
+}
+
+type PassageStore interface {
+	GetTranslation(ctx context.Context, id primitive.ObjectID) (*translationmodel.Translation, error)
+	GetSentencesByTranslationID(ctx context.Context, translationID primitive.ObjectID) ([]translationmodel.TranslationSentence, error)
+}
+
+type UserStore interface {
+	GetByID(ctx context.Context, id primitive.ObjectID) (*usermodel.User, error)
+}
+
+type processRunBiz struct {
+	store      ProcessRunStore
+	attempts   AttemptHistoryStore
+	challenges ChallengeStore
+	passages   PassageStore
+	users      UserStore
+	grader     scorebiz.GradingProvider
+	pairs      scorebiz.LanguagePairs
+	interval   time.Duration
+	listeners  []scorebiz.ScoreChangeListener
+}
+
+func NewProcessRunBiz(
+	store ProcessRunStore,
+	attempts AttemptHistoryStore,
+	challenges ChallengeStore,
+	passages PassageStore,
+	users UserStore,
+	grader scorebiz.GradingProvider,
+	pairs scorebiz.LanguagePairs,
+	interval time.Duration,
+	listeners ...scorebiz.ScoreChangeListener,
+) *processRunBiz {
+	return &processRunBiz{
+		store:      store,
+		attempts:   attempts,
+		challenges: challenges,
+		passages:   passages,
+		users:      users,
+		grader:     grader,
+		pairs:      pairs,
+		interval:   interval,
+		listeners:  listeners,
+	}
+}
+
+// Process grades the run's remaining attempts one at a time, at most one per interval, and
+// checkpoints after each success. Attempts whose content is gone are skipped and checkpointed like
+// successes. Other failed attempts are only checkpointed behind a later success, so when a run fails
+// on a streak of failures, resuming it retries them; they still renew the lease.
+// It returns when the run completes, fails or is cancelled. When ctx ends first the run is left
+// running and another runner resumes it once the lease expires.
+func (biz *processRunBiz) Process(ctx context.Context, run *model.RegradeRun) error {
+	checkpoint := &model.Checkpoint{
+		Cursor:        run.Cursor,
+		Processed:     run.Processed,
+		Failed:        run.Failed,
+		Applied:       run.Applied,
+		Skipped:       run.Skipped,
+		Before:        run.Before,
+		After:         run.After,
+		Provider:      run.Provider,
+		PromptVersion: run.PromptVersion,
+	}
+	content := newContentCache(biz.challenges, biz.passages, biz.users, biz.pairs)
+
+	var pending []model.RegradeResult
+	failures := 0
+	cursor := run.Cursor
+	next := time.Now()
+
+	for {
+		limit := batchSize
+		if run.Limit > 0 {
+			limit = min(limit, run.Limit-checkpoint.Processed)
+			if limit <= 0 {
+				break
+			}
+		}
+
+		attempts, err := biz.store.NextAttempts(ctx, &run.Selection, cursor, limit)
+		if err != nil {
+			return biz.fail(ctx, run.ID, err)
+		}
+		if len(attempts) == 0 {
+			break
+		}
+
+		for i := range attempts {
+			attempt := &attempts[i]
+			if err := waitUntil(ctx, next); err != nil {
+				return err
+			}
+			next = time.Now().Add(biz.interval)
+
+			result, err := biz.regrade(ctx, run, attempt, content)
+			cursor = attempt.ID
+			checkpoint.Processed++
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				// Content that can no longer be graded is skipped for good, without a before and after
+				result.SkipReason = skipReason(err)
+				if result.SkipReason == "" {
+					result.Error = err.Error()
+					pending = append(pending, *result)
+					checkpoint.Failed++
+					failures++
+					if failures >= maxConsecutiveFailures {
+						return biz.fail(ctx, run.ID, err)
+					}
+
+					running, err := biz.store.RenewLease(ctx, run.ID, time.Now().Add(Lease))
+					if err != nil {
+						return biz.fail(ctx, run.ID, err)
+					}
+					if !running {
+						return nil
+					}
+					continue
+				}
+			}
+			failures = 0
+
+			pending = append(pending, *result)
+			if err == nil {
+				checkpoint.Before.Add(result.BeforeScore)
+				checkpoint.After.Add(result.AfterScore)
+				checkpoint.PromptVersion = result.AfterPromptVersion
+				checkpoint.Provider = biz.grader.Name()
+			}
+			switch {
+			case result.Applied:
+				checkpoint.Applied++
+			case result.SkipReason != "":
+				checkpoint.Skipped++
+			}
+
+			checkpoint.Cursor = cursor
+			running, err := biz.save(ctx, run.ID, checkpoint, pending)
+			if err != nil {
+				return biz.fail(ctx, run.ID, err)
+			}
+			if !running {
+				return nil
+			}
+			pending = nil
+		}
+	}
+
+	// Trailing failures are recorded as they are: there is no later success to retry them behind
+	if len(pending) > 0 {
+		checkpoint.Cursor = cursor
+		running, err := biz.save(ctx, run.ID, checkpoint, pending)
+		if err != nil {
+			return biz.fail(ctx, run.ID, err)
+		}
+		if !running {
+			return nil
+		}
+	}
+
+	return biz.store.FinishRun(ctx, run.ID, model.StatusCompleted, "")
+}
+
+// regrade grades one attempt again and, for runs that apply, writes the grade to the learner's score.
+// The attempt's hint penalty is deducted again so before and after compare like for like.
+func (biz *processRunBiz) regrade(ctx context.Context, run *model.RegradeRun, attempt *attemptmodel.Attempt, content *contentCache) (*model.RegradeResult, error) {
+	result := &model.RegradeResult{
+		RunID:               run.ID,
+		AttemptID:           attempt.ID,
+		Kind:                attempt.Kind,
+		UserID:              attempt.UserID,
+		ChallengeID:         attempt.ChallengeID,
+		TranslationID:       attempt.TranslationID,
+		SentenceIndex:       attempt.SentenceIndex,
+		AttemptNumber:       attempt.AttemptNumber,
+		UserTranslation:     attempt.UserTranslation,
+		BeforeScore:         attempt.Score,
+		BeforePromptVersion: attempt.PromptVersion,
+		CreatedAt:           time.Now(),
+	}
+
+	gradeReq, err := content.gradeRequest(ctx, attempt)
+	if err != nil {
+		return result, err
+	}
+
+	gradeCtx := usagebiz.WithCaller(scorebiz.WithCacheBypass(ctx), usagemodel.FeatureRegrade, attempt.UserID)
+	analysis, err := biz.grader.AnalyzeGrammar(gradeCtx, gradeReq)
+	if err != nil {
+		return result, err
+	}
+	analysis.Score = math.Max(0, analysis.Score-attempt.HintPenalty)
+
+	result.AfterScore = analysis.Score
+	result.AfterPromptVersion = analysis.PromptVersion
+	result.Delta = result.AfterScore - result.BeforeScore
+	result.AbsDelta = math.Abs(result.Delta)
+
+	if run.Apply {
+		result.Applied, result.SkipReason, err = biz.apply(ctx, attempt, analysis)
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// apply writes the new grade to the score record when the attempt is still its latest one and
+// no reviewer has graded it by hand, and corrects the attempt's score so later bests see it.
+// Earlier attempts are only reported: the score record holds the latest grade.
+func (biz *processRunBiz) apply(ctx context.Context, attempt *attemptmodel.Attempt, analysis *scorebiz.GrammarAnalysis) (bool, string, error) {
+	state, err := biz.store.ScoreFor(ctx, attempt)
+	if err != nil {
+		return false, "", err
+	}
+	switch {
+	case state == nil:
+		return false, model.SkipNoScore, nil
+	case state.AttemptCount != attempt.AttemptNumber:
+		return false, model.SkipNotLatest, nil
+	case state.HumanReviewed:
+		return false, model.SkipHumanReviewed, nil
+	}
+
+	filter := &attemptmodel.AttemptFilter{
+		UserID:        attempt.UserID,
+		Kind:          attempt.Kind,
+		ChallengeID:   attempt.ChallengeID,
+		TranslationID: attempt.TranslationID,
+		SentenceIndex: attempt.SentenceIndex,
+	}
+	earlierBest, err := biz.attempts.BestScoreBefore(ctx, filter, attempt.AttemptNumber)
+	if err != nil {
+		return false, "", err
+	}
+
+	suggestions := ""
+	if len(analysis.Suggestions) > 0 {
+		b, _ := json.Marshal(analysis.Suggestions)
+		suggestions = string(b)
+	}
+
+	bestScore := bestAfter(earlierBest, state, analysis.Score)
+	applied, err := biz.store.ApplyGrade(ctx, attempt.Kind, state.ID, attempt.AttemptNumber, &model.AppliedGrade{
+		Score:         analysis.Score,
+		BestScore:     bestScore,
+		Feedback:      analysis.Feedback,
+		Errors:        scorebiz.StoredErrors(analysis.Errors),
+		Suggestions:   suggestions,
+		PromptVersion: analysis.PromptVersion,
+	})
+	if err != nil {
+		return false, "", err
+	}
+	if !applied {
+		// The learner submitted again or a reviewer stepped in while this attempt was graded
+		return false, model.SkipNotLatest, nil
+	}
+
+	now := time.Now()
+	if err := biz.attempts.CorrectScore(ctx, filter, attempt.AttemptNumber, analysis.Score, now); err != nil {
+		log.Printf("Correcting attempt %s after re-grade failed: %v", attempt.ID.Hex(), err)
+	}
+
+	scorebiz.NotifyScoreChange(ctx, biz.listeners, &scorebiz.ScoreChange{
+		Kind:          attempt.Kind,
+		UserID:        attempt.UserID,
+		ChallengeID:   attempt.ChallengeID,
+		TranslationID: attempt.TranslationID,
+		SentenceIndex: attempt.SentenceIndex,
+		PreviousBest:  state.BestScore,
+		BestScore:     bestScore,
+		PasteFlag:     state.PasteFlag,
+		ChangedAt:     now,
+	})
+	return true, "", nil
+}
+
+// skipReason tells attempts that can never be graded again apart from provider and storage failures,
+// which are worth retrying
+func skipReason(err error) string {
+	switch {
+	case errors.Is(err, errContentGone):
+		return model.SkipContentGone
+	case errors.Is(err, scorebiz.ErrUnsupportedLanguagePair):
+		return model.SkipUnsupportedPair
+	}
+	return ""
+}
+
+// bestAfter works out the best score once the latest attempt is regraded, as score overrides do.
+// Records older than the attempt history keep a stored best that did not come from the latest attempt.
+func bestAfter(earlierBest *float64, state *model.ScoreState, newScore float64) float64 {
+	best := newScore
+	switch {
+	case earlierBest != nil:
+		best = math.Max(best, *earlierBest)
+	case state.AttemptCount > 1 && state.BestScore > state.Score:
+		best = math.Max(best, state.BestScore)
+	}
+	return best
+}
+
+func (biz *processRunBiz) save(ctx context.Context, runID primitive.ObjectID, checkpoint *model.Checkpoint, results []model.RegradeResult) (bool, error) {
+	if err := biz.store.SaveResults(ctx, results); err != nil {
+		return false, err
+	}
+	checkpoint.LeaseUntil = time.Now().Add(Lease)
+	return biz.store.SaveCheckpoint(ctx, runID, checkpoint)
+}
+
+// fail ends the run with err, unless the process is shutting down: then the lease runs out and
+// the run is picked up again
+func (biz *processRunBiz) fail(ctx context.Context, runID primitive.ObjectID, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if finishErr := biz.store.FinishRun(ctx, runID, model.StatusFailed, err.Error()); finishErr != nil {
+		return finishErr
+	}
+	return err
+}
+
+func waitUntil(ctx context.Context, at time.Time) error {
+	wait := time.Until(at)
+	if wait <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// contentCache rebuilds grading requests, loading each challenge, passage and learner once per run
+type contentCache struct {
+	challenges     ChallengeStore
+	passages       PassageStore
+	users          UserStore
+	pairs          scorebiz.LanguagePairs
+	challengeByID  map[primitive.ObjectID]*challengemodel.Challenge
+	passageByID    map[primitive.ObjectID]*passage
+	languageByUser map[primitive.ObjectID]string
+}
+
+type passage struct {
+	translation *translationmodel.Translation
+	sentences   []translationmodel.TranslationSentence
+}
+
+func newContentCache(challenges ChallengeStore, passages PassageStore, users UserStore, pairs scorebiz.LanguagePairs) *contentCache {
+	return &contentCache{
+		challenges:     challenges,
+		passages:       passages,
+		users:          users,
+		pairs:          pairs,
+		challengeByID:  map[primitive.ObjectID]*challengemodel.Challenge{},
+		passageByID:    map[primitive.ObjectID]*passage{},
+		languageByUser: map[primitive.ObjectID]string{},
+	}
+}
+
+// gradeRequest grades the attempt against the content's current references, with feedback in the
+// learner's current language
+func (c *contentCache) gradeRequest(ctx context.Context, attempt *attemptmodel.Attempt) (*scorebiz.GradeRequest, error) {
+	language := c.feedbackLanguage(ctx, attempt.UserID)
+
+	if attempt.Kind == attemptmodel.KindSentence {
+		p, err := c.passage(ctx, attempt.TranslationID)
+		if err != nil {
+			return nil, err
+		}
+		if attempt.SentenceIndex >= len(p.sentences) {
+			return nil, fmt.Errorf("sentence %d of translation %s: %w", attempt.SentenceIndex, attempt.TranslationID.Hex(), errContentGone)
+		}
+		sentence := p.sentences[attempt.SentenceIndex]
+
+		gradeReq, err := c.pairs.NewGradeRequest(sentence.Content, attempt.UserTranslation, p.translation.SourceLang, p.translation.TargetLang, language)
+		if err != nil {
+			return nil, err
+		}
+		gradeReq.References = sentence.GradingReferences()
+		gradeReq.MachineTranslation = sentence.MachineTranslation
+		return gradeReq, nil
+	}
+
+	challenge, err := c.challenge(ctx, attempt.ChallengeID)
+	if err != nil {
+		return nil, err
+	}
+	gradeReq, err := c.pairs.NewGradeRequest(challenge.Content, attempt.UserTranslation, challenge.SourceLang, challenge.TargetLang, language)
+	if err != nil {
+		return nil, err
+	}
+	gradeReq.References = challenge.GradingReferences()
+	gradeReq.MachineTranslation = challenge.MachineTranslation
+	return gradeReq, nil
+}
+
+func (c *contentCache) challenge(ctx context.Context, id primitive.ObjectID) (*challengemodel.Challenge, error) {
+	if challenge, ok := c.challengeByID[id]; ok {
+		return challenge, nil
+	}
+	challenge, err := c.challenges.Get(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("challenge %s: %w", id.Hex(), errContentGone)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading challenge %s: %w", id.Hex(), err)
+	}
+	c.challengeByID[id] = challenge
+	return challenge, nil
+}
+
+func (c *contentCache) passage(ctx context.Context, id primitive.ObjectID) (*passage, error) {
+	if p, ok := c.passageByID[id]; ok {
+		return p, nil
+	}
+	translation, err := c.passages.GetTranslation(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("translation %s: %w", id.Hex(), errContentGone)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading translation %s: %w", id.Hex(), err)
+	}
+	sentences, err := c.passages.GetSentencesByTranslationID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("loading sentences of translation %s: %w", id.Hex(), err)
+	}
+	p := &passage{translation: translation, sentences: sentences}
+	c.passageByID[id] = p
+	return p, nil
+}
+
+// feedbackLanguage falls back to "", the content's source language, when the learner is gone
+func (c *contentCache) feedbackLanguage(ctx context.Context, userID primitive.ObjectID) string {
+	if language, ok := c.languageByUser[userID]; ok {
+		return language
+	}
+	language := ""
+	if user, err := c.users.GetByID(ctx, userID); err == nil && user != nil {
+		language = user.FeedbackLanguage
+	}
+	c.languageByUser[userID] = language
+	return language
+}
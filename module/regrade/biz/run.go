@@ -0,0 +1,101 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"hub-service/common"
+	"hub-service/module/regrade/model"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrRunNotCancellable means the run already finished
+var ErrRunNotCancellable = common.NewFullErrorResponse(
+	http.StatusConflict,
+	errors.New("regrade run not queued or running"),
+	"Only queued or running re-grades can be cancelled",
+	"regrade run not queued or running",
+	"ErrRunNotCancellable",
+)
+
+// ErrRunNotResumable means the run is not stopped
+var ErrRunNotResumable = common.NewFullErrorResponse(
+	http.StatusConflict,
+	errors.New("regrade run not failed or cancelled"),
+	"Only failed or cancelled re-grades can be resumed",
+	"regrade run not failed or cancelled",
+	"ErrRunNotResumable",
+)
+
+type RunStore interface {
+	GetRun(ctx context.Context, id primitive.ObjectID) (*model.RegradeRun, error)
+	ListRuns(ctx context.Context, paging *common.Paging) ([]model.RegradeRun, error)
+	ListResults(ctx context.Context, runID primitive.ObjectID, paging *common.Paging) ([]model.RegradeResult, error)
+	SetRunStatus(ctx context.Context, id primitive.ObjectID, from []string, to string) (bool, error)
+}
+
+type runBiz struct {
+	store RunStore
+}
+
+func NewRunBiz(store RunStore) *runBiz {
+	return &runBiz{store: store}
+}
+
+func (biz *runBiz) GetRun(ctx context.Context, id primitive.ObjectID) (*model.RegradeRun, error) {
+	run, err := biz.store.GetRun(ctx, id)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("RegradeRun", err)
+	}
+	if run == nil {
+		return nil, common.ErrEntityNotFound("RegradeRun", common.RecordNotFound)
+	}
+	return run, nil
+}
+
+func (biz *runBiz) ListRuns(ctx context.Context, paging *common.Paging) ([]model.RegradeRun, error) {
+	runs, err := biz.store.ListRuns(ctx, paging)
+	if err != nil {
+		return nil, common.ErrCannotListEntity("RegradeRun", err)
+	}
+	return runs, nil
+}
+
+// ListResults returns the per-attempt outcomes of a run, largest score changes first
+func (biz *runBiz) ListResults(ctx context.Context, runID primitive.ObjectID, paging *common.Paging) ([]model.RegradeResult, error) {
+	if _, err := biz.GetRun(ctx, runID); err != nil {
+		return nil, err
+	}
+
+	results, err := biz.store.ListResults(ctx, runID, paging)
+	if err != nil {
+		return nil, common.ErrCannotListEntity("RegradeResult", err)
+	}
+	return results, nil
+}
+
+// Cancel stops a run after the attempt it is grading; grades already applied stay applied
+func (biz *runBiz) Cancel(ctx context.Context, id primitive.ObjectID) (*model.RegradeRun, error) {
+	return biz.transition(ctx, id, []string{model.StatusQueued, model.StatusRunning}, model.StatusCancelled, ErrRunNotCancellable)
+}
+
+// Resume queues a failed or cancelled run again; it continues after its last checkpoint
+func (biz *runBiz) Resume(ctx context.Context, id primitive.ObjectID) (*model.RegradeRun, error) {
+	return biz.transition(ctx, id, []string{model.StatusFailed, model.StatusCancelled}, model.StatusQueued, ErrRunNotResumable)
+}
+
+func (biz *runBiz) transition(ctx context.Context, id primitive.ObjectID, from []string, to string, conflict error) (*model.RegradeRun, error) {
+	if _, err := biz.GetRun(ctx, id); err != nil {
+		return nil, err
+	}
+
+	ok, err := biz.store.SetRunStatus(ctx, id, from, to)
+	if err != nil {
+		return nil, common.ErrCannotUpdateEntity("RegradeRun", err)
+	}
+	if !ok {
+		return nil, conflict
+	}
+	return biz.GetRun(ctx, id)
+}
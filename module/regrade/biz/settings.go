@@ -0,0 +1,25 @@
+package biz
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+const defaultRatePerMinute = 30
+
+// Interval reads REGRADE_RATE_PER_MINUTE, how many attempts a run grades per minute, and returns
+// the pause between two of them. It keeps bulk runs from starving learners of provider quota.
+func Interval() time.Duration {
+	rate := defaultRatePerMinute
+	if raw := os.Getenv("REGRADE_RATE_PER_MINUTE"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			log.Printf("Invalid REGRADE_RATE_PER_MINUTE %q, using %d", raw, defaultRatePerMinute)
+		} else {
+			rate = n
+		}
+	}
+	return time.Minute / time.Duration(rate)
+}
@@ -0,0 +1,163 @@
+// Package job runs queued re-grades in the background, one at a time
+package job
+
+import (
+	"context"
+	"hub-service/core/appctx"
+	attemptstorage "hub-service/module/attempt/storage"
+	challengestorage "hub-service/module/challenge/storage"
+	"hub-service/module/grading"
+	leaderboardbiz "hub-service/module/leaderboard/biz"
+	leaderboardstorage "hub-service/module/leaderboard/storage"
+	"hub-service/module/regrade/biz"
+	"hub-service/module/regrade/model"
+	"hub-service/module/regrade/storage"
+	scorebiz "hub-service/module/score/biz"
+	translationstorage "hub-service/module/translation/storage"
+	userstorage "hub-service/module/user/storage"
+	"log"
+	"sync"
+	"time"
+)
+
+const pollInterval = 30 * time.Second
+
+type Runner struct {
+	appCtx   appctx.AppContext
+	interval time.Duration
+	pairs    scorebiz.LanguagePairs
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	running  bool
+	mu       sync.Mutex
+}
+
+func NewRunner(appCtx appctx.AppContext) *Runner {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Runner{
+		appCtx:   appCtx,
+		interval: biz.Interval(),
+		pairs:    scorebiz.SupportedLanguagePairs(),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Start starts polling for queued re-grades
+func (r *Runner) Start() {
+	r.mu.Lock()
+	if r.running {
+		r.mu.Unlock()
+		return
+	}
+	r.running = true
+	r.mu.Unlock()
+
+	log.Println("Starting regrade runner...")
+
+	r.wg.Add(1)
+	go r.run()
+}
+
+// Stop stops the runner. A run in progress keeps its checkpoint and is picked up again once
+// its lease expires.
+func (r *Runner) Stop() {
+	r.mu.Lock()
+	if !r.running {
+		r.mu.Unlock()
+		return
+	}
+	r.running = false
+	r.mu.Unlock()
+
+	r.cancel()
+	r.wg.Wait()
+	log.Println("Regrade runner stopped")
+}
+
+func (r *Runner) run() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain the queue before waiting for the next tick
+		for r.ctx.Err() == nil && r.processNext() {
+		}
+
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// processNext claims and processes one run, and reports whether there was one
+func (r *Runner) processNext() bool {
+	db := r.appCtx.GetDatabase()
+	store := storage.NewStorage(db)
+
+	run, err := store.ClaimRun(r.ctx, biz.Lease)
+	if err != nil {
+		log.Printf("Error claiming regrade run: %v", err)
+		return false
+	}
+	if run == nil {
+		return false
+	}
+
+	provider, err := grading.NewProvider(r.appCtx)
+	if err != nil {
+		log.Printf("Regrade run %s failed: %v", run.ID.Hex(), err)
+		if err := store.FinishRun(r.ctx, run.ID, model.StatusFailed, err.Error()); err != nil {
+			log.Printf("Error failing regrade run %s: %v", run.ID.Hex(), err)
+		}
+		return true
+	}
+
+	log.Printf("Regrading run %s (%d of %d attempts done)", run.ID.Hex(), run.Processed, run.Total)
+
+	business := biz.NewProcessRunBiz(
+		store,
+		attemptstorage.NewStorage(db),
+		challengestorage.NewStorage(db),
+		translationstorage.NewStorage(db),
+		userstorage.NewUserStorage(r.appCtx),
+		provider,
+		r.pairs,
+		r.interval,
+		grading.ScoreChangeListeners(r.appCtx)...,
+	)
+	if err := business.Process(r.ctx, run); err != nil {
+		if r.ctx.Err() != nil {
+			return true
+		}
+		log.Printf("Regrade run %s failed: %v", run.ID.Hex(), err)
+	}
+
+	r.afterRun(store, run)
+	return true
+}
+
+// afterRun rebuilds the leaderboards once a run has changed learners' scores, whether it
+// completed, failed or was cancelled after applying some grades
+func (r *Runner) afterRun(store *storage.Storage, run *model.RegradeRun) {
+	finished, err := store.GetRun(r.ctx, run.ID)
+	if err != nil || finished == nil {
+		return
+	}
+	log.Printf("Regrade run %s %s: %d processed, %d applied, %d failed", finished.ID.Hex(), finished.Status, finished.Processed, finished.Applied, finished.Failed)
+
+	rdb := r.appCtx.GetRedis()
+	if finished.Applied == 0 || rdb == nil {
+		return
+	}
+	business := leaderboardbiz.NewRebuildLeaderboardBiz(leaderboardstorage.NewStorage(r.appCtx.GetDatabase(), rdb))
+	if _, err := business.Rebuild(r.ctx); err != nil {
+		log.Printf("Leaderboard rebuild after regrade run %s failed: %v", finished.ID.Hex(), err)
+	}
+}
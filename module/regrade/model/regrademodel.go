@@ -0,0 +1,196 @@
+package model
+
+import (
+	scoremodel "hub-service/module/score/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionName       = "regrade_runs"
+	ResultCollectionName = "regrade_results"
+)
+
+// Run statuses. Queued and running runs are picked up by the runner; failed and cancelled ones
+// can be resumed from their checkpoint.
+const (
+	StatusQueued    = "queued"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+	StatusCancelled = "cancelled"
+)
+
+// Attempt kinds, matching the attempt history
+const (
+	KindChallenge = "challenge"
+	KindSentence  = "sentence"
+)
+
+// Reasons a new grade was not applied to the learner's score
+const (
+	SkipNotLatest     = "not_latest"
+	SkipHumanReviewed = "human_reviewed"
+	SkipNoScore       = "no_score"
+	// The attempt could not be graded again: its challenge or passage sentence is gone,
+	// or its language pair is no longer supported
+	SkipContentGone     = "content_gone"
+	SkipUnsupportedPair = "unsupported_language_pair"
+)
+
+// Buckets is the number of 10-point buckets in a score distribution; 100 falls in the last one
+const Buckets = 10
+
+// Selection picks the stored attempts to re-grade; zero values match everything
+type Selection struct {
+	Kind          string             `json:"kind,omitempty" bson:"kind,omitempty"`
+	ChallengeID   primitive.ObjectID `json:"challenge_id,omitempty" bson:"challenge_id,omitempty"`
+	SectionID     primitive.ObjectID `json:"section_id,omitempty" bson:"section_id,omitempty"`
+	TranslationID primitive.ObjectID `json:"translation_id,omitempty" bson:"translation_id,omitempty"`
+	From          *time.Time         `json:"from,omitempty" bson:"from,omitempty"`
+	To            *time.Time         `json:"to,omitempty" bson:"to,omitempty"`
+	PromptVersion string             `json:"prompt_version,omitempty" bson:"prompt_version,omitempty"`
+}
+
+// Distribution summarises a set of scores in 10-point buckets
+type Distribution struct {
+	Count   int     `json:"count" bson:"count"`
+	Sum     float64 `json:"-" bson:"sum"`
+	Mean    float64 `json:"mean" bson:"mean"`
+	Buckets []int   `json:"buckets" bson:"buckets"`
+}
+
+// Add counts one score
+func (d *Distribution) Add(score float64) {
+	if len(d.Buckets) != Buckets {
+		d.Buckets = make([]int, Buckets)
+	}
+
+	bucket := int(score) / (100 / Buckets)
+	if bucket >= Buckets {
+		bucket = Buckets - 1
+	}
+	if bucket < 0 {
+		bucket = 0
+	}
+
+	d.Buckets[bucket]++
+	d.Count++
+	d.Sum += score
+	d.Mean = d.Sum / float64(d.Count)
+}
+
+// RegradeRun is one bulk re-grade of stored attempts with the current grading provider.
+// Attempts are processed in _id order and Cursor is the last one checkpointed, so a run that
+// stops, fails or is cancelled resumes where it left off.
+type RegradeRun struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Status    string             `json:"status" bson:"status"`
+	Selection Selection          `json:"selection" bson:"selection"`
+	// Apply writes the new grades to the learners' scores; otherwise the run only reports
+	Apply bool  `json:"apply" bson:"apply"`
+	Limit int   `json:"limit,omitempty" bson:"limit,omitempty"`
+	Total int64 `json:"total" bson:"total"`
+
+	Processed int                `json:"processed" bson:"processed"`
+	Failed    int                `json:"failed" bson:"failed"`
+	Applied   int                `json:"applied" bson:"applied"`
+	Skipped   int                `json:"skipped" bson:"skipped"`
+	Cursor    primitive.ObjectID `json:"cursor,omitempty" bson:"cursor,omitempty"`
+
+	// Before and After cover the attempts graded successfully, so their means compare like for like
+	Before        Distribution `json:"before" bson:"before"`
+	After         Distribution `json:"after" bson:"after"`
+	Provider      string       `json:"provider,omitempty" bson:"provider,omitempty"`
+	PromptVersion string       `json:"prompt_version,omitempty" bson:"prompt_version,omitempty"`
+	Error         string       `json:"error,omitempty" bson:"error,omitempty"`
+
+	LeaseUntil  *time.Time         `json:"-" bson:"lease_until,omitempty"`
+	CreatedBy   primitive.ObjectID `json:"created_by" bson:"created_by"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	StartedAt   *time.Time         `json:"started_at,omitempty" bson:"started_at,omitempty"`
+	UpdatedAt   time.Time          `json:"updated_at" bson:"updated_at"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+}
+
+func (RegradeRun) TableName() string {
+	return CollectionName
+}
+
+// RegradeResult is the outcome for one attempt of a run
+type RegradeResult struct {
+	ID                  primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	RunID               primitive.ObjectID `json:"run_id" bson:"run_id"`
+	AttemptID           primitive.ObjectID `json:"attempt_id" bson:"attempt_id"`
+	Kind                string             `json:"kind" bson:"kind"`
+	UserID              primitive.ObjectID `json:"user_id" bson:"user_id"`
+	ChallengeID         primitive.ObjectID `json:"challenge_id,omitempty" bson:"challenge_id,omitempty"`
+	TranslationID       primitive.ObjectID `json:"translation_id,omitempty" bson:"translation_id,omitempty"`
+	SentenceIndex       int                `json:"sentence_index" bson:"sentence_index"`
+	AttemptNumber       int                `json:"attempt_number" bson:"attempt_number"`
+	UserTranslation     string             `json:"user_translation" bson:"user_translation"`
+	BeforeScore         float64            `json:"before_score" bson:"before_score"`
+	AfterScore          float64            `json:"after_score" bson:"after_score"`
+	Delta               float64            `json:"delta" bson:"delta"`
+	AbsDelta            float64            `json:"-" bson:"abs_delta"`
+	BeforePromptVersion string             `json:"before_prompt_version" bson:"before_prompt_version"`
+	AfterPromptVersion  string             `json:"after_prompt_version" bson:"after_prompt_version"`
+	Applied             bool               `json:"applied" bson:"applied"`
+	SkipReason          string             `json:"skip_reason,omitempty" bson:"skip_reason,omitempty"`
+	Error               string             `json:"error,omitempty" bson:"error,omitempty"`
+	CreatedAt           time.Time          `json:"created_at" bson:"created_at"`
+}
+
+func (RegradeResult) TableName() string {
+	return ResultCollectionName
+}
+
+// CreateRunRequest starts a run. At least one of the selection fields narrows it unless all is set,
+// so a stray request cannot re-grade the whole history.
+type CreateRunRequest struct {
+	Kind          string     `json:"kind" binding:"omitempty,oneof=challenge sentence" example:"challenge"`
+	ChallengeID   string     `json:"challenge_id"`
+	SectionID     string     `json:"section_id"`
+	TranslationID string     `json:"translation_id"`
+	From          *time.Time `json:"from" example:"2026-01-01T00:00:00Z"`
+	To            *time.Time `json:"to"`
+	PromptVersion string     `json:"prompt_version" example:"v3"`
+	All           bool       `json:"all"`
+	Apply         bool       `json:"apply"`
+	Limit         int        `json:"limit" binding:"min=0" example:"500"`
+}
+
+// Checkpoint is a run's progress after its latest graded attempt
+type Checkpoint struct {
+	Cursor        primitive.ObjectID
+	Processed     int
+	Failed        int
+	Applied       int
+	Skipped       int
+	Before        Distribution
+	After         Distribution
+	Provider      string
+	PromptVersion string
+	LeaseUntil    time.Time
+}
+
+// ScoreState is the learner's current score record for a challenge or sentence
+type ScoreState struct {
+	ID            primitive.ObjectID    `bson:"_id"`
+	AttemptCount  int                   `bson:"attempt_count"`
+	Score         float64               `bson:"score"`
+	BestScore     float64               `bson:"best_score"`
+	HumanReviewed bool                  `bson:"human_reviewed"`
+	PasteFlag     *scoremodel.PasteFlag `bson:"paste_flag,omitempty"`
+}
+
+// AppliedGrade replaces the AI grade on a learner's score record
+type AppliedGrade struct {
+	Score         float64
+	BestScore     float64
+	Feedback      string
+	Errors        scoremodel.ErrorList
+	Suggestions   string
+	PromptVersion string
+}
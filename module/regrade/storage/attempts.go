@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"context"
+	attemptmodel "hub-service/module/attempt/model"
+	"hub-service/module/regrade/model"
+	scoremodel "hub-service/module/score/model"
+	translationmodel "hub-service/module/translation/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CountAttempts counts the attempts a selection matches
+func (s *Storage) CountAttempts(ctx context.Context, selection *model.Selection) (int64, error) {
+	collection := s.db.MongoDB.GetCollection(attemptmodel.CollectionName)
+	return collection.CountDocuments(ctx, selectionQuery(selection))
+}
+
+// NextAttempts returns up to limit selected attempts after the cursor, in _id order
+func (s *Storage) NextAttempts(ctx context.Context, selection *model.Selection, after primitive.ObjectID, limit int) ([]attemptmodel.Attempt, error) {
+	collection := s.db.MongoDB.GetCollection(attemptmodel.CollectionName)
+
+	query := selectionQuery(selection)
+	if !after.IsZero() {
+		query["_id"] = bson.M{"$gt": after}
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	attempts := []attemptmodel.Attempt{}
+	if err = cursor.All(ctx, &attempts); err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+// ScoreFor returns the learner's score record the attempt belongs to, or nil when there is none
+func (s *Storage) ScoreFor(ctx context.Context, attempt *attemptmodel.Attempt) (*model.ScoreState, error) {
+	collection := s.db.MongoDB.GetCollection(scoreCollection(attempt.Kind))
+
+	var state model.ScoreState
+	if err := collection.FindOne(ctx, scoreQuery(attempt)).Decode(&state); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &state, nil
+}
+
+// ApplyGrade replaces the AI grade of a score record. It only writes while attemptNumber is still
+// the latest attempt and no reviewer has graded it by hand, and returns false otherwise.
+// updated_at is left alone so it keeps tracking the learner's last submission.
+func (s *Storage) ApplyGrade(ctx context.Context, kind string, scoreID primitive.ObjectID, attemptNumber int, grade *model.AppliedGrade) (bool, error) {
+	collection := s.db.MongoDB.GetCollection(scoreCollection(kind))
+
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": scoreID, "attempt_count": attemptNumber, "human_reviewed": bson.M{"$ne": true}},
+		bson.M{"$set": bson.M{
+			"score":          grade.Score,
+			"best_score":     grade.BestScore,
+			"feedback":       grade.Feedback,
+			"errors":         grade.Errors,
+			"suggestions":    grade.Suggestions,
+			"prompt_version": grade.PromptVersion,
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func selectionQuery(selection *model.Selection) bson.M {
+	query := bson.M{}
+	if selection.Kind != "" {
+		query["kind"] = selection.Kind
+	}
+	if !selection.ChallengeID.IsZero() {
+		query["challenge_id"] = selection.ChallengeID
+	}
+	if !selection.SectionID.IsZero() {
+		query["section_id"] = selection.SectionID
+	}
+	if !selection.TranslationID.IsZero() {
+		query["translation_id"] = selection.TranslationID
+	}
+	if selection.PromptVersion != "" {
+		query["prompt_version"] = selection.PromptVersion
+	}
+
+	createdAt := bson.M{}
+	if selection.From != nil {
+		createdAt["$gte"] = *selection.From
+	}
+	if selection.To != nil {
+		createdAt["$lt"] = *selection.To
+	}
+	if len(createdAt) > 0 {
+		query["created_at"] = createdAt
+	}
+	return query
+}
+
+func scoreCollection(kind string) string {
+	if kind == attemptmodel.KindSentence {
+		return translationmodel.UserTranslationScoreCollectionName
+	}
+	return scoremodel.CollectionName
+}
+
+func scoreQuery(attempt *attemptmodel.Attempt) bson.M {
+	if attempt.Kind == attemptmodel.KindSentence {
+		return bson.M{
+			"user_id":        attempt.UserID,
+			"translation_id": attempt.TranslationID,
+			"sentence_index": attempt.SentenceIndex,
+		}
+	}
+	return bson.M{"user_id": attempt.UserID, "challenge_id": attempt.ChallengeID}
+}
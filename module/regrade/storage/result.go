@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"hub-service/common"
+	"hub-service/module/regrade/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SaveResults stores attempt outcomes keyed by run and attempt, so an attempt graded again after
+// a resume replaces its earlier result instead of duplicating it
+func (s *Storage) SaveResults(ctx context.Context, results []model.RegradeResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+	collection := s.db.MongoDB.GetCollection(model.ResultCollectionName)
+
+	writes := make([]mongo.WriteModel, 0, len(results))
+	for _, result := range results {
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"run_id": result.RunID, "attempt_id": result.AttemptID}).
+			SetReplacement(result).
+			SetUpsert(true))
+	}
+
+	_, err := collection.BulkWrite(ctx, writes)
+	return err
+}
+
+// ListResults returns a run's results, largest score changes first, and fills paging.Total
+func (s *Storage) ListResults(ctx context.Context, runID primitive.ObjectID, paging *common.Paging) ([]model.RegradeResult, error) {
+	collection := s.db.MongoDB.GetCollection(model.ResultCollectionName)
+
+	query := bson.M{"run_id": runID}
+
+	findOptions := options.Find()
+	findOptions.SetSkip(int64((paging.Page - 1) * paging.Limit))
+	findOptions.SetLimit(int64(paging.Limit))
+	findOptions.SetSort(bson.D{{Key: "abs_delta", Value: -1}, {Key: "_id", Value: 1}})
+
+	cursor, err := collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	results := []model.RegradeResult{}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	total, err := collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	paging.Total = total
+
+	return results, nil
+}
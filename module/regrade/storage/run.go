@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"context"
+	"hub-service/common"
+	"hub-service/module/regrade/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Storage) CreateRun(ctx context.Context, run *model.RegradeRun) error {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	result, err := collection.InsertOne(ctx, run)
+	if err != nil {
+		return err
+	}
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		run.ID = id
+	}
+	return nil
+}
+
+func (s *Storage) GetRun(ctx context.Context, id primitive.ObjectID) (*model.RegradeRun, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	var run model.RegradeRun
+	if err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&run); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+// ListRuns returns runs newest first and fills paging.Total
+func (s *Storage) ListRuns(ctx context.Context, paging *common.Paging) ([]model.RegradeRun, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	findOptions := options.Find()
+	findOptions.SetSkip(int64((paging.Page - 1) * paging.Limit))
+	findOptions.SetLimit(int64(paging.Limit))
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := collection.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	runs := []model.RegradeRun{}
+	if err = cursor.All(ctx, &runs); err != nil {
+		return nil, err
+	}
+
+	total, err := collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	paging.Total = total
+
+	return runs, nil
+}
+
+// SetRunStatus moves a run from one of the from statuses to to and returns false when it was in none
+// of them. The checkpoint is kept, so a resumed run continues where it stopped.
+func (s *Storage) SetRunStatus(ctx context.Context, id primitive.ObjectID, from []string, to string) (bool, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": bson.M{"$in": from}},
+		bson.M{
+			"$set":   bson.M{"status": to, "updated_at": time.Now()},
+			"$unset": bson.M{"lease_until": "", "error": ""},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// ClaimRun leases the oldest queued run, or failing that a running one whose lease has expired
+// because the process working on it stopped. It returns nil when there is nothing to do.
+func (s *Storage) ClaimRun(ctx context.Context, lease time.Duration) (*model.RegradeRun, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+	now := time.Now()
+	leaseUntil := now.Add(lease)
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var run model.RegradeRun
+	err := collection.FindOneAndUpdate(ctx,
+		bson.M{"status": model.StatusQueued},
+		bson.M{"$set": bson.M{"status": model.StatusRunning, "lease_until": leaseUntil, "started_at": now, "updated_at": now}},
+		opts,
+	).Decode(&run)
+	if err == nil {
+		return &run, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	err = collection.FindOneAndUpdate(ctx,
+		bson.M{"status": model.StatusRunning, "lease_until": bson.M{"$lt": now}},
+		bson.M{"$set": bson.M{"lease_until": leaseUntil, "updated_at": now}},
+		opts,
+	).Decode(&run)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+// SaveCheckpoint records progress and renews the lease. It returns false when the run is no longer
+// running, e.g. because an admin cancelled it.
+func (s *Storage) SaveCheckpoint(ctx context.Context, id primitive.ObjectID, checkpoint *model.Checkpoint) (bool, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": model.StatusRunning},
+		bson.M{"$set": bson.M{
+			"cursor":         checkpoint.Cursor,
+			"processed":      checkpoint.Processed,
+			"failed":         checkpoint.Failed,
+			"applied":        checkpoint.Applied,
+			"skipped":        checkpoint.Skipped,
+			"before":         checkpoint.Before,
+			"after":          checkpoint.After,
+			"provider":       checkpoint.Provider,
+			"prompt_version": checkpoint.PromptVersion,
+			"lease_until":    checkpoint.LeaseUntil,
+			"updated_at":     time.Now(),
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// RenewLease keeps a running run with its runner until leaseUntil. It returns false when the run
+// is no longer running, e.g. after it was cancelled.
+func (s *Storage) RenewLease(ctx context.Context, id primitive.ObjectID, leaseUntil time.Time) (bool, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": model.StatusRunning},
+		bson.M{"$set": bson.M{"lease_until": leaseUntil, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// FinishRun ends a running run as completed or failed; a run cancelled meanwhile is left alone
+func (s *Storage) FinishRun(ctx context.Context, id primitive.ObjectID, status string, runErr string) error {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+	now := time.Now()
+
+	set := bson.M{"status": status, "updated_at": now}
+	if status == model.StatusCompleted {
+		set["completed_at"] = now
+	}
+	if runErr != "" {
+		set["error"] = runErr
+	}
+
+	_, err := collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": model.StatusRunning},
+		bson.M{"$set": set, "$unset": bson.M{"lease_until": ""}},
+	)
+	return err
+}
@@ -0,0 +1,23 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
+	runs := g.Group("/regrade/runs")
+	runs.Use(auth.AuthMiddleware(appCtx))
+	runs.Use(auth.RequireRoles(common.RoleAdmin, common.RoleSuperAdmin))
+	{
+		runs.POST("", CreateRun(appCtx))
+		runs.GET("", ListRuns(appCtx))
+		runs.GET("/:id", GetRun(appCtx))
+		runs.GET("/:id/results", ListResults(appCtx))
+		runs.POST("/:id/cancel", CancelRun(appCtx))
+		runs.POST("/:id/resume", ResumeRun(appCtx))
+	}
+}
@@ -0,0 +1,209 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/module/regrade/biz"
+	"hub-service/module/regrade/model"
+	"hub-service/module/regrade/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CreateRun godoc
+// @Summary Start a bulk re-grade
+// @Description Queue a background re-grade of stored attempts with the current grading provider, e.g. after a prompt or model change. Narrow it by kind, challenge, section, passage, date range or the prompt version the attempts were graded with, or set all. The run reports before and after score distributions; with apply, the new grade also replaces the learner's score when the attempt is still their latest one and no reviewer has overridden it. Admin only.
+// @Tags regrade
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.CreateRunRequest true "Selection"
+// @Success 200 {object} common.Response{data=model.RegradeRun} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Failure 422 {object} common.AppError "No attempts match the selection"
+// @Router /api/regrade/runs [post]
+func CreateRun(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req model.CreateRunRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		adminID := c.MustGet("user_id").(primitive.ObjectID)
+
+		business := biz.NewCreateRunBiz(storage.NewStorage(appCtx.GetDatabase()))
+
+		result, err := business.CreateRun(c.Request.Context(), &req, adminID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// ListRuns godoc
+// @Summary List bulk re-grades
+// @Description Re-grade runs with their progress and score distributions, newest first. Admin only.
+// @Tags regrade
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Success 200 {object} common.Response{data=[]model.RegradeRun,meta=common.Paging} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Router /api/regrade/runs [get]
+func ListRuns(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var paging common.Paging
+		if err := c.ShouldBind(&paging); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+		paging.Fulfill()
+
+		business := biz.NewRunBiz(storage.NewStorage(appCtx.GetDatabase()))
+
+		result, err := business.ListRuns(c.Request.Context(), &paging)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.NewSuccessResponse(result, paging, nil))
+	}
+}
+
+// GetRun godoc
+// @Summary Get a bulk re-grade
+// @Description Progress, before and after score distributions and the error of a failed run. Admin only.
+// @Tags regrade
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Run ID"
+// @Success 200 {object} common.Response{data=model.RegradeRun} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Failure 404 {object} common.AppError "Run not found"
+// @Router /api/regrade/runs/{id} [get]
+func GetRun(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		runID := runIDParam(c)
+
+		business := biz.NewRunBiz(storage.NewStorage(appCtx.GetDatabase()))
+
+		result, err := business.GetRun(c.Request.Context(), runID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// ListResults godoc
+// @Summary List the results of a bulk re-grade
+// @Description Before and after score of each attempt graded so far, largest changes first, with why a new grade was not applied or why grading failed. Admin only.
+// @Tags regrade
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Run ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Success 200 {object} common.Response{data=[]model.RegradeResult,meta=common.Paging} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Failure 404 {object} common.AppError "Run not found"
+// @Router /api/regrade/runs/{id}/results [get]
+func ListResults(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		runID := runIDParam(c)
+
+		var paging common.Paging
+		if err := c.ShouldBind(&paging); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+		paging.Fulfill()
+
+		business := biz.NewRunBiz(storage.NewStorage(appCtx.GetDatabase()))
+
+		result, err := business.ListResults(c.Request.Context(), runID, &paging)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.NewSuccessResponse(result, paging, nil))
+	}
+}
+
+// CancelRun godoc
+// @Summary Cancel a bulk re-grade
+// @Description Stop a queued or running re-grade after the attempt it is grading. Grades already applied stay applied. Admin only.
+// @Tags regrade
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Run ID"
+// @Success 200 {object} common.Response{data=model.RegradeRun} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Failure 404 {object} common.AppError "Run not found"
+// @Failure 409 {object} common.AppError "Run already finished"
+// @Router /api/regrade/runs/{id}/cancel [post]
+func CancelRun(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		runID := runIDParam(c)
+
+		business := biz.NewRunBiz(storage.NewStorage(appCtx.GetDatabase()))
+
+		result, err := business.Cancel(c.Request.Context(), runID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// ResumeRun godoc
+// @Summary Resume a bulk re-grade
+// @Description Queue a failed or cancelled re-grade again. It continues after its last checkpoint. Admin only.
+// @Tags regrade
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Run ID"
+// @Success 200 {object} common.Response{data=model.RegradeRun} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Failure 404 {object} common.AppError "Run not found"
+// @Failure 409 {object} common.AppError "Run not failed or cancelled"
+// @Router /api/regrade/runs/{id}/resume [post]
+func ResumeRun(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		runID := runIDParam(c)
+
+		business := biz.NewRunBiz(storage.NewStorage(appCtx.GetDatabase()))
+
+		result, err := business.Resume(c.Request.Context(), runID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+func runIDParam(c *gin.Context) primitive.ObjectID {
+	runID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		panic(common.ErrInvalidRequest(err))
+	}
+	return runID
+}
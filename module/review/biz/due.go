@@ -0,0 +1,57 @@
+package biz
+
+import (
+	"context"
+	"hub-service/common"
+	"hub-service/module/review/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DefaultDueLimit = 20
+	MaxDueLimit     = 100
+)
+
+type DueStore interface {
+	ListDue(ctx context.Context, userID primitive.ObjectID, now time.Time, limit int64) ([]model.ReviewItem, error)
+	CountDue(ctx context.Context, userID primitive.ObjectID, now time.Time) (int64, error)
+	NextDueAt(ctx context.Context, userID primitive.ObjectID, now time.Time) (*time.Time, error)
+}
+
+type getDueBiz struct {
+	store DueStore
+}
+
+func NewGetDueBiz(store DueStore) *getDueBiz {
+	return &getDueBiz{store: store}
+}
+
+// GetDue returns up to limit items due for review, most overdue first
+func (biz *getDueBiz) GetDue(ctx context.Context, userID primitive.ObjectID, limit int) (*model.DueQueue, error) {
+	if limit <= 0 {
+		limit = DefaultDueLimit
+	}
+	if limit > MaxDueLimit {
+		limit = MaxDueLimit
+	}
+
+	now := time.Now()
+	items, err := biz.store.ListDue(ctx, userID, now, int64(limit))
+	if err != nil {
+		return nil, common.ErrCannotListEntity(model.CollectionName, err)
+	}
+
+	count, err := biz.store.CountDue(ctx, userID, now)
+	if err != nil {
+		return nil, common.ErrCannotListEntity(model.CollectionName, err)
+	}
+
+	next, err := biz.store.NextDueAt(ctx, userID, now)
+	if err != nil {
+		return nil, common.ErrCannotListEntity(model.CollectionName, err)
+	}
+
+	return &model.DueQueue{Items: items, DueCount: count, NextDueAt: next}, nil
+}
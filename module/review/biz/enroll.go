@@ -0,0 +1,79 @@
+package biz
+
+import (
+	"context"
+	"hub-service/common"
+	"hub-service/module/review/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type EnrollStore interface {
+	ListScores(ctx context.Context, userID primitive.ObjectID) ([]model.ScoredItem, error)
+	FindChallenge(ctx context.Context, userID, challengeID primitive.ObjectID) (*model.ReviewItem, error)
+	FindSentence(ctx context.Context, userID, translationID primitive.ObjectID, sentenceIndex int) (*model.ReviewItem, error)
+	Create(ctx context.Context, data *model.ReviewItem) error
+}
+
+type enrollBiz struct {
+	store EnrollStore
+	rule  enrollRule
+}
+
+// NewEnrollBiz enrolls scores the way NewScheduler does, with the same thresholds
+func NewEnrollBiz(store EnrollStore, enrollBelow float64, enrollMinErrors int) *enrollBiz {
+	return &enrollBiz{store: store, rule: enrollRule{below: enrollBelow, minErrors: enrollMinErrors}}
+}
+
+// EnrollFromScores queues the learner's existing weak scores, e.g. those graded before the
+// review queue existed. Items already in the queue are left as they are.
+func (biz *enrollBiz) EnrollFromScores(ctx context.Context, userID primitive.ObjectID) (*model.EnrollResult, error) {
+	scores, err := biz.store.ListScores(ctx, userID)
+	if err != nil {
+		return nil, common.ErrCannotListEntity("Score", err)
+	}
+
+	now := time.Now()
+	result := &model.EnrollResult{}
+	for _, sc := range scores {
+		if !biz.rule.weak(sc.BestScore, len(sc.Errors)) {
+			continue
+		}
+
+		var existing *model.ReviewItem
+		if sc.Kind == model.KindSentence {
+			existing, err = biz.store.FindSentence(ctx, userID, sc.TranslationID, sc.SentenceIndex)
+		} else {
+			existing, err = biz.store.FindChallenge(ctx, userID, sc.ChallengeID)
+		}
+		if err != nil {
+			return nil, common.ErrCannotGetEntity(model.CollectionName, err)
+		}
+		if existing != nil {
+			continue
+		}
+
+		item := &model.ReviewItem{
+			UserID:          userID,
+			Kind:            sc.Kind,
+			ChallengeID:     sc.ChallengeID,
+			TranslationID:   sc.TranslationID,
+			SentenceIndex:   sc.SentenceIndex,
+			OriginalContent: sc.OriginalContent,
+			BestScore:       sc.BestScore,
+			LastScore:       sc.LastScore,
+			ErrorTypes:      errorTypes(sc.Errors),
+		}
+		// Old scores are due right away rather than a day after they were graded
+		newItem(item, now)
+		item.DueAt = now
+
+		if err := biz.store.Create(ctx, item); err != nil {
+			return nil, common.ErrCannotCreateEntity(model.CollectionName, err)
+		}
+		result.Enrolled++
+	}
+
+	return result, nil
+}
@@ -0,0 +1,109 @@
+package biz
+
+import (
+	"context"
+	"hub-service/module/review/model"
+	scorebiz "hub-service/module/score/biz"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type reviewKey struct{}
+
+// WithReview marks ctx as a review of the item, so the submission reschedules it even before it is due
+func WithReview(ctx context.Context, itemID primitive.ObjectID) context.Context {
+	return context.WithValue(ctx, reviewKey{}, itemID)
+}
+
+func reviewing(ctx context.Context, itemID primitive.ObjectID) bool {
+	id, _ := ctx.Value(reviewKey{}).(primitive.ObjectID)
+	return id == itemID
+}
+
+type SchedulerStore interface {
+	FindChallenge(ctx context.Context, userID, challengeID primitive.ObjectID) (*model.ReviewItem, error)
+	FindSentence(ctx context.Context, userID, translationID primitive.ObjectID, sentenceIndex int) (*model.ReviewItem, error)
+	Create(ctx context.Context, data *model.ReviewItem) error
+	Update(ctx context.Context, data *model.ReviewItem) error
+}
+
+// scheduler enrolls weak submissions in the review queue and reschedules items when they are reviewed
+type scheduler struct {
+	store SchedulerStore
+	rule  enrollRule
+}
+
+// NewScheduler returns a SubmissionListener that maintains the review queue. Items are enrolled
+// when their best score is below enrollBelow or their latest submission has enrollMinErrors errors.
+func NewScheduler(store SchedulerStore, enrollBelow float64, enrollMinErrors int) *scheduler {
+	return &scheduler{store: store, rule: enrollRule{below: enrollBelow, minErrors: enrollMinErrors}}
+}
+
+func (s *scheduler) OnSubmission(ctx context.Context, event *scorebiz.SubmissionEvent) error {
+	if event.Analysis == nil {
+		return nil
+	}
+
+	var item *model.ReviewItem
+	var err error
+	if event.Kind == scorebiz.SubmissionKindSentence {
+		item, err = s.store.FindSentence(ctx, event.UserID, event.TranslationID, event.SentenceIndex)
+	} else {
+		item, err = s.store.FindChallenge(ctx, event.UserID, event.ChallengeID)
+	}
+	if err != nil {
+		return err
+	}
+
+	at := event.SubmittedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+	score := event.Analysis.Score
+	errors := scorebiz.StoredErrors(event.Analysis.Errors)
+
+	if item == nil {
+		if !s.rule.weak(event.BestScore, len(errors)) {
+			return nil
+		}
+		item = &model.ReviewItem{
+			UserID:          event.UserID,
+			Kind:            event.Kind,
+			ChallengeID:     event.ChallengeID,
+			TranslationID:   event.TranslationID,
+			SentenceIndex:   event.SentenceIndex,
+			OriginalContent: event.OriginalContent,
+			BestScore:       event.BestScore,
+			LastScore:       score,
+			ErrorTypes:      errorTypes(errors),
+		}
+		newItem(item, at)
+		return s.store.Create(ctx, item)
+	}
+
+	item.BestScore = math.Max(item.BestScore, event.BestScore)
+	item.ErrorTypes = errorTypes(errors)
+
+	switch {
+	case item.Status == model.StatusGraduated:
+		// A graduated item that is failed again goes back into the queue
+		if quality(score) < 3 {
+			createdAt := item.CreatedAt
+			item.Repetitions = 0
+			item.Lapses++
+			newItem(item, at)
+			item.CreatedAt = createdAt
+		}
+		item.LastScore = score
+	case !item.DueAt.After(at) || reviewing(ctx, item.ID):
+		applyReview(item, score, at)
+	default:
+		// Practising before the item is due does not change its schedule
+		item.LastScore = score
+	}
+
+	item.UpdatedAt = at
+	return s.store.Update(ctx, item)
+}
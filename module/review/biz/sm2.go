@@ -0,0 +1,134 @@
+package biz
+
+import (
+	"hub-service/module/review/model"
+	scoremodel "hub-service/module/score/model"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	initialEaseFactor = 2.5
+	minEaseFactor     = 1.3
+
+	// An item leaves the queue after this many successful reviews in a row
+	// once the learner scores at least graduateScore
+	graduateAfter = 3
+	graduateScore = 90.0
+
+	defaultEnrollBelow     = 70.0
+	defaultEnrollMinErrors = 3
+)
+
+// EnrollBelow reads REVIEW_ENROLL_BELOW, the best score under which an item is queued for review
+func EnrollBelow() float64 {
+	raw := os.Getenv("REVIEW_ENROLL_BELOW")
+	if raw == "" {
+		return defaultEnrollBelow
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		log.Printf("Invalid REVIEW_ENROLL_BELOW %q, using %.0f", raw, defaultEnrollBelow)
+		return defaultEnrollBelow
+	}
+	return v
+}
+
+// EnrollMinErrors reads REVIEW_ENROLL_MIN_ERRORS, the number of grading errors in the latest
+// submission that queues an item for review whatever its score; 0 disables the rule
+func EnrollMinErrors() int {
+	raw := os.Getenv("REVIEW_ENROLL_MIN_ERRORS")
+	if raw == "" {
+		return defaultEnrollMinErrors
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		log.Printf("Invalid REVIEW_ENROLL_MIN_ERRORS %q, using %d", raw, defaultEnrollMinErrors)
+		return defaultEnrollMinErrors
+	}
+	return v
+}
+
+// enrollRule decides which scores are queued for review, from EnrollBelow and EnrollMinErrors
+type enrollRule struct {
+	below     float64
+	minErrors int
+}
+
+// weak reports whether a score is poor enough to be reviewed later
+func (r enrollRule) weak(bestScore float64, errorCount int) bool {
+	if bestScore < r.below {
+		return true
+	}
+	return r.minErrors > 0 && errorCount >= r.minErrors
+}
+
+// newItem queues an item for its first review a day after the submission
+func newItem(item *model.ReviewItem, at time.Time) {
+	item.EaseFactor = initialEaseFactor
+	item.IntervalDays = 1
+	item.Status = model.StatusActive
+	item.DueAt = at.AddDate(0, 0, 1)
+	item.CreatedAt = at
+	item.UpdatedAt = at
+}
+
+// quality maps a 0-100 score to the SM-2 recall grade 0-5; 3 and up is a successful review
+func quality(score float64) int {
+	q := int(score / 20)
+	if q > 5 {
+		return 5
+	}
+	if q < 0 {
+		return 0
+	}
+	return q
+}
+
+// applyReview reschedules the item after a review scored score, following SM-2
+func applyReview(item *model.ReviewItem, score float64, at time.Time) {
+	q := quality(score)
+
+	// A failed recall restarts the repetitions but, as in SM-2, leaves the ease factor alone
+	if q < 3 {
+		item.Repetitions = 0
+		item.IntervalDays = 1
+		item.Lapses++
+	} else {
+		switch item.Repetitions {
+		case 0:
+			item.IntervalDays = 1
+		case 1:
+			item.IntervalDays = 6
+		default:
+			item.IntervalDays = int(math.Round(float64(item.IntervalDays) * item.EaseFactor))
+		}
+		item.Repetitions++
+
+		miss := float64(5 - q)
+		item.EaseFactor = math.Max(minEaseFactor, item.EaseFactor+0.1-miss*(0.08+miss*0.02))
+	}
+
+	item.LastScore = score
+	item.LastReviewedAt = &at
+	item.DueAt = at.AddDate(0, 0, item.IntervalDays)
+	if item.Repetitions >= graduateAfter && score >= graduateScore {
+		item.Status = model.StatusGraduated
+	}
+}
+
+// errorTypes lists the distinct error types in grading order
+func errorTypes(errors scoremodel.ErrorList) []string {
+	seen := map[string]bool{}
+	types := []string{}
+	for _, e := range errors {
+		if e.Type != "" && !seen[e.Type] {
+			seen[e.Type] = true
+			types = append(types, e.Type)
+		}
+	}
+	return types
+}
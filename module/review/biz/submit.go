@@ -0,0 +1,81 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"hub-service/common"
+	"hub-service/module/review/model"
+	scoremodel "hub-service/module/score/model"
+	translationmodel "hub-service/module/translation/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrItemGraduated = common.NewErrorResponse(
+	errors.New("review item graduated"),
+	"This item has left the review queue",
+	"review item graduated",
+	"ErrItemGraduated",
+)
+
+type SubmitReviewStore interface {
+	Get(ctx context.Context, id primitive.ObjectID) (*model.ReviewItem, error)
+}
+
+// ChallengeGrader is the challenge submit path, implemented by the score module
+type ChallengeGrader interface {
+	SubmitScore(ctx context.Context, userID primitive.ObjectID, req *scoremodel.SubmitScoreRequest, feedbackLanguage string) (*scoremodel.SubmitScoreResponse, error)
+}
+
+// SentenceGrader is the passage sentence submit path, implemented by the translation module
+type SentenceGrader interface {
+	SubmitSentenceTranslation(ctx context.Context, translationID primitive.ObjectID, sentenceIndex int, userTranslation string, telemetry *scoremodel.Telemetry, userID primitive.ObjectID, feedbackLanguage string) (*translationmodel.SubmitSentenceTranslationResponse, error)
+}
+
+type submitReviewBiz struct {
+	store      SubmitReviewStore
+	challenges ChallengeGrader
+	sentences  SentenceGrader
+}
+
+func NewSubmitReviewBiz(store SubmitReviewStore, challenges ChallengeGrader, sentences SentenceGrader) *submitReviewBiz {
+	return &submitReviewBiz{store: store, challenges: challenges, sentences: sentences}
+}
+
+// SubmitReview grades a new translation of the item through the regular submit path. The
+// scheduler listener registered on that path then reschedules the item, even if it was not due yet.
+func (biz *submitReviewBiz) SubmitReview(ctx context.Context, userID, itemID primitive.ObjectID, req *model.ReviewSubmitRequest, feedbackLanguage string) (*model.ReviewSubmitResult, error) {
+	item, err := biz.store.Get(ctx, itemID)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity(model.CollectionName, err)
+	}
+	if item == nil || item.UserID != userID {
+		return nil, common.ErrEntityNotFound(model.CollectionName, common.RecordNotFound)
+	}
+	if item.Status == model.StatusGraduated {
+		return nil, ErrItemGraduated
+	}
+
+	ctx = WithReview(ctx, item.ID)
+
+	var grading interface{}
+	if item.Kind == model.KindSentence {
+		grading, err = biz.sentences.SubmitSentenceTranslation(ctx, item.TranslationID, item.SentenceIndex, req.UserTranslation, req.Telemetry, userID, feedbackLanguage)
+	} else {
+		grading, err = biz.challenges.SubmitScore(ctx, userID, &scoremodel.SubmitScoreRequest{
+			ChallengeID:     item.ChallengeID.Hex(),
+			UserTranslation: req.UserTranslation,
+			Telemetry:       req.Telemetry,
+		}, feedbackLanguage)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := biz.store.Get(ctx, itemID)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity(model.CollectionName, err)
+	}
+
+	return &model.ReviewSubmitResult{Grading: grading, Item: updated}, nil
+}
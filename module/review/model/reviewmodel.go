@@ -0,0 +1,88 @@
+package model
+
+import (
+	scoremodel "hub-service/module/score/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const CollectionName = "review_items"
+
+// Item kinds, matching the submission kinds
+const (
+	KindChallenge = "challenge"
+	KindSentence  = "sentence"
+)
+
+// Item statuses. Graduated items are remembered well enough to leave the queue.
+const (
+	StatusActive    = "active"
+	StatusGraduated = "graduated"
+)
+
+// ReviewItem schedules a challenge or passage sentence the learner did poorly on
+// for another try, SM-2 style: intervals grow while reviews go well and reset when they don't.
+type ReviewItem struct {
+	ID              primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID          primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Kind            string             `json:"kind" bson:"kind"`
+	ChallengeID     primitive.ObjectID `json:"challenge_id,omitempty" bson:"challenge_id,omitempty"`
+	TranslationID   primitive.ObjectID `json:"translation_id,omitempty" bson:"translation_id,omitempty"`
+	SentenceIndex   int                `json:"sentence_index" bson:"sentence_index"`
+	OriginalContent string             `json:"original_content" bson:"original_content"`
+	BestScore       float64            `json:"best_score" bson:"best_score"`
+	LastScore       float64            `json:"last_score" bson:"last_score"`
+	ErrorTypes      []string           `json:"error_types,omitempty" bson:"error_types,omitempty"`
+	EaseFactor      float64            `json:"ease_factor" bson:"ease_factor"`
+	IntervalDays    int                `json:"interval_days" bson:"interval_days"`
+	Repetitions     int                `json:"repetitions" bson:"repetitions"`
+	Lapses          int                `json:"lapses" bson:"lapses"`
+	Status          string             `json:"status" bson:"status"`
+	DueAt           time.Time          `json:"due_at" bson:"due_at"`
+	LastReviewedAt  *time.Time         `json:"last_reviewed_at,omitempty" bson:"last_reviewed_at,omitempty"`
+	CreatedAt       time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+func (ReviewItem) TableName() string {
+	return CollectionName
+}
+
+// DueQueue is the learner's review items due now
+type DueQueue struct {
+	Items    []ReviewItem `json:"items"`
+	DueCount int64        `json:"due_count"`
+	// NextDueAt is when the next item not yet due becomes due
+	NextDueAt *time.Time `json:"next_due_at,omitempty"`
+}
+
+// ReviewSubmitRequest is a new translation for a review item
+type ReviewSubmitRequest struct {
+	UserTranslation string                `json:"user_translation" binding:"required"`
+	Telemetry       *scoremodel.Telemetry `json:"telemetry,omitempty"`
+}
+
+// ReviewSubmitResult is the grading result of a review together with its new schedule
+type ReviewSubmitResult struct {
+	Grading interface{} `json:"grading"`
+	Item    *ReviewItem `json:"item"`
+}
+
+// EnrollResult reports how many weak scores were added to the queue
+type EnrollResult struct {
+	Enrolled int `json:"enrolled"`
+}
+
+// ScoredItem is an existing challenge or sentence score considered for enrollment
+type ScoredItem struct {
+	Kind            string
+	ChallengeID     primitive.ObjectID
+	TranslationID   primitive.ObjectID
+	SentenceIndex   int
+	OriginalContent string
+	BestScore       float64
+	LastScore       float64
+	Errors          scoremodel.ErrorList
+	UpdatedAt       time.Time
+}
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/review/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Storage) Create(ctx context.Context, data *model.ReviewItem) error {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	result, err := collection.InsertOne(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	data.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (s *Storage) Update(ctx context.Context, data *model.ReviewItem) error {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	_, err := collection.ReplaceOne(ctx, bson.M{"_id": data.ID}, data)
+	return err
+}
+
+func (s *Storage) Get(ctx context.Context, id primitive.ObjectID) (*model.ReviewItem, error) {
+	return s.findOne(ctx, bson.M{"_id": id})
+}
+
+// FindChallenge returns the user's review item for a challenge
+func (s *Storage) FindChallenge(ctx context.Context, userID, challengeID primitive.ObjectID) (*model.ReviewItem, error) {
+	return s.findOne(ctx, bson.M{"user_id": userID, "kind": model.KindChallenge, "challenge_id": challengeID})
+}
+
+// FindSentence returns the user's review item for a passage sentence
+func (s *Storage) FindSentence(ctx context.Context, userID, translationID primitive.ObjectID, sentenceIndex int) (*model.ReviewItem, error) {
+	return s.findOne(ctx, bson.M{
+		"user_id":        userID,
+		"kind":           model.KindSentence,
+		"translation_id": translationID,
+		"sentence_index": sentenceIndex,
+	})
+}
+
+func (s *Storage) findOne(ctx context.Context, filter bson.M) (*model.ReviewItem, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	var item model.ReviewItem
+	if err := collection.FindOne(ctx, filter).Decode(&item); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// ListDue returns the user's active items due by now, most overdue first
+func (s *Storage) ListDue(ctx context.Context, userID primitive.ObjectID, now time.Time, limit int64) ([]model.ReviewItem, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	opts := options.Find().SetSort(bson.M{"due_at": 1}).SetLimit(limit)
+	cursor, err := collection.Find(ctx, dueFilter(userID, now), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	items := []model.ReviewItem{}
+	if err = cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (s *Storage) CountDue(ctx context.Context, userID primitive.ObjectID, now time.Time) (int64, error) {
+	return s.db.MongoDB.GetCollection(model.CollectionName).CountDocuments(ctx, dueFilter(userID, now))
+}
+
+// NextDueAt returns when the user's next item not yet due becomes due, or nil
+func (s *Storage) NextDueAt(ctx context.Context, userID primitive.ObjectID, now time.Time) (*time.Time, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	filter := bson.M{"user_id": userID, "status": model.StatusActive, "due_at": bson.M{"$gt": now}}
+	var item model.ReviewItem
+	err := collection.FindOne(ctx, filter, options.FindOne().SetSort(bson.M{"due_at": 1})).Decode(&item)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item.DueAt, nil
+}
+
+func dueFilter(userID primitive.ObjectID, now time.Time) bson.M {
+	return bson.M{"user_id": userID, "status": model.StatusActive, "due_at": bson.M{"$lte": now}}
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/review/model"
+	scoremodel "hub-service/module/score/model"
+	translationmodel "hub-service/module/translation/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ListScores returns every challenge and passage sentence score of the user, so the
+// caller can pick the weak ones by best score and recorded errors
+func (s *Storage) ListScores(ctx context.Context, userID primitive.ObjectID) ([]model.ScoredItem, error) {
+	result := []model.ScoredItem{}
+
+	cursor, err := s.db.MongoDB.GetCollection(scoremodel.CollectionName).Find(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	var scores []scoremodel.Score
+	err = cursor.All(ctx, &scores)
+	cursor.Close(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, sc := range scores {
+		result = append(result, model.ScoredItem{
+			Kind:            model.KindChallenge,
+			ChallengeID:     sc.ChallengeID,
+			OriginalContent: sc.OriginalContent,
+			BestScore:       sc.BestScore,
+			LastScore:       sc.Score,
+			Errors:          sc.Errors,
+			UpdatedAt:       sc.UpdatedAt,
+		})
+	}
+
+	// Sentence scores do not keep the source text, so it is joined from the sentence
+	pipeline := []bson.M{
+		{"$match": bson.M{"user_id": userID}},
+		{"$lookup": bson.M{
+			"from":         translationmodel.SentenceCollectionName,
+			"localField":   "sentence_id",
+			"foreignField": "_id",
+			"as":           "sentence",
+		}},
+		{"$unwind": bson.M{"path": "$sentence", "preserveNullAndEmptyArrays": true}},
+	}
+	cursor, err = s.db.MongoDB.GetCollection(translationmodel.UserTranslationScoreCollectionName).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var sentenceScores []struct {
+		translationmodel.UserTranslationScore `bson:",inline"`
+		Sentence                              struct {
+			Content string `bson:"content"`
+		} `bson:"sentence"`
+	}
+	err = cursor.All(ctx, &sentenceScores)
+	cursor.Close(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, sc := range sentenceScores {
+		result = append(result, model.ScoredItem{
+			Kind:            model.KindSentence,
+			TranslationID:   sc.TranslationID,
+			SentenceIndex:   sc.SentenceIndex,
+			OriginalContent: sc.Sentence.Content,
+			BestScore:       sc.BestScore,
+			LastScore:       sc.Score,
+			Errors:          sc.Errors,
+			UpdatedAt:       sc.UpdatedAt,
+		})
+	}
+
+	return result, nil
+}
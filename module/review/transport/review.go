@@ -0,0 +1,124 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	challengestorage "hub-service/module/challenge/storage"
+	"hub-service/module/grading"
+	"hub-service/module/review/biz"
+	"hub-service/module/review/model"
+	"hub-service/module/review/storage"
+	scorebiz "hub-service/module/score/biz"
+	scorestorage "hub-service/module/score/storage"
+	translationbiz "hub-service/module/translation/biz"
+	translationstorage "hub-service/module/translation/storage"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetDueReviews godoc
+// @Summary Get review items due now
+// @Description Challenges and passage sentences the caller did poorly on, scheduled for another try with SM-2 style intervals. Items are enrolled automatically when a submission's best score is low or its grading lists many errors.
+// @Tags review
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Maximum number of items" default(20)
+// @Success 200 {object} common.Response{data=model.DueQueue} "Success"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Router /api/review/due [get]
+func GetDueReviews(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, _ := strconv.Atoi(c.Query("limit"))
+		userID := c.MustGet("user_id").(primitive.ObjectID)
+
+		business := biz.NewGetDueBiz(storage.NewStorage(appCtx.GetDatabase()))
+		result, err := business.GetDue(c.Request.Context(), userID, limit)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// EnrollReviews godoc
+// @Summary Queue my existing weak scores for review
+// @Description Add the caller's weak challenge and sentence scores that are not in the review queue yet, e.g. ones graded before the queue existed. They are due immediately.
+// @Tags review
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} common.Response{data=model.EnrollResult} "Success"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Router /api/review/enroll [post]
+func EnrollReviews(appCtx appctx.AppContext) gin.HandlerFunc {
+	enrollBelow, enrollMinErrors := biz.EnrollBelow(), biz.EnrollMinErrors()
+
+	return func(c *gin.Context) {
+		userID := c.MustGet("user_id").(primitive.ObjectID)
+
+		business := biz.NewEnrollBiz(storage.NewStorage(appCtx.GetDatabase()), enrollBelow, enrollMinErrors)
+		result, err := business.EnrollFromScores(c.Request.Context(), userID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// SubmitReview godoc
+// @Summary Submit a review
+// @Description Grade a new translation of a review item through the regular challenge or sentence submit path and reschedule the item. Returns the usual grading result and the item's new schedule.
+// @Tags review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Review item ID"
+// @Param request body model.ReviewSubmitRequest true "Translation"
+// @Success 200 {object} common.Response{data=model.ReviewSubmitResult} "Success"
+// @Failure 400 {object} common.AppError "Bad request or item graduated"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 404 {object} common.AppError "Review item not found"
+// @Router /api/review/items/{id}/submit [post]
+func SubmitReview(appCtx appctx.AppContext) gin.HandlerFunc {
+	pairs := scorebiz.SupportedLanguagePairs()
+
+	return func(c *gin.Context) {
+		itemID, err := primitive.ObjectIDFromHex(c.Param("id"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		var req model.ReviewSubmitRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		userID := c.MustGet("user_id").(primitive.ObjectID)
+		db := appCtx.GetDatabase()
+
+		provider, err := grading.NewProvider(appCtx)
+		if err != nil {
+			panic(err)
+		}
+		hints := grading.HintLedger(appCtx)
+		paste := grading.PasteDetector(appCtx)
+		listeners := grading.SubmissionListeners(appCtx)
+
+		business := biz.NewSubmitReviewBiz(
+			storage.NewStorage(db),
+			scorebiz.NewScoreBiz(scorestorage.NewStorage(db), challengestorage.NewStorage(db), provider, pairs, hints, paste, listeners...),
+			translationbiz.NewSubmitTranslationBiz(translationstorage.NewStorage(db), provider, pairs, hints, paste, listeners...),
+		)
+
+		result, err := business.SubmitReview(grading.RequestContext(c), userID, itemID, &req, grading.FeedbackLanguage(c, appCtx))
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
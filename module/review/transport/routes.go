@@ -0,0 +1,19 @@
+package transport
+
+import (
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+	"hub-service/middleware/quota"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
+	review := g.Group("/review")
+	review.Use(auth.AuthMiddleware(appCtx))
+	{
+		review.GET("/due", GetDueReviews(appCtx))
+		review.POST("/enroll", EnrollReviews(appCtx))
+		review.POST("/items/:id/submit", quota.RequireAIQuota(appCtx), SubmitReview(appCtx))
+	}
+}
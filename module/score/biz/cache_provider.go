@@ -0,0 +1,160 @@
+package biz
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	gradingCacheKeyPrefix = "grading:cache:"
+	gradingCacheHitsKey   = "grading:cache:stats:hits"
+	gradingCacheMissesKey = "grading:cache:stats:misses"
+
+	defaultGradingCacheTTL = 24 * time.Hour
+)
+
+// GradingCache is the subset of redis.RedisClient used by the grading cache
+type GradingCache interface {
+	Get(key string) (string, error)
+	SetWithExpiry(key string, value interface{}, expiration time.Duration) error
+	Incr(key string) (int64, error)
+}
+
+// GradingCacheStats reports how often graded results were served from Redis
+type GradingCacheStats struct {
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+type cacheBypassKey struct{}
+
+// WithCacheBypass marks ctx so the grading cache is skipped on read; the fresh result still refreshes the cache
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+func cacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return bypass
+}
+
+// GradingCacheTTL reads GRADING_CACHE_TTL (a Go duration, e.g. "12h"); zero or negative disables caching
+func GradingCacheTTL() time.Duration {
+	raw := os.Getenv("GRADING_CACHE_TTL")
+	if raw == "" {
+		return defaultGradingCacheTTL
+	}
+	ttl, err := time.ParseDuration(raw)
+	if err != nil {
+		log.Printf("Invalid GRADING_CACHE_TTL %q, using %s", raw, defaultGradingCacheTTL)
+		return defaultGradingCacheTTL
+	}
+	return ttl
+}
+
+// cachedProvider serves identical grading requests from Redis
+type cachedProvider struct {
+	next  GradingProvider
+	cache GradingCache
+	ttl   time.Duration
+}
+
+// NewCachedProvider puts a content-addressed cache in front of next
+func NewCachedProvider(next GradingProvider, cache GradingCache, ttl time.Duration) GradingProvider {
+	return &cachedProvider{next: next, cache: cache, ttl: ttl}
+}
+
+func (p *cachedProvider) Name() string {
+	return p.next.Name()
+}
+
+func (p *cachedProvider) PromptVersion(ctx context.Context) string {
+	return p.next.PromptVersion(ctx)
+}
+
+func (p *cachedProvider) AnalyzeGrammar(ctx context.Context, req *GradeRequest) (*GrammarAnalysis, error) {
+	key := p.cacheKey(p.next.PromptVersion(ctx), req)
+
+	if !cacheBypassed(ctx) {
+		if cached, err := p.cache.Get(key); err != nil {
+			log.Printf("Grading cache read failed: %v", err)
+		} else if cached != "" {
+			var analysis GrammarAnalysis
+			if err := json.Unmarshal([]byte(cached), &analysis); err == nil {
+				p.count(gradingCacheHitsKey)
+				return &analysis, nil
+			}
+		}
+		p.count(gradingCacheMissesKey)
+	}
+
+	analysis, err := p.next.AnalyzeGrammar(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload, err := json.Marshal(analysis); err == nil {
+		if err := p.cache.SetWithExpiry(key, payload, p.ttl); err != nil {
+			log.Printf("Grading cache write failed: %v", err)
+		}
+	}
+
+	return analysis, nil
+}
+
+// cacheKey hashes the inputs together with the provider and prompt version,
+// so switching either never serves results graded under the old setup
+func (p *cachedProvider) cacheKey(promptVersion string, req *GradeRequest) string {
+	parts := []string{
+		p.next.Name(),
+		promptVersion,
+		strings.TrimSpace(req.OriginalText),
+		strings.TrimSpace(req.UserTranslation),
+		req.SourceLanguage,
+		req.TargetLanguage,
+		req.FeedbackLanguage,
+		req.MachineTranslation,
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return gradingCacheKeyPrefix + hex.EncodeToString(sum[:])
+}
+
+func (p *cachedProvider) count(key string) {
+	if _, err := p.cache.Incr(key); err != nil {
+		log.Printf("Grading cache metric %s failed: %v", key, err)
+	}
+}
+
+// GetGradingCacheStats reads the hit/miss counters kept by the grading cache
+func GetGradingCacheStats(cache GradingCache) (*GradingCacheStats, error) {
+	hits, err := readCounter(cache, gradingCacheHitsKey)
+	if err != nil {
+		return nil, err
+	}
+	misses, err := readCounter(cache, gradingCacheMissesKey)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &GradingCacheStats{Hits: hits, Misses: misses}
+	if total := hits + misses; total > 0 {
+		stats.HitRate = float64(hits) / float64(total)
+	}
+	return stats, nil
+}
+
+func readCounter(cache GradingCache, key string) (int64, error) {
+	raw, err := cache.Get(key)
+	if err != nil || raw == "" {
+		return 0, err
+	}
+	return strconv.ParseInt(raw, 10, 64)
+}
@@ -0,0 +1,39 @@
+package biz
+
+import (
+	"context"
+
+	"hub-service/infrastructure/external/llm"
+)
+
+// jsonGenerator prompts the grading backend for JSON content other than grades, e.g. draft challenges
+type jsonGenerator struct {
+	client llm.Client
+}
+
+// NewJSONGenerator uses the same llm.Client as grading. The offline mock provider cannot write
+// content, so AI_PROVIDER=mock is reported as not configured.
+func NewJSONGenerator(client llm.Client) (*jsonGenerator, error) {
+	if client == nil || llm.ProviderName() == llm.ProviderMock {
+		return nil, ErrProviderNotConfigured
+	}
+	return &jsonGenerator{client: client}, nil
+}
+
+func (g *jsonGenerator) Name() string {
+	return g.client.Name()
+}
+
+// GenerateJSON returns the JSON object in the model's reply, without code fences or chatter
+func (g *jsonGenerator) GenerateJSON(ctx context.Context, prompt string) (string, error) {
+	reply, err := generateText(ctx, g.client, prompt)
+	if err != nil {
+		return "", err
+	}
+
+	body := extractJSONObject(stripCodeFence(reply))
+	if body == "" {
+		return "", ErrAIResponseUnparsable
+	}
+	return body, nil
+}
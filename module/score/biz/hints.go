@@ -0,0 +1,63 @@
+package biz
+
+import (
+	"context"
+	"log"
+	"math"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// HintTarget identifies the challenge or passage sentence a learner is submitting
+type HintTarget struct {
+	Kind          string
+	UserID        primitive.ObjectID
+	ChallengeID   primitive.ObjectID
+	TranslationID primitive.ObjectID
+	SentenceIndex int
+}
+
+// HintPenalty is what the hints revealed before a submission cost
+type HintPenalty struct {
+	// HintsUsed is the strongest hint level revealed, 0 when none
+	HintsUsed int
+	Points    float64
+	// UsageID is the consumed hint usage, so a submission that fails to save can return it
+	UsageID primitive.ObjectID
+}
+
+// HintLedger hands over the hints revealed on a target since its last submission, so each
+// hint is charged once. A nil HintLedger charges nothing.
+type HintLedger interface {
+	ConsumeHints(ctx context.Context, target *HintTarget) (*HintPenalty, error)
+	ReturnHints(ctx context.Context, penalty *HintPenalty) error
+}
+
+// ApplyHintPenalty deducts the penalty of consumed hints from the analysis score, never below 0
+func ApplyHintPenalty(ctx context.Context, hints HintLedger, target *HintTarget, analysis *GrammarAnalysis) (*HintPenalty, error) {
+	if hints == nil {
+		return &HintPenalty{}, nil
+	}
+
+	penalty, err := hints.ConsumeHints(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	if penalty == nil {
+		return &HintPenalty{}, nil
+	}
+
+	analysis.Score = math.Max(0, analysis.Score-penalty.Points)
+	return penalty, nil
+}
+
+// ReturnHintPenalty hands the hints charged by ApplyHintPenalty back to the ledger when the
+// submission could not be saved, so the next attempt is charged for them instead
+func ReturnHintPenalty(ctx context.Context, hints HintLedger, penalty *HintPenalty) {
+	if hints == nil || penalty == nil || penalty.UsageID.IsZero() {
+		return
+	}
+	if err := hints.ReturnHints(ctx, penalty); err != nil {
+		log.Printf("Returning hint usage %s failed: %v", penalty.UsageID.Hex(), err)
+	}
+}
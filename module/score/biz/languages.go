@@ -0,0 +1,153 @@
+package biz
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"hub-service/common"
+)
+
+const defaultLanguagePairs = "vi-en,en-vi"
+
+// languageNames maps ISO 639-1 codes to the names used in grading prompts
+var languageNames = map[string]string{
+	"vi": "Vietnamese",
+	"en": "English",
+	"fr": "French",
+	"de": "German",
+	"es": "Spanish",
+	"ja": "Japanese",
+	"ko": "Korean",
+	"zh": "Chinese",
+}
+
+var (
+	ErrUnsupportedLanguagePair = common.NewFullErrorResponse(
+		http.StatusUnprocessableEntity,
+		errors.New("unsupported language pair"),
+		"Grading is not available for this language pair",
+		"unsupported language pair",
+		"UNSUPPORTED_LANGUAGE_PAIR",
+	)
+	ErrUnsupportedLanguage = common.NewFullErrorResponse(
+		http.StatusBadRequest,
+		errors.New("unsupported language"),
+		"Unsupported language",
+		"unsupported language",
+		"UNSUPPORTED_LANGUAGE",
+	)
+)
+
+// GradeRequest is one translation to grade, with the languages taken from the content
+// being translated and the language the learner wants feedback in
+type GradeRequest struct {
+	OriginalText     string
+	UserTranslation  string
+	SourceLanguage   string
+	TargetLanguage   string
+	FeedbackLanguage string
+	// References are the content's accepted translations, if any
+	References []string
+	// MachineTranslation is the content's machine reference. It is shown to the model as context
+	// when there are no references, but a submission matching it is not accepted as correct.
+	MachineTranslation string
+}
+
+// NewGradeRequest normalizes the languages and rejects pairs that are not enabled.
+// An empty feedback language falls back to the source language, the learner's native one.
+func (p LanguagePairs) NewGradeRequest(originalText, userTranslation, sourceLanguage, targetLanguage, feedbackLanguage string) (*GradeRequest, error) {
+	source := NormalizeLanguage(sourceLanguage)
+	target := NormalizeLanguage(targetLanguage)
+	if !p.Supports(source, target) {
+		return nil, ErrUnsupportedLanguagePair
+	}
+
+	feedback := NormalizeLanguage(feedbackLanguage)
+	if feedback == "" {
+		feedback = source
+	}
+
+	return &GradeRequest{
+		OriginalText:     originalText,
+		UserTranslation:  userTranslation,
+		SourceLanguage:   source,
+		TargetLanguage:   target,
+		FeedbackLanguage: feedback,
+	}, nil
+}
+
+// NormalizeLanguage turns "EN", "en-US", "en_GB" or "English" into "en".
+// It returns "" for languages the grader does not know.
+func NormalizeLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_"); i > 0 {
+		lang = lang[:i]
+	}
+	if _, ok := languageNames[lang]; ok {
+		return lang
+	}
+	for code, name := range languageNames {
+		if strings.ToLower(name) == lang {
+			return code
+		}
+	}
+	return ""
+}
+
+// LanguageName returns the English name of a normalized code, or the code itself
+func LanguageName(code string) string {
+	if name, ok := languageNames[code]; ok {
+		return name
+	}
+	return code
+}
+
+// LanguagePairs are the enabled [source, target] grading pairs, read with SupportedLanguagePairs
+// when a biz is built
+type LanguagePairs [][2]string
+
+// Supports reports whether source→target grading is enabled. Both codes must already be normalized.
+func (p LanguagePairs) Supports(source, target string) bool {
+	if source == "" || target == "" || source == target {
+		return false
+	}
+	for _, pair := range p {
+		if pair[0] == source && pair[1] == target {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportedLanguagePairs parses SUPPORTED_LANGUAGE_PAIRS (comma separated, e.g. "vi-en,en-vi")
+// into [source, target] codes
+func SupportedLanguagePairs() LanguagePairs {
+	raw := os.Getenv("SUPPORTED_LANGUAGE_PAIRS")
+	if raw == "" {
+		raw = defaultLanguagePairs
+	}
+
+	var pairs LanguagePairs
+	for _, item := range strings.Split(raw, ",") {
+		parts := strings.SplitN(strings.TrimSpace(item), "-", 2)
+		if len(parts) != 2 {
+			log.Printf("Invalid SUPPORTED_LANGUAGE_PAIRS entry %q", item)
+			continue
+		}
+		source, target := NormalizeLanguage(parts[0]), NormalizeLanguage(parts[1])
+		if source == "" || target == "" {
+			log.Printf("Unknown language in SUPPORTED_LANGUAGE_PAIRS entry %q", item)
+			continue
+		}
+		pairs = append(pairs, [2]string{source, target})
+	}
+	return pairs
+}
+
+// IsSupportedFeedbackLanguage reports whether feedback can be written in lang
+func IsSupportedFeedbackLanguage(lang string) bool {
+	return NormalizeLanguage(lang) != ""
+}
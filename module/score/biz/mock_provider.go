@@ -0,0 +1,361 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+
+	"hub-service/infrastructure/external/llm"
+)
+
+// MockFixture is a scripted grading result returned for a matching submission.
+// An empty OriginalText matches any source text.
+type MockFixture struct {
+	OriginalText    string          `json:"original_text"`
+	UserTranslation string          `json:"user_translation"`
+	Analysis        GrammarAnalysis `json:"analysis"`
+}
+
+var (
+	mockFixtures     []MockFixture
+	mockFixturesOnce sync.Once
+)
+
+// loadMockFixtures reads the optional AI_MOCK_FIXTURES JSON file once per process
+func loadMockFixtures() []MockFixture {
+	mockFixturesOnce.Do(func() {
+		path := os.Getenv("AI_MOCK_FIXTURES")
+		if path == "" {
+			return
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Printf("Warning: Failed to read mock AI fixtures %s: %v", path, err)
+			return
+		}
+
+		if err := json.Unmarshal(data, &mockFixtures); err != nil {
+			log.Printf("Warning: Failed to parse mock AI fixtures %s: %v", path, err)
+			return
+		}
+
+		log.Printf("Mock AI fixtures loaded: %d fixtures", len(mockFixtures))
+	})
+	return mockFixtures
+}
+
+// MockProvider grades translations offline with simple, reproducible heuristics.
+// It is enabled with AI_PROVIDER=mock and never calls an external service.
+type MockProvider struct {
+	fixtures []MockFixture
+}
+
+// NewMockProvider creates a mock provider with the given fixtures taking precedence over heuristics
+func NewMockProvider(fixtures []MockFixture) *MockProvider {
+	return &MockProvider{fixtures: fixtures}
+}
+
+func (p *MockProvider) Name() string {
+	return llm.ProviderMock
+}
+
+// PromptVersion is always the builtin version; the mock never renders a prompt
+func (p *MockProvider) PromptVersion(ctx context.Context) string {
+	return BuiltinPromptVersion
+}
+
+func (p *MockProvider) AnalyzeGrammar(ctx context.Context, req *GradeRequest) (*GrammarAnalysis, error) {
+	var analysis GrammarAnalysis
+	if fixture := p.findFixture(req.OriginalText, req.UserTranslation); fixture != nil {
+		analysis = fixture.Analysis
+		// Copy so normalization never edits the shared fixture
+		analysis.Errors = append([]Error(nil), fixture.Analysis.Errors...)
+	} else {
+		analysis = *gradeHeuristically(req.OriginalText, req.UserTranslation, req.SourceLanguage, req.TargetLanguage, req.FeedbackLanguage)
+	}
+
+	// Fixtures go through the same validation as real model output
+	normalizeAnalysis(&analysis, req.UserTranslation)
+	analysis.Provider = llm.ProviderMock
+	analysis.PromptVersion = BuiltinPromptVersion
+	return &analysis, nil
+}
+
+func (p *MockProvider) findFixture(originalText, userTranslation string) *MockFixture {
+	original := normalizeForMock(originalText)
+	translation := normalizeForMock(userTranslation)
+
+	for i := range p.fixtures {
+		fixture := &p.fixtures[i]
+		if normalizeForMock(fixture.UserTranslation) != translation {
+			continue
+		}
+		if fixture.OriginalText == "" || normalizeForMock(fixture.OriginalText) == original {
+			return fixture
+		}
+	}
+	return nil
+}
+
+// gradeHeuristically scores a translation by comparing its shape with the source text and writes
+// the feedback in feedbackLanguage
+func gradeHeuristically(originalText, userTranslation, sourceLanguage, targetLanguage, feedbackLanguage string) *GrammarAnalysis {
+	text := mockTextFor(feedbackLanguage)
+	translation := strings.TrimSpace(userTranslation)
+	original := strings.TrimSpace(originalText)
+
+	if translation == "" {
+		return &GrammarAnalysis{
+			Score: 0,
+			Errors: []Error{{
+				Type:        "vocabulary",
+				Description: text.empty,
+				Position:    0,
+				Correction:  "",
+			}},
+			Suggestions: []string{text.emptySuggestion},
+			Feedback:    text.emptyFeedback,
+		}
+	}
+
+	if normalizeForMock(translation) == normalizeForMock(original) {
+		return &GrammarAnalysis{
+			Score: 10,
+			Errors: []Error{{
+				Type:        "vocabulary",
+				Description: text.copied,
+				Position:    0,
+				Correction:  "",
+			}},
+			Suggestions: []string{text.copiedSuggestion},
+			Feedback:    text.copiedFeedback,
+		}
+	}
+
+	var errs []Error
+	score := 100.0
+
+	// Capitalization of the first letter
+	first := []rune(translation)[0]
+	if unicode.IsLetter(first) && !unicode.IsUpper(first) {
+		score -= 5
+		errs = append(errs, Error{
+			Type:        "grammar",
+			Description: text.capitalize,
+			Position:    0,
+			Correction:  string(unicode.ToUpper(first)) + string([]rune(translation)[1:]),
+		})
+	}
+
+	// Ending punctuation mirrors the source sentence
+	if endsWithSentencePunct(original) && !endsWithSentencePunct(translation) {
+		score -= 5
+		errs = append(errs, Error{
+			Type:        "syntax",
+			Description: text.punctuation,
+			Position:    len([]rune(translation)),
+			Correction:  translation + string([]rune(original)[len([]rune(original))-1]),
+		})
+	}
+
+	// Words left untranslated from a Vietnamese source (still containing Vietnamese letters)
+	offset := 0
+	checkDiacritics := isVietnamese(sourceLanguage) && !isVietnamese(targetLanguage)
+	for _, word := range strings.Fields(translation) {
+		pos := strings.Index(translation[offset:], word) + offset
+		offset = pos + len(word)
+		if checkDiacritics && hasVietnameseLetters(word) {
+			score -= 15
+			errs = append(errs, Error{
+				Type:        "vocabulary",
+				Description: fmt.Sprintf(text.untranslatedWord, word),
+				Position:    len([]rune(translation[:pos])),
+				Correction:  "",
+			})
+		}
+	}
+
+	// Immediately repeated words
+	words := strings.Fields(strings.ToLower(translation))
+	for i := 1; i < len(words); i++ {
+		if words[i] == words[i-1] {
+			score -= 5
+			errs = append(errs, Error{
+				Type:        "grammar",
+				Description: fmt.Sprintf(text.repeatedWord, words[i]),
+				Position:    0,
+				Correction:  "",
+			})
+		}
+	}
+
+	// Length ratio between source syllables and translated words
+	sourceWords := len(strings.Fields(original))
+	if sourceWords > 0 {
+		ratio := float64(len(words)) / float64(sourceWords)
+		if ratio < 0.4 {
+			score -= 30
+			errs = append(errs, Error{
+				Type:        "vocabulary",
+				Description: text.tooShort,
+				Position:    0,
+				Correction:  "",
+			})
+		} else if ratio > 1.8 {
+			score -= 15
+			errs = append(errs, Error{
+				Type:        "syntax",
+				Description: text.tooLong,
+				Position:    0,
+				Correction:  "",
+			})
+		}
+	}
+
+	if score < 0 {
+		score = 0
+	}
+
+	return &GrammarAnalysis{
+		Score:       score,
+		Errors:      errs,
+		Suggestions: mockSuggestions(text, errs),
+		Feedback:    mockFeedback(text, score),
+	}
+}
+
+func mockSuggestions(text *mockText, errs []Error) []string {
+	seen := map[string]bool{}
+	var suggestions []string
+	for _, e := range errs {
+		if seen[e.Type] {
+			continue
+		}
+		seen[e.Type] = true
+		if suggestion, ok := text.suggestions[e.Type]; ok {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+	return suggestions
+}
+
+func mockFeedback(text *mockText, score float64) string {
+	switch {
+	case score >= 90:
+		return text.excellent
+	case score >= 70:
+		return text.good
+	case score >= 40:
+		return text.fair
+	default:
+		return text.poor
+	}
+}
+
+// mockText holds the mock grader's messages in one feedback language
+type mockText struct {
+	empty, emptySuggestion, emptyFeedback    string
+	copied, copiedSuggestion, copiedFeedback string
+	capitalize, punctuation                  string
+	// untranslatedWord and repeatedWord take the word as their only argument
+	untranslatedWord, repeatedWord string
+	tooShort, tooLong              string
+	suggestions                    map[string]string
+	excellent, good, fair, poor    string
+}
+
+var mockTexts = map[string]*mockText{
+	"vi": {
+		empty:            "Bản dịch đang để trống.",
+		emptySuggestion:  "Hãy thử dịch từng cụm từ trước rồi ghép lại thành câu.",
+		emptyFeedback:    "Bạn chưa nhập bản dịch.",
+		copied:           "Bản dịch giống hệt câu gốc, câu chưa được dịch.",
+		copiedSuggestion: "Hãy dịch câu sang ngôn ngữ đích thay vì chép lại câu gốc.",
+		copiedFeedback:   "Có vẻ bạn chưa dịch câu này.",
+		capitalize:       "Câu cần bắt đầu bằng chữ in hoa.",
+		punctuation:      "Câu thiếu dấu câu ở cuối.",
+		untranslatedWord: "Từ \"%s\" chưa được dịch.",
+		repeatedWord:     "Từ \"%s\" bị lặp lại.",
+		tooShort:         "Bản dịch quá ngắn so với câu gốc, có thể bị thiếu ý.",
+		tooLong:          "Bản dịch dài hơn nhiều so với câu gốc, có thể thừa ý.",
+		suggestions: map[string]string{
+			"grammar":    "Ôn lại quy tắc viết hoa và cách dùng từ trong câu.",
+			"syntax":     "Chú ý cấu trúc và dấu câu của câu hoàn chỉnh.",
+			"vocabulary": "Tra từ điển để chắc chắn mọi từ đều được dịch đúng nghĩa.",
+		},
+		excellent: "Tuyệt vời! Bản dịch rất tốt.",
+		good:      "Khá tốt, chỉ còn vài lỗi nhỏ.",
+		fair:      "Tạm ổn, bạn cần chú ý thêm một số lỗi.",
+		poor:      "Bản dịch còn nhiều lỗi, hãy thử lại nhé.",
+	},
+	"en": {
+		empty:            "The translation is empty.",
+		emptySuggestion:  "Try translating one phrase at a time, then join them into a sentence.",
+		emptyFeedback:    "You have not entered a translation yet.",
+		copied:           "The translation is identical to the original, so the sentence was not translated.",
+		copiedSuggestion: "Translate the sentence into the target language instead of copying the original.",
+		copiedFeedback:   "It looks like you have not translated this sentence.",
+		capitalize:       "The sentence should start with a capital letter.",
+		punctuation:      "The sentence is missing its final punctuation.",
+		untranslatedWord: "The word \"%s\" was not translated.",
+		repeatedWord:     "The word \"%s\" is repeated.",
+		tooShort:         "The translation is much shorter than the original and may leave out part of its meaning.",
+		tooLong:          "The translation is much longer than the original and may add meaning.",
+		suggestions: map[string]string{
+			"grammar":    "Review the capitalization rules and how the words are used in the sentence.",
+			"syntax":     "Pay attention to the structure and punctuation of a complete sentence.",
+			"vocabulary": "Check a dictionary to make sure every word is translated with the right meaning.",
+		},
+		excellent: "Excellent! This is a very good translation.",
+		good:      "Quite good, with only a few small mistakes.",
+		fair:      "Acceptable, but a few mistakes need attention.",
+		poor:      "The translation still has many mistakes, give it another try.",
+	},
+}
+
+// mockTextFor picks the messages for the feedback language, falling back to English
+func mockTextFor(feedbackLanguage string) *mockText {
+	if isVietnamese(feedbackLanguage) {
+		return mockTexts["vi"]
+	}
+	return mockTexts["en"]
+}
+
+func normalizeForMock(s string) string {
+	s = strings.ToLower(s)
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) {
+			return -1
+		}
+		return r
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func endsWithSentencePunct(s string) bool {
+	return strings.HasSuffix(s, ".") || strings.HasSuffix(s, "!") || strings.HasSuffix(s, "?")
+}
+
+// vietnameseLetters are the accented letters only Vietnamese uses, so accents in French or German
+// answers such as "é", "ü" or "ê" are not mistaken for untranslated words
+const vietnameseLetters = "ăằắẳẵặầấẩẫậđềếểễệồốổỗộơờớởỡợưừứửữựảạẻẽẹỉĩịỏọủũụỳỷỹỵ"
+
+func isVietnamese(language string) bool {
+	return strings.HasPrefix(strings.ToLower(language), "vi")
+}
+
+// hasVietnameseLetters reports whether a word contains letters that only appear in Vietnamese
+func hasVietnameseLetters(word string) bool {
+	for _, r := range word {
+		if strings.ContainsRune(vietnameseLetters, unicode.ToLower(r)) {
+			return true
+		}
+	}
+	return false
+}
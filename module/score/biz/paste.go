@@ -0,0 +1,34 @@
+package biz
+
+import (
+	"context"
+
+	scoremodel "hub-service/module/score/model"
+)
+
+// PasteCheck is one graded attempt to compare against machine translation
+type PasteCheck struct {
+	Content         string
+	UserTranslation string
+	SourceLang      string
+	TargetLang      string
+	// MachineTranslation is the stored machine reference, "" when the content has none
+	MachineTranslation string
+	Telemetry          *scoremodel.Telemetry
+	AttemptNumber      int
+}
+
+// PasteDetector flags attempts that look like pasted machine translation and returns nil for
+// attempts that look typed. It never fails a submission: a learner is not refused a grade because
+// the machine translation service is down.
+type PasteDetector interface {
+	CheckPaste(ctx context.Context, check *PasteCheck) *scoremodel.PasteFlag
+}
+
+// DetectPaste runs the detector; a nil PasteDetector flags nothing
+func DetectPaste(ctx context.Context, detector PasteDetector, check *PasteCheck) *scoremodel.PasteFlag {
+	if detector == nil {
+		return nil
+	}
+	return detector.CheckPaste(ctx, check)
+}
@@ -1,13 +1,17 @@
 package biz
 
+// BuiltinPromptVersion identifies GeminiGrammarPrompt in score and attempt records
+const BuiltinPromptVersion = "builtin-v3"
+
+// GeminiGrammarPrompt is the fallback grading prompt used while no prompt is active in the registry
 var GeminiGrammarPrompt = `
-    You are an English teacher assisting Vietnamese learners.
+    You are a {{target_language}} teacher assisting {{source_language}}-speaking learners.
 
-    Your task is to evaluate the student's English translation of a Vietnamese sentence and return structured feedback in JSON format. The response must be suitable for educational apps that teach English to Vietnamese users.
+    Your task is to evaluate the student's {{target_language}} translation of a {{source_language}} sentence and return structured feedback in JSON format. The response must be suitable for educational apps that teach {{target_language}} to {{source_language}} speakers.
 
-    Original Vietnamese sentence: "%s"
-    Student's English translation: "%s"
-    Target language: %s
+    Original {{source_language}} sentence: "{{original_text}}"
+    Student's {{target_language}} translation: "{{user_translation}}"
+    Reference translation (one acceptable answer, other correct translations are equally valid): {{reference_translation}}
 
     Return a JSON object with the following fields:
 
@@ -16,20 +20,33 @@ var GeminiGrammarPrompt = `
         "errors": [
             {
                 "type": "grammar | syntax | vocabulary",
-                "description": "Simple explanation in Vietnamese to help learners understand the mistake",
+                "description": "Simple explanation in {{feedback_language}} to help learners understand the mistake",
                 "position": character index of the mistake (or 0 if unknown),
-                "correction": "Suggested correction in English"
+                "correction": "Suggested correction in {{target_language}}"
             }
         ],
         "suggestions": [
-            "Learning tips or revision advice in Vietnamese"
+            "Learning tips or revision advice in {{feedback_language}}"
         ],
-        "feedback": "Write a short comment in Vietnamese to share how you feel - whether it's great, okay, not so good, or anything else you'd like to say."
+        "feedback": "Write a short comment in {{feedback_language}} to share how you feel - whether it's great, okay, not so good, or anything else you'd like to say."
     }
 
     Requirements:
-    - Use Vietnamese for 'description', 'suggestions', and 'feedback'.
+    - Use {{feedback_language}} for 'description', 'suggestions', and 'feedback'.
     - Be slightly generous in scoring. Give 100 points if the student's translation is fully correct or only has very minor, acceptable differences (e.g., “Hi” vs “Hello”).
     - Only deduct points for actual mistakes that affect grammar, meaning, or clarity.
     - Do NOT return any markdown, explanation, or extra text. Only respond with the raw JSON object.
 `
+
+// RepairPrompt asks the model to fix a reply that failed validation.
+// Arguments: the original prompt, the invalid reply, the validation problem.
+var RepairPrompt = `
+    %s
+
+    Your previous reply could not be used:
+    %s
+
+    Problem: %s
+
+    Reply again with ONLY the raw JSON object described above. "score" must be a number from 0 to 100, "errors" an array of objects whose "type" is one of grammar, syntax or vocabulary, "suggestions" an array of strings and "feedback" a string.
+`
@@ -0,0 +1,136 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+)
+
+// GradingPromptName is the registry name of the prompt used for translation grading
+const GradingPromptName = "grammar"
+
+// Variables available to grading prompt templates as {{name}}
+const (
+	PromptVarOriginalText     = "original_text"
+	PromptVarUserTranslation  = "user_translation"
+	PromptVarSourceLanguage   = "source_language"
+	PromptVarTargetLanguage   = "target_language"
+	PromptVarFeedbackLanguage = "feedback_language"
+	PromptVarReference        = "reference_translation"
+)
+
+// PromptVariables lists every variable a grading template may reference
+var PromptVariables = []string{
+	PromptVarOriginalText,
+	PromptVarUserTranslation,
+	PromptVarSourceLanguage,
+	PromptVarTargetLanguage,
+	PromptVarFeedbackLanguage,
+	PromptVarReference,
+}
+
+// RequiredPromptVariables must appear in every grading template
+var RequiredPromptVariables = []string{
+	PromptVarOriginalText,
+	PromptVarUserTranslation,
+}
+
+var promptVarPattern = regexp.MustCompile(`\{\{\s*([a-z_]+)\s*\}\}`)
+
+// PromptTemplate is a grading prompt with its version label, e.g. "grammar-v3"
+type PromptTemplate struct {
+	Version string
+	Text    string
+}
+
+// PromptSource resolves the active grading prompt; it is implemented by the prompt registry
+type PromptSource interface {
+	ActivePrompt(ctx context.Context, name string) (*PromptTemplate, error)
+}
+
+// BuiltinPrompt is the compiled-in grading prompt
+func BuiltinPrompt() *PromptTemplate {
+	return &PromptTemplate{Version: BuiltinPromptVersion, Text: GeminiGrammarPrompt}
+}
+
+// Render substitutes {{name}} placeholders; unknown placeholders are left untouched
+func (t *PromptTemplate) Render(vars map[string]string) string {
+	return promptVarPattern.ReplaceAllStringFunc(t.Text, func(match string) string {
+		name := promptVarPattern.FindStringSubmatch(match)[1]
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// ValidatePromptTemplate checks that a template only uses known variables and includes the required ones.
+// It returns the variables referenced by the template.
+func ValidatePromptTemplate(text string) ([]string, error) {
+	known := make(map[string]bool, len(PromptVariables))
+	for _, v := range PromptVariables {
+		known[v] = true
+	}
+
+	used := []string{}
+	seen := map[string]bool{}
+	for _, m := range promptVarPattern.FindAllStringSubmatch(text, -1) {
+		name := m[1]
+		if !known[name] {
+			return nil, fmt.Errorf("unknown variable {{%s}}, allowed: %s", name, strings.Join(PromptVariables, ", "))
+		}
+		if !seen[name] {
+			seen[name] = true
+			used = append(used, name)
+		}
+	}
+
+	for _, required := range RequiredPromptVariables {
+		if !seen[required] {
+			return nil, fmt.Errorf("template must use {{%s}}", required)
+		}
+	}
+
+	return used, nil
+}
+
+// noReference is rendered for {{reference_translation}} when the content has none
+const noReference = "(none provided)"
+
+// promptVariables are the values substituted into a grading template; languages are given by name
+func promptVariables(req *GradeRequest) map[string]string {
+	reference := noReference
+	switch {
+	case len(req.References) > 0:
+		reference = req.References[0]
+	case req.MachineTranslation != "":
+		reference = req.MachineTranslation
+	}
+
+	return map[string]string{
+		PromptVarOriginalText:     req.OriginalText,
+		PromptVarUserTranslation:  req.UserTranslation,
+		PromptVarSourceLanguage:   LanguageName(req.SourceLanguage),
+		PromptVarTargetLanguage:   LanguageName(req.TargetLanguage),
+		PromptVarFeedbackLanguage: LanguageName(req.FeedbackLanguage),
+		PromptVarReference:        reference,
+	}
+}
+
+// resolvePrompt returns the active registry prompt, falling back to the builtin one
+func resolvePrompt(ctx context.Context, source PromptSource) *PromptTemplate {
+	if source == nil {
+		return BuiltinPrompt()
+	}
+	tmpl, err := source.ActivePrompt(ctx, GradingPromptName)
+	if err != nil {
+		log.Printf("Prompt registry error, using builtin prompt: %v", err)
+		return BuiltinPrompt()
+	}
+	if tmpl == nil {
+		return BuiltinPrompt()
+	}
+	return tmpl
+}
@@ -0,0 +1,208 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"hub-service/common"
+	"hub-service/infrastructure/external/llm"
+)
+
+// ErrProviderNotConfigured is returned when AI_PROVIDER points at a backend without credentials
+var ErrProviderNotConfigured = common.NewErrorResponse(
+	errors.New("ai provider not configured"),
+	"AI grading provider not configured",
+	"AI_PROVIDER_CONFIG_ERROR",
+	"CONFIG_ERROR",
+)
+
+// ErrProviderUnavailable is returned while the provider's circuit breaker is open
+var ErrProviderUnavailable = common.NewFullErrorResponse(
+	http.StatusServiceUnavailable,
+	llm.ErrCircuitOpen,
+	"AI grading is temporarily unavailable, please try again later",
+	"AI_PROVIDER_UNAVAILABLE",
+	"ErrProviderUnavailable",
+)
+
+// ErrProviderOverloaded is returned when too many grading calls are already in flight
+var ErrProviderOverloaded = common.NewFullErrorResponse(
+	http.StatusServiceUnavailable,
+	llm.ErrOverloaded,
+	"AI grading is busy, please try again in a moment",
+	"AI_PROVIDER_OVERLOADED",
+	"ErrProviderOverloaded",
+)
+
+// ErrSameProvider is returned when a second opinion is asked of the provider that graded the first time
+var ErrSameProvider = common.NewErrorResponse(
+	errors.New("same grading provider"),
+	"Re-grading needs a different AI provider than the one configured for grading",
+	"same grading provider",
+	"ErrSameProvider",
+)
+
+// ErrMockSecondOpinion is returned when a second opinion is asked of the mock provider, whose
+// heuristic grade must never replace a learner's score
+var ErrMockSecondOpinion = common.NewErrorResponse(
+	errors.New("mock grading provider"),
+	"Re-grading needs a real AI provider, not the mock",
+	"mock grading provider",
+	"ErrMockSecondOpinion",
+)
+
+// GradingProvider is a GeminiAnalyzer with a name, so callers can tell which backend graded a translation.
+// Every grading flow (challenges, passage sentences, demo) goes through this interface.
+type GradingProvider interface {
+	GeminiAnalyzer
+	Name() string
+	// PromptVersion is the version the next AnalyzeGrammar call will be graded with
+	PromptVersion(ctx context.Context) string
+}
+
+// llmGrader grades translations by prompting any llm.Client and parsing its JSON reply
+type llmGrader struct {
+	client  llm.Client
+	prompts PromptSource
+	fixed   *PromptTemplate
+}
+
+// NewGradingProvider wraps the configured llm.Client in a GradingProvider that grades with
+// the active prompt from prompts (the builtin prompt when prompts is nil or has none active).
+// With AI_PROVIDER=mock it returns the offline MockProvider and ignores the client.
+func NewGradingProvider(client llm.Client, prompts PromptSource) (GradingProvider, error) {
+	if llm.ProviderName() == llm.ProviderMock {
+		return NewMockProvider(loadMockFixtures()), nil
+	}
+	if client == nil {
+		return nil, ErrProviderNotConfigured
+	}
+	return &llmGrader{client: client, prompts: prompts}, nil
+}
+
+// NewSecondOpinionProvider grades with the named provider instead of AI_PROVIDER, e.g. to re-grade a
+// disputed translation with another model. The configured provider and the mock are refused.
+func NewSecondOpinionProvider(provider string, prompts PromptSource) (GradingProvider, error) {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	if provider == llm.ProviderMock {
+		return nil, ErrMockSecondOpinion
+	}
+	if provider == llm.ProviderName() {
+		return nil, ErrSameProvider
+	}
+
+	client := llm.NewClientFor(provider)
+	if client == nil {
+		return nil, ErrProviderNotConfigured
+	}
+	return &llmGrader{client: client, prompts: prompts}, nil
+}
+
+// NewPromptedGrader grades with a fixed template regardless of the registry, e.g. to try a draft prompt.
+// Like NewGradingProvider it returns the MockProvider with AI_PROVIDER=mock, which ignores the template.
+func NewPromptedGrader(client llm.Client, tmpl *PromptTemplate) (GradingProvider, error) {
+	if llm.ProviderName() == llm.ProviderMock {
+		return NewMockProvider(loadMockFixtures()), nil
+	}
+	if client == nil {
+		return nil, ErrProviderNotConfigured
+	}
+	return &llmGrader{client: client, fixed: tmpl}, nil
+}
+
+func (g *llmGrader) Name() string {
+	return g.client.Name()
+}
+
+func (g *llmGrader) PromptVersion(ctx context.Context) string {
+	return g.template(ctx).Version
+}
+
+func (g *llmGrader) template(ctx context.Context) *PromptTemplate {
+	if g.fixed != nil {
+		return g.fixed
+	}
+	return resolvePrompt(ctx, g.prompts)
+}
+
+func (g *llmGrader) AnalyzeGrammar(ctx context.Context, req *GradeRequest) (*GrammarAnalysis, error) {
+	tmpl := g.template(ctx)
+	promptText := tmpl.Render(promptVariables(req))
+
+	reply, err := g.generate(ctx, promptText)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis, parseErr := parseAnalysis(reply, req.UserTranslation)
+	if parseErr != nil {
+		// One repair round: show the model its reply and what was wrong with it
+		log.Printf("%s invalid analysis, asking for repair: %v", g.client.Name(), parseErr)
+
+		reply, err = g.generate(ctx, fmt.Sprintf(RepairPrompt, promptText, reply, parseErr.Error()))
+		if err != nil {
+			return nil, err
+		}
+
+		analysis, parseErr = parseAnalysis(reply, req.UserTranslation)
+		if parseErr != nil {
+			log.Printf("%s JSON parse error after repair: %v", g.client.Name(), parseErr)
+			return nil, ErrAIResponseUnparsable
+		}
+	}
+
+	analysis.Provider = g.client.Name()
+	analysis.PromptVersion = tmpl.Version
+
+	return analysis, nil
+}
+
+func (g *llmGrader) generate(ctx context.Context, prompt string) (string, error) {
+	return generateText(ctx, g.client, prompt)
+}
+
+// generateText calls the client and maps transport-level failures to AppErrors
+func generateText(ctx context.Context, client llm.Client, prompt string) (string, error) {
+	resp, err := client.Generate(ctx, prompt)
+	if err != nil {
+		log.Printf("%s error: %v", client.Name(), err)
+		switch {
+		case errors.Is(err, llm.ErrCircuitOpen):
+			return "", ErrProviderUnavailable
+		case errors.Is(err, llm.ErrOverloaded):
+			return "", ErrProviderOverloaded
+		case errors.Is(err, llm.ErrBlocked):
+			return "", ErrAIResponseBlocked
+		case errors.Is(err, llm.ErrEmptyResponse):
+			return "", ErrAIResponseEmpty
+		}
+		return "", err
+	}
+
+	if strings.TrimSpace(resp.Text) == "" {
+		return "", ErrAIResponseEmpty
+	}
+	return resp.Text, nil
+}
+
+// stripCodeFence removes a surrounding markdown code fence from a model reply
+func stripCodeFence(text string) string {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "```json") {
+		text = strings.TrimPrefix(text, "```json")
+		text = strings.TrimSpace(text)
+	}
+	if strings.HasPrefix(text, "```") {
+		text = strings.TrimPrefix(text, "```")
+		text = strings.TrimSpace(text)
+	}
+	if strings.HasSuffix(text, "```") {
+		text = strings.TrimSuffix(text, "```")
+		text = strings.TrimSpace(text)
+	}
+	return text
+}
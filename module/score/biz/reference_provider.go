@@ -0,0 +1,112 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"hub-service/common"
+	"hub-service/utils/similarity"
+)
+
+// Provider names recorded on analyses that did not come from the LLM
+const (
+	ProviderReferenceMatch = "reference"
+	ProviderSimilarity     = "similarity"
+)
+
+// referenceFeedback is the canned feedback per feedback language; English is the fallback
+var referenceFeedback = map[string]struct{ exact, fallback string }{
+	"vi": {
+		exact:    "Chính xác! Bản dịch của bạn khớp với bản dịch tham khảo.",
+		fallback: "Hệ thống chấm bằng AI đang tạm thời không khả dụng. Điểm này được ước tính dựa trên mức độ giống với bản dịch tham khảo.",
+	},
+	"en": {
+		exact:    "Correct! Your translation matches the reference translation.",
+		fallback: "AI grading is temporarily unavailable. This score is estimated from how closely your translation matches the reference.",
+	},
+}
+
+// referenceProvider grades against the content's reference translations before and after the LLM:
+// normalized exact matches never reach the LLM, and when the LLM fails the similarity score is used instead
+type referenceProvider struct {
+	next GradingProvider
+}
+
+// NewReferenceProvider puts reference-translation matching in front of next
+func NewReferenceProvider(next GradingProvider) GradingProvider {
+	return &referenceProvider{next: next}
+}
+
+func (p *referenceProvider) Name() string {
+	return p.next.Name()
+}
+
+func (p *referenceProvider) PromptVersion(ctx context.Context) string {
+	return p.next.PromptVersion(ctx)
+}
+
+func (p *referenceProvider) AnalyzeGrammar(ctx context.Context, req *GradeRequest) (*GrammarAnalysis, error) {
+	match := similarity.Compare(req.UserTranslation, req.References)
+	if match == nil {
+		return p.next.AnalyzeGrammar(ctx, req)
+	}
+
+	if match.ExactMatch {
+		return referenceAnalysis(req, match, ProviderReferenceMatch), nil
+	}
+
+	analysis, err := p.next.AnalyzeGrammar(ctx, req)
+	if err == nil {
+		analysis.Similarity = match
+		return analysis, nil
+	}
+
+	if !providerFailed(ctx, err) {
+		return nil, err
+	}
+
+	log.Printf("%s grading failed, using reference similarity: %v", p.next.Name(), err)
+	return referenceAnalysis(req, match, ProviderSimilarity), nil
+}
+
+// providerFailed reports whether err means the provider could not grade, as opposed to
+// refusing the content or the caller giving up
+func providerFailed(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var appErr *common.AppError
+	if !errors.As(err, &appErr) {
+		// Network and upstream errors that survived the client's retries
+		return true
+	}
+
+	switch appErr {
+	case ErrProviderUnavailable, ErrProviderOverloaded, ErrAIResponseEmpty, ErrAIResponseUnparsable:
+		return true
+	}
+	return false
+}
+
+func referenceAnalysis(req *GradeRequest, match *similarity.Result, provider string) *GrammarAnalysis {
+	messages, ok := referenceFeedback[req.FeedbackLanguage]
+	if !ok {
+		messages = referenceFeedback["en"]
+	}
+
+	feedback := messages.fallback
+	if match.ExactMatch {
+		feedback = messages.exact
+	}
+
+	return &GrammarAnalysis{
+		Score:       match.Score,
+		Errors:      []Error{},
+		Suggestions: []string{},
+		Feedback:    feedback,
+		Provider:    provider,
+		Similarity:  match,
+	}
+}
@@ -4,16 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
-	"io"
-	"net/http"
-	"strings"
 	"time"
 
 	challengemodel "hub-service/module/challenge/model"
 	challengestorage "hub-service/module/challenge/storage"
 	scoremodel "hub-service/module/score/model"
 	scorestorage "hub-service/module/score/storage"
+	usagebiz "hub-service/module/usage/biz"
+	usagemodel "hub-service/module/usage/model"
+	"hub-service/utils/similarity"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -23,33 +22,7 @@ var (
 )
 
 type GeminiAnalyzer interface {
-	AnalyzeGrammar(ctx context.Context, originalText, userTranslation, targetLanguage string) (*GrammarAnalysis, error)
-}
-
-type GeminiBiz struct {
-	apiKey  string
-	client  *http.Client
-	baseURL string
-}
-
-type GeminiRequest struct {
-	Contents []Content `json:"contents"`
-}
-
-type Content struct {
-	Parts []Part `json:"parts"`
-}
-
-type Part struct {
-	Text string `json:"text"`
-}
-
-type GeminiResponse struct {
-	Candidates []Candidate `json:"candidates"`
-}
-
-type Candidate struct {
-	Content Content `json:"content"`
+	AnalyzeGrammar(ctx context.Context, req *GradeRequest) (*GrammarAnalysis, error)
 }
 
 type GrammarAnalysis struct {
@@ -57,6 +30,11 @@ type GrammarAnalysis struct {
 	Errors      []Error  `json:"errors"`
 	Suggestions []string `json:"suggestions"`
 	Feedback    string   `json:"feedback"`
+
+	// Set by the grader, not by the model
+	Provider      string             `json:"provider,omitempty"`
+	PromptVersion string             `json:"prompt_version,omitempty"`
+	Similarity    *similarity.Result `json:"similarity,omitempty"`
 }
 
 type Error struct {
@@ -66,85 +44,18 @@ type Error struct {
 	Correction  string `json:"correction"`
 }
 
-func NewGeminiBiz(apiKey string, baseURL string) *GeminiBiz {
-	return &GeminiBiz{
-		apiKey:  apiKey,
-		client:  &http.Client{},
-		baseURL: baseURL,
+// StoredErrors converts the grader's errors to the structured list kept on score records
+func StoredErrors(errs []Error) scoremodel.ErrorList {
+	list := make(scoremodel.ErrorList, 0, len(errs))
+	for _, e := range errs {
+		list = append(list, scoremodel.ScoreError{
+			Type:        e.Type,
+			Description: e.Description,
+			Position:    e.Position,
+			Correction:  e.Correction,
+		})
 	}
-}
-
-func (b *GeminiBiz) AnalyzeGrammar(ctx context.Context, originalText, userTranslation, targetLanguage string) (*GrammarAnalysis, error) {
-	promptText := fmt.Sprintf(GeminiGrammarPrompt, originalText, userTranslation, targetLanguage)
-
-	req := GeminiRequest{
-		Contents: []Content{
-			{
-				Parts: []Part{
-					{Text: promptText},
-				},
-			},
-		},
-	}
-
-	jsonData, err := json.Marshal(req)
-	if err != nil {
-		return nil, errors.New("failed to marshal request")
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", b.baseURL, strings.NewReader(string(jsonData)))
-	if err != nil {
-		return nil, errors.New("failed to create request")
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("x-goog-api-key", b.apiKey)
-
-	resp, err := b.client.Do(httpReq)
-	if err != nil {
-		return nil, errors.New("failed to execute request")
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		fmt.Printf("Gemini error status: %d, body: %s\n", resp.StatusCode, string(body))
-		return nil, fmt.Errorf("status code: %d, body: %s", resp.StatusCode, string(body))
-	}
-
-	var geminiResp GeminiResponse
-	if err := json.NewDecoder(resp.Body).Decode(&geminiResp); err != nil {
-		return nil, errors.New("failed to decode response")
-	}
-
-	if len(geminiResp.Candidates) == 0 || len(geminiResp.Candidates[0].Content.Parts) == 0 {
-		return nil, errors.New("no response from Gemini")
-	}
-
-	responseText := geminiResp.Candidates[0].Content.Parts[0].Text
-	fmt.Printf("Gemini responseText: %s\n", responseText)
-
-	responseText = strings.TrimSpace(responseText)
-	if strings.HasPrefix(responseText, "```json") {
-		responseText = strings.TrimPrefix(responseText, "```json")
-		responseText = strings.TrimSpace(responseText)
-	}
-	if strings.HasPrefix(responseText, "```") {
-		responseText = strings.TrimPrefix(responseText, "```")
-		responseText = strings.TrimSpace(responseText)
-	}
-	if strings.HasSuffix(responseText, "```") {
-		responseText = strings.TrimSuffix(responseText, "```")
-		responseText = strings.TrimSpace(responseText)
-	}
-
-	var analysis GrammarAnalysis
-	if err := json.Unmarshal([]byte(responseText), &analysis); err != nil {
-		fmt.Printf("Gemini JSON parse error: %v\n", err)
-		return nil, errors.New("failed to parse Gemini analysis")
-	}
-
-	return &analysis, nil
+	return list
 }
 
 type ChallengeStore interface {
@@ -155,17 +66,25 @@ type ScoreBiz struct {
 	scoreStorage     *scorestorage.Storage
 	challengeStorage *challengestorage.Storage
 	geminiBiz        GeminiAnalyzer
+	languagePairs    LanguagePairs
+	hints            HintLedger
+	paste            PasteDetector
+	listeners        []SubmissionListener
 }
 
-func NewScoreBiz(scoreStorage *scorestorage.Storage, challengeStorage *challengestorage.Storage, geminiBiz GeminiAnalyzer) *ScoreBiz {
+func NewScoreBiz(scoreStorage *scorestorage.Storage, challengeStorage *challengestorage.Storage, geminiBiz GeminiAnalyzer, languagePairs LanguagePairs, hints HintLedger, paste PasteDetector, listeners ...SubmissionListener) *ScoreBiz {
 	return &ScoreBiz{
 		scoreStorage:     scoreStorage,
 		challengeStorage: challengeStorage,
 		geminiBiz:        geminiBiz,
+		languagePairs:    languagePairs,
+		hints:            hints,
+		paste:            paste,
+		listeners:        listeners,
 	}
 }
 
-func (biz *ScoreBiz) SubmitScore(ctx context.Context, userID primitive.ObjectID, req *scoremodel.SubmitScoreRequest, targetLanguage string) (*scoremodel.SubmitScoreResponse, error) {
+func (biz *ScoreBiz) SubmitScore(ctx context.Context, userID primitive.ObjectID, req *scoremodel.SubmitScoreRequest, feedbackLanguage string) (*scoremodel.SubmitScoreResponse, error) {
 	challengeID, err := primitive.ObjectIDFromHex(req.ChallengeID)
 	if err != nil {
 		return nil, err
@@ -179,7 +98,14 @@ func (biz *ScoreBiz) SubmitScore(ctx context.Context, userID primitive.ObjectID,
 		return nil, ErrChallengeNotFound
 	}
 
-	analysis, err := biz.geminiBiz.AnalyzeGrammar(ctx, challenge.Content, req.UserTranslation, targetLanguage)
+	gradeReq, err := biz.languagePairs.NewGradeRequest(challenge.Content, req.UserTranslation, challenge.SourceLang, challenge.TargetLang, feedbackLanguage)
+	if err != nil {
+		return nil, err
+	}
+	gradeReq.References = challenge.GradingReferences()
+	gradeReq.MachineTranslation = challenge.MachineTranslation
+
+	analysis, err := biz.geminiBiz.AnalyzeGrammar(usagebiz.WithCaller(ctx, usagemodel.FeatureChallenge, userID), gradeReq)
 	if err != nil {
 		return nil, err
 	}
@@ -189,16 +115,35 @@ func (biz *ScoreBiz) SubmitScore(ctx context.Context, userID primitive.ObjectID,
 		return nil, err
 	}
 
+	penalty, err := ApplyHintPenalty(ctx, biz.hints, &HintTarget{
+		Kind:        SubmissionKindChallenge,
+		UserID:      userID,
+		ChallengeID: challengeID,
+	}, analysis)
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
-	var attemptCount int
 	var bestScore float64
+	var previousBest float64
 	isNewBest := false
 
-	errors := ""
-	if len(analysis.Errors) > 0 {
-		b, _ := json.Marshal(analysis.Errors)
-		errors = string(b)
+	attemptCount := 1
+	if existingScore != nil {
+		attemptCount = existingScore.AttemptCount + 1
 	}
+	pasteFlag := DetectPaste(ctx, biz.paste, &PasteCheck{
+		Content:            challenge.Content,
+		UserTranslation:    req.UserTranslation,
+		SourceLang:         challenge.SourceLang,
+		TargetLang:         challenge.TargetLang,
+		MachineTranslation: challenge.MachineTranslation,
+		Telemetry:          req.Telemetry,
+		AttemptNumber:      attemptCount,
+	})
+
+	errors := StoredErrors(analysis.Errors)
 	suggestions := ""
 	if len(analysis.Suggestions) > 0 {
 		b, _ := json.Marshal(analysis.Suggestions)
@@ -206,7 +151,6 @@ func (biz *ScoreBiz) SubmitScore(ctx context.Context, userID primitive.ObjectID,
 	}
 
 	if existingScore == nil {
-		attemptCount = 1
 		bestScore = analysis.Score
 		isNewBest = true
 
@@ -221,17 +165,21 @@ func (biz *ScoreBiz) SubmitScore(ctx context.Context, userID primitive.ObjectID,
 			OriginalContent: challenge.Content,
 			AttemptCount:    attemptCount,
 			BestScore:       bestScore,
+			PromptVersion:   analysis.PromptVersion,
+			PasteFlag:       pasteFlag,
 			CreatedAt:       now,
 			UpdatedAt:       now,
 		}
 		err = biz.scoreStorage.CreateScore(ctx, scoreCreate)
 	} else {
-		attemptCount = existingScore.AttemptCount + 1
 		bestScore = existingScore.BestScore
+		previousBest = existingScore.BestScore
 		if analysis.Score > existingScore.BestScore {
 			bestScore = analysis.Score
 			isNewBest = true
 		}
+		// A fresh AI grade replaces any earlier human review
+		humanReviewed := false
 		scoreUpdate := &scoremodel.ScoreUpdate{
 			UserTranslation: &req.UserTranslation,
 			Score:           &analysis.Score,
@@ -240,15 +188,48 @@ func (biz *ScoreBiz) SubmitScore(ctx context.Context, userID primitive.ObjectID,
 			Suggestions:     &suggestions,
 			AttemptCount:    &attemptCount,
 			BestScore:       &bestScore,
+			PromptVersion:   &analysis.PromptVersion,
+			HumanReviewed:   &humanReviewed,
+			PasteFlag:       pasteFlag,
 			UpdatedAt:       &now,
 		}
 		err = biz.scoreStorage.UpdateScore(ctx, existingScore.ID, scoreUpdate)
 	}
 
 	if err != nil {
+		ReturnHintPenalty(ctx, biz.hints, penalty)
 		return nil, err
 	}
 
+	// An open flag survives a clean resubmission, since the update leaves it in place
+	var previousFlag *scoremodel.PasteFlag
+	if existingScore != nil {
+		previousFlag = existingScore.PasteFlag
+	}
+	currentFlag := pasteFlag
+	if currentFlag == nil {
+		currentFlag = previousFlag
+	}
+
+	NotifySubmission(ctx, biz.listeners, &SubmissionEvent{
+		Kind:              SubmissionKindChallenge,
+		UserID:            userID,
+		ChallengeID:       challengeID,
+		SectionID:         challenge.SectionID,
+		OriginalContent:   challenge.Content,
+		UserTranslation:   req.UserTranslation,
+		Analysis:          analysis,
+		AttemptCount:      attemptCount,
+		PreviousBest:      previousBest,
+		BestScore:         bestScore,
+		IsNewBest:         isNewBest,
+		HintsUsed:         penalty.HintsUsed,
+		HintPenalty:       penalty.Points,
+		PasteFlag:         currentFlag,
+		PreviousPasteFlag: previousFlag,
+		SubmittedAt:       now,
+	})
+
 	return &scoremodel.SubmitScoreResponse{
 		Score:           analysis.Score,
 		UserTranslation: req.UserTranslation,
@@ -259,6 +240,8 @@ func (biz *ScoreBiz) SubmitScore(ctx context.Context, userID primitive.ObjectID,
 		AttemptCount:    attemptCount,
 		BestScore:       bestScore,
 		IsNewBest:       isNewBest,
+		HintsUsed:       penalty.HintsUsed,
+		HintPenalty:     penalty.Points,
 	}, nil
 }
 
@@ -290,6 +273,10 @@ func (biz *ScoreBiz) GetUserScores(ctx context.Context, userID primitive.ObjectI
 			Errors:          score.Errors,
 			Suggestions:     score.Suggestions,
 			OriginalContent: score.OriginalContent,
+			HumanReviewed:   score.HumanReviewed,
+			ReviewedAt:      score.ReviewedAt,
+
+			CountsTowardTotals: score.PasteFlag.CountsTowardTotals(),
 		}
 	}
 
@@ -0,0 +1,89 @@
+package biz
+
+import (
+	"context"
+	scoremodel "hub-service/module/score/model"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Submission kinds reported in SubmissionEvent.Kind
+const (
+	SubmissionKindChallenge = "challenge"
+	SubmissionKindSentence  = "sentence"
+)
+
+// SubmissionEvent describes a graded submission after its score record has been saved
+type SubmissionEvent struct {
+	Kind            string
+	UserID          primitive.ObjectID
+	ChallengeID     primitive.ObjectID
+	SectionID       primitive.ObjectID
+	TranslationID   primitive.ObjectID
+	SentenceID      primitive.ObjectID
+	SentenceIndex   int
+	OriginalContent string
+	UserTranslation string
+	Analysis        *GrammarAnalysis
+	AttemptCount    int
+	PreviousBest    float64
+	BestScore       float64
+	IsNewBest       bool
+	// HintsUsed and HintPenalty describe the hints charged; Analysis.Score is already reduced
+	HintsUsed   int
+	HintPenalty float64
+	// PasteFlag is the score's paste flag after this submission and PreviousPasteFlag the one before;
+	// a best score whose flag does not count toward totals is kept off the leaderboards and badges
+	PasteFlag         *scoremodel.PasteFlag
+	PreviousPasteFlag *scoremodel.PasteFlag
+	SubmittedAt       time.Time
+}
+
+// SubmissionListener reacts to graded submissions, e.g. to keep history or update rankings.
+// Listeners run after the score is persisted, so their failures never fail the submission.
+type SubmissionListener interface {
+	OnSubmission(ctx context.Context, event *SubmissionEvent) error
+}
+
+// NotifySubmission runs every listener in order and logs the ones that fail
+func NotifySubmission(ctx context.Context, listeners []SubmissionListener, event *SubmissionEvent) {
+	for _, listener := range listeners {
+		if err := listener.OnSubmission(ctx, event); err != nil {
+			log.Printf("Submission listener %T failed for user %s: %v", listener, event.UserID.Hex(), err)
+		}
+	}
+}
+
+// ScoreChange describes a best score changed outside of a submission, e.g. by a reviewer's
+// override or an applied bulk re-grade, after the score record has been saved. When a reviewer
+// clears a paste flag the best score starts counting, which is reported with a PreviousBest of 0.
+type ScoreChange struct {
+	Kind          string
+	UserID        primitive.ObjectID
+	ChallengeID   primitive.ObjectID
+	TranslationID primitive.ObjectID
+	SentenceIndex int
+	PreviousBest  float64
+	BestScore     float64
+	// PasteFlag is the score's paste flag; changes to a score that does not count toward totals are
+	// kept off the leaderboards
+	PasteFlag *scoremodel.PasteFlag
+	ChangedAt time.Time
+}
+
+// ScoreChangeListener reacts to changed grades the way SubmissionListener reacts to new ones.
+// Listeners run after the score is persisted, so their failures never fail the change.
+type ScoreChangeListener interface {
+	OnScoreChange(ctx context.Context, change *ScoreChange) error
+}
+
+// NotifyScoreChange runs every listener in order and logs the ones that fail
+func NotifyScoreChange(ctx context.Context, listeners []ScoreChangeListener, change *ScoreChange) {
+	for _, listener := range listeners {
+		if err := listener.OnScoreChange(ctx, change); err != nil {
+			log.Printf("Score change listener %T failed for user %s: %v", listener, change.UserID.Hex(), err)
+		}
+	}
+}
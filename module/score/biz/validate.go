@@ -0,0 +1,226 @@
+package biz
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hub-service/common"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Error types accepted in GrammarAnalysis.Errors; anything else is mapped onto one of these
+const (
+	ErrorTypeGrammar    = "grammar"
+	ErrorTypeSyntax     = "syntax"
+	ErrorTypeVocabulary = "vocabulary"
+)
+
+// errorTypeAliases maps labels models commonly invent onto the three supported types
+var errorTypeAliases = map[string]string{
+	"grammar":        ErrorTypeGrammar,
+	"grammatical":    ErrorTypeGrammar,
+	"tense":          ErrorTypeGrammar,
+	"agreement":      ErrorTypeGrammar,
+	"article":        ErrorTypeGrammar,
+	"preposition":    ErrorTypeGrammar,
+	"syntax":         ErrorTypeSyntax,
+	"syntactic":      ErrorTypeSyntax,
+	"word_order":     ErrorTypeSyntax,
+	"structure":      ErrorTypeSyntax,
+	"punctuation":    ErrorTypeSyntax,
+	"vocabulary":     ErrorTypeVocabulary,
+	"word_choice":    ErrorTypeVocabulary,
+	"lexical":        ErrorTypeVocabulary,
+	"spelling":       ErrorTypeVocabulary,
+	"meaning":        ErrorTypeVocabulary,
+	"mistranslation": ErrorTypeVocabulary,
+}
+
+var (
+	ErrAIResponseBlocked = common.NewFullErrorResponse(
+		http.StatusUnprocessableEntity,
+		errors.New("ai response blocked"),
+		"The AI provider refused to grade this translation",
+		"ai response blocked",
+		"AI_RESPONSE_BLOCKED",
+	)
+	ErrAIResponseEmpty = common.NewFullErrorResponse(
+		http.StatusBadGateway,
+		errors.New("ai response empty"),
+		"The AI provider returned an empty response",
+		"ai response empty",
+		"AI_RESPONSE_EMPTY",
+	)
+	ErrAIResponseUnparsable = common.NewFullErrorResponse(
+		http.StatusBadGateway,
+		errors.New("failed to parse AI analysis"),
+		"The AI provider returned a response that could not be read",
+		"failed to parse AI analysis",
+		"AI_RESPONSE_UNPARSABLE",
+	)
+)
+
+// rawAnalysis accepts the loose shapes models produce before they are normalized
+type rawAnalysis struct {
+	Score       json.RawMessage `json:"score"`
+	Errors      []rawError      `json:"errors"`
+	Suggestions json.RawMessage `json:"suggestions"`
+	Feedback    json.RawMessage `json:"feedback"`
+}
+
+type rawError struct {
+	Type        string          `json:"type"`
+	Description string          `json:"description"`
+	Position    json.RawMessage `json:"position"`
+	Correction  string          `json:"correction"`
+}
+
+// parseAnalysis validates a model reply against the GrammarAnalysis schema and normalizes it.
+// The returned error describes the problem in words suitable for a repair prompt.
+func parseAnalysis(text, userTranslation string) (*GrammarAnalysis, error) {
+	body := extractJSONObject(stripCodeFence(text))
+	if body == "" {
+		return nil, errors.New("the reply does not contain a JSON object")
+	}
+
+	var raw rawAnalysis
+	if err := json.Unmarshal([]byte(body), &raw); err != nil {
+		return nil, fmt.Errorf("the reply is not valid JSON for the schema: %v", err)
+	}
+
+	score, ok := parseNumber(raw.Score)
+	if !ok {
+		return nil, errors.New(`"score" is missing or not a number`)
+	}
+
+	suggestions, ok := parseStringList(raw.Suggestions)
+	if !ok {
+		return nil, errors.New(`"suggestions" must be an array of strings`)
+	}
+
+	feedback, ok := parseString(raw.Feedback)
+	if !ok {
+		return nil, errors.New(`"feedback" must be a string`)
+	}
+
+	analysis := &GrammarAnalysis{
+		Score:       score,
+		Errors:      make([]Error, 0, len(raw.Errors)),
+		Suggestions: suggestions,
+		Feedback:    feedback,
+	}
+	for _, e := range raw.Errors {
+		position, _ := parseNumber(e.Position)
+		analysis.Errors = append(analysis.Errors, Error{
+			Type:        e.Type,
+			Description: e.Description,
+			Position:    int(position),
+			Correction:  e.Correction,
+		})
+	}
+
+	normalizeAnalysis(analysis, userTranslation)
+	return analysis, nil
+}
+
+// normalizeAnalysis clamps the score to 0-100, maps error types onto the supported set
+// and resets positions outside the translation to 0 ("unknown" in the prompt)
+func normalizeAnalysis(analysis *GrammarAnalysis, userTranslation string) {
+	if math.IsNaN(analysis.Score) || analysis.Score < 0 {
+		analysis.Score = 0
+	}
+	if analysis.Score > 100 {
+		analysis.Score = 100
+	}
+
+	length := utf8.RuneCountInString(userTranslation)
+	for i := range analysis.Errors {
+		analysis.Errors[i].Type = normalizeErrorType(analysis.Errors[i].Type)
+		if analysis.Errors[i].Position < 0 || analysis.Errors[i].Position > length {
+			analysis.Errors[i].Position = 0
+		}
+	}
+
+	if analysis.Errors == nil {
+		analysis.Errors = []Error{}
+	}
+	if analysis.Suggestions == nil {
+		analysis.Suggestions = []string{}
+	}
+}
+
+func normalizeErrorType(t string) string {
+	key := strings.ToLower(strings.TrimSpace(t))
+	key = strings.NewReplacer(" ", "_", "-", "_").Replace(key)
+	if mapped, ok := errorTypeAliases[key]; ok {
+		return mapped
+	}
+	return ErrorTypeGrammar
+}
+
+// extractJSONObject returns the outermost {...} span, dropping chatter around it
+func extractJSONObject(text string) string {
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
+	if start < 0 || end <= start {
+		return ""
+	}
+	return text[start : end+1]
+}
+
+// parseNumber accepts a JSON number or a numeric string such as "85" or "85/100"
+func parseNumber(raw json.RawMessage) (float64, bool) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return 0, false
+	}
+
+	var n float64
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n, true
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return 0, false
+	}
+	s = strings.TrimSpace(strings.SplitN(s, "/", 2)[0])
+	n, err := strconv.ParseFloat(s, 64)
+	return n, err == nil
+}
+
+// parseStringList accepts an array of strings, a single string, or nothing
+func parseStringList(raw json.RawMessage) ([]string, bool) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return []string{}, true
+	}
+
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list, true
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return []string{s}, true
+	}
+	return nil, false
+}
+
+// parseString accepts a string or nothing
+func parseString(raw json.RawMessage) (string, bool) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", true
+	}
+
+	var s string
+	err := json.Unmarshal(raw, &s)
+	return s, err == nil
+}
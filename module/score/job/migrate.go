@@ -0,0 +1,34 @@
+// Package job holds the score module's background jobs
+package job
+
+import (
+	"context"
+	"hub-service/core/appctx"
+	scoremodel "hub-service/module/score/model"
+	"hub-service/module/score/storage"
+	translationmodel "hub-service/module/translation/model"
+	"log"
+	"time"
+)
+
+const migrateTimeout = 30 * time.Minute
+
+// MigrateErrors converts grading errors still stored as JSON strings on challenge and
+// sentence scores into structured BSON arrays. It is idempotent, so it runs on every start;
+// until it finishes, string records keep decoding through scoremodel.ErrorList.
+func MigrateErrors(appCtx appctx.AppContext) {
+	ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
+	defer cancel()
+
+	store := storage.NewStorage(appCtx.GetDatabase())
+	for _, collection := range []string{scoremodel.CollectionName, translationmodel.UserTranslationScoreCollectionName} {
+		migrated, err := store.MigrateStringErrors(ctx, collection)
+		if err != nil {
+			log.Printf("Migrating errors in %s failed after %d records: %v", collection, migrated, err)
+			continue
+		}
+		if migrated > 0 {
+			log.Printf("Migrated errors of %d records in %s to structured BSON", migrated, collection)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// Error types assigned by the grader
+const (
+	ErrorTypeGrammar    = "grammar"
+	ErrorTypeSyntax     = "syntax"
+	ErrorTypeVocabulary = "vocabulary"
+)
+
+// ScoreError is one grading error on a score record
+type ScoreError struct {
+	Type        string `json:"type" bson:"type"`
+	Description string `json:"description" bson:"description"`
+	Position    int    `json:"position" bson:"position"`
+	Correction  string `json:"correction" bson:"correction"`
+}
+
+// ErrorList is stored as a BSON array so errors can be queried and aggregated.
+// In JSON it keeps the format clients have always received: the list encoded as a
+// string, or "" when there are no errors.
+type ErrorList []ScoreError
+
+func (l ErrorList) MarshalJSON() ([]byte, error) {
+	if len(l) == 0 {
+		return json.Marshal("")
+	}
+	b, err := json.Marshal([]ScoreError(l))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(b))
+}
+
+// UnmarshalJSON accepts both the encoded string and a plain array
+func (l *ErrorList) UnmarshalJSON(data []byte) error {
+	var encoded string
+	if err := json.Unmarshal(data, &encoded); err == nil {
+		*l, err = ParseErrorList(encoded)
+		return err
+	}
+	return json.Unmarshal(data, (*[]ScoreError)(l))
+}
+
+// UnmarshalBSONValue also reads the JSON strings written before errors were structured,
+// so records not migrated yet still decode
+func (l *ErrorList) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	raw := bson.RawValue{Type: t, Value: data}
+
+	switch t {
+	case bsontype.Array:
+		var errors []ScoreError
+		if err := raw.Unmarshal(&errors); err != nil {
+			return err
+		}
+		*l = errors
+		return nil
+	case bsontype.String:
+		var err error
+		*l, err = ParseErrorList(raw.StringValue())
+		return err
+	case bsontype.Null, bsontype.Undefined:
+		*l = nil
+		return nil
+	}
+	return fmt.Errorf("cannot decode %s into ErrorList", t)
+}
+
+// ParseErrorList decodes the JSON string format errors were stored in before
+func ParseErrorList(encoded string) (ErrorList, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+	var errors ErrorList
+	if err := json.Unmarshal([]byte(encoded), (*[]ScoreError)(&errors)); err != nil {
+		return nil, err
+	}
+	return errors, nil
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Paste flag review statuses. Pending and confirmed flags keep a score out of the learner's totals.
+const (
+	PasteStatusPending   = "pending"
+	PasteStatusCleared   = "cleared"
+	PasteStatusConfirmed = "confirmed"
+)
+
+// Reasons an attempt was flagged
+const (
+	PasteReasonMachineMatch = "machine_match"
+	PasteReasonPasted       = "pasted"
+	PasteReasonTypingSpeed  = "typing_speed"
+)
+
+// Telemetry is what the client observed while the learner wrote the translation. It is optional:
+// older clients do not send it.
+type Telemetry struct {
+	TypingTimeMs int64 `json:"typing_time_ms" bson:"typing_time_ms" binding:"min=0" example:"42000"`
+	Keystrokes   int   `json:"keystrokes" bson:"keystrokes" binding:"min=0" example:"118"`
+	PastedChars  int   `json:"pasted_chars" bson:"pasted_chars" binding:"min=0" example:"0"`
+}
+
+// PasteFlag marks a score whose attempt looks like pasted machine translation.
+// It keeps the flagged translation because later attempts overwrite the score's own.
+type PasteFlag struct {
+	Status            string             `json:"status" bson:"status"`
+	Reasons           []string           `json:"reasons" bson:"reasons"`
+	MachineSimilarity float64            `json:"machine_similarity" bson:"machine_similarity"`
+	MachineReference  string             `json:"machine_reference,omitempty" bson:"machine_reference,omitempty"`
+	UserTranslation   string             `json:"user_translation" bson:"user_translation"`
+	AttemptNumber     int                `json:"attempt_number" bson:"attempt_number"`
+	Telemetry         *Telemetry         `json:"telemetry,omitempty" bson:"telemetry,omitempty"`
+	FlaggedAt         time.Time          `json:"flagged_at" bson:"flagged_at"`
+	ReviewerID        primitive.ObjectID `json:"reviewer_id,omitempty" bson:"reviewer_id,omitempty"`
+	ReviewNote        string             `json:"review_note,omitempty" bson:"review_note,omitempty"`
+	ReviewedAt        *time.Time         `json:"reviewed_at,omitempty" bson:"reviewed_at,omitempty"`
+}
+
+// PasteFlagFilter narrows the flagged scores listed for review; zero values match everything
+type PasteFlagFilter struct {
+	Status string
+	UserID primitive.ObjectID
+}
+
+// CountsTowardTotals reports whether a score with this flag is included in summaries
+func (f *PasteFlag) CountsTowardTotals() bool {
+	return f == nil || f.Status == PasteStatusCleared
+}
@@ -18,11 +18,15 @@ type Score struct {
 	UserTranslation string             `json:"user_translation" bson:"user_translation"`
 	Score           float64            `json:"score" bson:"score"`
 	Feedback        string             `json:"feedback" bson:"feedback"`
-	Errors          string             `json:"errors" bson:"errors"`
+	Errors          ErrorList          `json:"errors" bson:"errors" swaggertype:"string"`
 	Suggestions     string             `json:"suggestions" bson:"suggestions"`
 	OriginalContent string             `json:"original_content" bson:"original_content"`
 	AttemptCount    int                `json:"attempt_count" bson:"attempt_count"`
 	BestScore       float64            `json:"best_score" bson:"best_score"`
+	PromptVersion   string             `json:"prompt_version" bson:"prompt_version"`
+	HumanReviewed   bool               `json:"human_reviewed" bson:"human_reviewed"`
+	ReviewedAt      *time.Time         `json:"reviewed_at,omitempty" bson:"reviewed_at,omitempty"`
+	PasteFlag       *PasteFlag         `json:"paste_flag,omitempty" bson:"paste_flag,omitempty"`
 	CreatedAt       time.Time          `json:"created_at" bson:"created_at"`
 	UpdatedAt       time.Time          `json:"updated_at" bson:"updated_at"`
 }
@@ -40,11 +44,13 @@ type ScoreCreate struct {
 	UserTranslation string             `json:"user_translation" bson:"user_translation"`
 	Score           float64            `json:"score" bson:"score"`
 	Feedback        string             `json:"feedback" bson:"feedback"`
-	Errors          string             `json:"errors" bson:"errors"`
+	Errors          ErrorList          `json:"errors" bson:"errors" swaggertype:"string"`
 	Suggestions     string             `json:"suggestions" bson:"suggestions"`
 	OriginalContent string             `json:"original_content" bson:"original_content"`
 	AttemptCount    int                `json:"attempt_count" bson:"attempt_count"`
 	BestScore       float64            `json:"best_score" bson:"best_score"`
+	PromptVersion   string             `json:"prompt_version" bson:"prompt_version"`
+	PasteFlag       *PasteFlag         `json:"paste_flag,omitempty" bson:"paste_flag,omitempty"`
 	CreatedAt       time.Time          `json:"created_at" bson:"created_at"`
 	UpdatedAt       time.Time          `json:"updated_at" bson:"updated_at"`
 }
@@ -55,15 +61,21 @@ func (ScoreCreate) TableName() string {
 
 // ScoreUpdate is the model for updating an existing score
 // Updated to match the new Gemini-based scoring structure
+// ReviewedAt has no omitempty: an update without it (a new AI grade) clears the previous human review time
+// PasteFlag is only set when the new attempt is flagged, so an open flag survives a clean resubmission
 
 type ScoreUpdate struct {
 	UserTranslation *string    `json:"user_translation,omitempty" bson:"user_translation,omitempty"`
 	Score           *float64   `json:"score,omitempty" bson:"score,omitempty"`
 	Feedback        *string    `json:"feedback,omitempty" bson:"feedback,omitempty"`
-	Errors          *string    `json:"errors,omitempty" bson:"errors,omitempty"`
+	Errors          *ErrorList `json:"errors,omitempty" bson:"errors,omitempty" swaggertype:"string"`
 	Suggestions     *string    `json:"suggestions,omitempty" bson:"suggestions,omitempty"`
 	AttemptCount    *int       `json:"attempt_count,omitempty" bson:"attempt_count,omitempty"`
 	BestScore       *float64   `json:"best_score,omitempty" bson:"best_score,omitempty"`
+	PromptVersion   *string    `json:"prompt_version,omitempty" bson:"prompt_version,omitempty"`
+	HumanReviewed   *bool      `json:"human_reviewed,omitempty" bson:"human_reviewed,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty" bson:"reviewed_at"`
+	PasteFlag       *PasteFlag `json:"paste_flag,omitempty" bson:"paste_flag,omitempty"`
 	UpdatedAt       *time.Time `json:"updated_at" bson:"updated_at,omitempty"`
 }
 
@@ -82,31 +94,38 @@ type ChallengeScore struct {
 	LastAttemptAt   time.Time          `json:"last_attempt_at"`
 	UserTranslation string             `json:"user_translation"`
 	Feedback        string             `json:"feedback"`
-	Errors          string             `json:"errors"`
+	Errors          ErrorList          `json:"errors" swaggertype:"string"`
 	Suggestions     string             `json:"suggestions"`
 	OriginalContent string             `json:"original_content"`
+	HumanReviewed   bool               `json:"human_reviewed"`
+	ReviewedAt      *time.Time         `json:"reviewed_at,omitempty"`
+	// CountsTowardTotals is false while a paste flag is pending or after it was confirmed
+	CountsTowardTotals bool `json:"counts_toward_totals"`
 }
 
 // SubmitScoreRequest giữ nguyên
 
 type SubmitScoreRequest struct {
-	ChallengeID     string `json:"challenge_id" binding:"required"`
-	UserTranslation string `json:"user_translation" binding:"required"`
+	ChallengeID     string     `json:"challenge_id" binding:"required"`
+	UserTranslation string     `json:"user_translation" binding:"required"`
+	Telemetry       *Telemetry `json:"telemetry,omitempty"`
 }
 
 // SubmitScoreResponse trả về kết quả chấm điểm của Gemini
 // Updated to match the new Gemini-based scoring structure
 
 type SubmitScoreResponse struct {
-	Score           float64 `json:"score"`
-	UserTranslation string  `json:"user_translation"`
-	Feedback        string  `json:"feedback"`
-	Errors          string  `json:"errors"`
-	Suggestions     string  `json:"suggestions"`
-	OriginalContent string  `json:"original_content"`
-	AttemptCount    int     `json:"attempt_count"`
-	BestScore       float64 `json:"best_score"`
-	IsNewBest       bool    `json:"is_new_best"`
+	Score           float64   `json:"score"`
+	UserTranslation string    `json:"user_translation"`
+	Feedback        string    `json:"feedback"`
+	Errors          ErrorList `json:"errors" swaggertype:"string"`
+	Suggestions     string    `json:"suggestions"`
+	OriginalContent string    `json:"original_content"`
+	AttemptCount    int       `json:"attempt_count"`
+	BestScore       float64   `json:"best_score"`
+	IsNewBest       bool      `json:"is_new_best"`
+	HintsUsed       int       `json:"hints_used"`
+	HintPenalty     float64   `json:"hint_penalty"`
 }
 
 // ScoreRequest giữ nguyên
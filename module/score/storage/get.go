@@ -30,6 +30,21 @@ func (s *Storage) GetScoreByUserAndChallenge(ctx context.Context, userID, challe
 	return &score, nil
 }
 
+func (s *Storage) GetScoreByID(ctx context.Context, id primitive.ObjectID) (*model.Score, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	var score model.Score
+	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&score)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &score, nil
+}
+
 func (s *Storage) GetUserScores(ctx context.Context, userID primitive.ObjectID) ([]model.Score, error) {
 	collection := s.db.MongoDB.GetCollection(model.CollectionName)
 
@@ -53,7 +68,14 @@ func (s *Storage) GetUserScoreSummary(ctx context.Context, userID primitive.Obje
 	collection := s.db.MongoDB.GetCollection(model.CollectionName)
 
 	pipeline := []bson.M{
-		{"$match": bson.M{"user_id": userID}},
+		// Scores with a pending or confirmed paste flag stay out of the totals until cleared
+		{"$match": bson.M{
+			"user_id": userID,
+			"paste_flag.status": bson.M{"$nin": []string{
+				model.PasteStatusPending,
+				model.PasteStatusConfirmed,
+			}},
+		}},
 		// Group by challenge_id to get the best score for each challenge
 		{"$group": bson.M{
 			"_id":           "$challenge_id",
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/score/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MigrateStringErrors rewrites errors stored as JSON strings in the named score collection
+// into BSON arrays. Records that cannot be parsed get an empty list. Each record is only
+// rewritten while it still holds the original string, so concurrent submissions win.
+func (s *Storage) MigrateStringErrors(ctx context.Context, collectionName string) (int, error) {
+	collection := s.db.MongoDB.GetCollection(collectionName)
+
+	cursor, err := collection.Find(ctx, bson.M{"errors": bson.M{"$type": "string"}})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	migrated := 0
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID     primitive.ObjectID `bson:"_id"`
+			Errors string             `bson:"errors"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return migrated, err
+		}
+
+		errors, err := model.ParseErrorList(doc.Errors)
+		if err != nil || errors == nil {
+			errors = model.ErrorList{}
+		}
+
+		result, err := collection.UpdateOne(ctx,
+			bson.M{"_id": doc.ID, "errors": doc.Errors},
+			bson.M{"$set": bson.M{"errors": errors}},
+		)
+		if err != nil {
+			return migrated, err
+		}
+		migrated += int(result.ModifiedCount)
+	}
+
+	return migrated, cursor.Err()
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"hub-service/common"
+	"hub-service/module/score/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ListFlaggedScores returns the scores carrying a paste flag, most recently flagged first
+func (s *Storage) ListFlaggedScores(ctx context.Context, filter *model.PasteFlagFilter, paging *common.Paging) ([]model.Score, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	query := bson.M{"paste_flag": bson.M{"$exists": true}}
+	if filter.Status != "" {
+		query["paste_flag.status"] = filter.Status
+	}
+	if !filter.UserID.IsZero() {
+		query["user_id"] = filter.UserID
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSkip(int64((paging.Page - 1) * paging.Limit))
+	findOptions.SetLimit(int64(paging.Limit))
+	findOptions.SetSort(bson.D{{Key: "paste_flag.flagged_at", Value: -1}})
+
+	cursor, err := collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	scores := []model.Score{}
+	if err = cursor.All(ctx, &scores); err != nil {
+		return nil, err
+	}
+
+	total, err := collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	paging.Total = total
+
+	return scores, nil
+}
+
+// ReviewPasteFlag records a reviewer's verdict on a pending paste flag. It returns false when the
+// score has no pending flag, so two reviewers cannot resolve the same flag.
+func (s *Storage) ReviewPasteFlag(ctx context.Context, id primitive.ObjectID, status string, reviewerID primitive.ObjectID, note string) (bool, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": id, "paste_flag.status": model.PasteStatusPending},
+		bson.M{"$set": bson.M{
+			"paste_flag.status":      status,
+			"paste_flag.reviewer_id": reviewerID,
+			"paste_flag.review_note": note,
+			"paste_flag.reviewed_at": time.Now(),
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
@@ -17,3 +17,18 @@ func (s *Storage) UpdateScore(ctx context.Context, id primitive.ObjectID, data *
 	_, err := collection.UpdateOne(ctx, filter, update)
 	return err
 }
+
+// OverrideScore writes a reviewer's grade while attemptCount is still the latest attempt,
+// and returns false once the learner has submitted again
+func (s *Storage) OverrideScore(ctx context.Context, id primitive.ObjectID, attemptCount int, data *model.ScoreUpdate) (bool, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	filter := bson.M{"_id": id, "attempt_count": attemptCount}
+	update := bson.M{"$set": data}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
@@ -1,20 +1,24 @@
 package transport
 
 import (
-	"errors"
 	"net/http"
 
 	"hub-service/common"
 	"hub-service/core/appctx"
+	"hub-service/module/grading"
 	scorebiz "hub-service/module/score/biz"
+	usagebiz "hub-service/module/usage/biz"
+	usagemodel "hub-service/module/usage/model"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // AIDemoResponse represents the AI analysis payload for the demo endpoint
 type AIDemoResponse struct {
 	OriginalText    string   `json:"original_text"`
 	UserTranslation string   `json:"user_translation"`
+	SourceLanguage  string   `json:"source_language"`
 	TargetLanguage  string   `json:"target_language"`
 	Score           float64  `json:"score"`
 	Feedback        string   `json:"feedback"`
@@ -26,41 +30,73 @@ type AIDemoResponse struct {
 
 // DemoGeminiScoreRequest represents the POST body for demo scoring
 type DemoGeminiScoreRequest struct {
-	UserTranslation string `json:"user_translation" binding:"required"`
-	TargetLanguage  string `json:"target_language" binding:"required"`
+	UserTranslation  string `json:"user_translation" binding:"required"`
+	SourceLanguage   string `json:"source_language" example:"vi"`
+	TargetLanguage   string `json:"target_language" binding:"required" example:"en"`
+	FeedbackLanguage string `json:"feedback_language" example:"vi"`
+}
+
+// demoSentences are the fixed texts the demo grades, by source language
+var demoSentences = map[string]string{
+	"vi": "Việc học tiếng Anh là rất quan trọng. Tiếng Anh là ngôn ngữ chính của quốc tế.",
+	"en": "Learning English is very important. English is the main international language.",
+}
+
+// demoSource returns the requested source language, or Vietnamese unless the target is
+// Vietnamese, in which case the demo translates from English
+func demoSource(req *DemoGeminiScoreRequest) string {
+	if req.SourceLanguage != "" {
+		return scorebiz.NormalizeLanguage(req.SourceLanguage)
+	}
+	if scorebiz.NormalizeLanguage(req.TargetLanguage) == "vi" {
+		return "en"
+	}
+	return "vi"
 }
 
 // AIDemoScoreHandler godoc
 // @Summary AI demo scoring (no auth, no persistence)
-// @Description Performs Gemini AI analysis on a fixed Vietnamese sentence using the provided user translation and target language. No auth. Does not save data.
+// @Description Performs AI analysis on a fixed sentence in the source language (Vietnamese by default, English when the target is Vietnamese) using the provided user translation and target language. No auth. Does not save data.
 // @Tags scores
 // @Accept json
 // @Produce json
 // @Param request body DemoGeminiScoreRequest true "Demo scoring request"
 // @Success 200 {object} common.Response{data=AIDemoResponse}
 // @Failure 400 {object} common.AppError
+// @Failure 422 {object} common.AppError "Unsupported language pair"
 // @Failure 500 {object} common.AppError
 // @Router /api/scores/ai-demo [post]
 func AIDemoScoreHandler(appCtx appctx.AppContext) gin.HandlerFunc {
+	pairs := scorebiz.SupportedLanguagePairs()
+
 	return func(c *gin.Context) {
 		var req DemoGeminiScoreRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
 			panic(common.ErrInvalidRequest(err))
 		}
 
-		original := "Việc học tiếng Anh là rất quan trọng. Tiếng Anh là ngôn ngữ chính của quốc tế."
+		source := demoSource(&req)
+		original, ok := demoSentences[source]
+		if !ok {
+			panic(scorebiz.ErrUnsupportedLanguagePair)
+		}
 
-		geminiAPIKey := appCtx.GetEnv("GEMINI_API_KEY")
-		if geminiAPIKey == "" {
-			panic(common.NewErrorResponse(errors.New("gemini api key not configured"), "Gemini API key not configured", "GEMINI_CONFIG_ERROR", "CONFIG_ERROR"))
+		provider, err := grading.NewProvider(appCtx)
+		if err != nil {
+			panic(err)
 		}
-		geminiBaseURL := appCtx.GetEnv("GEMINI_BASE_URL")
-		if geminiBaseURL == "" {
-			panic(common.NewErrorResponse(errors.New("gemini base url not configured"), "Gemini base url not configured", "GEMINI_CONFIG_ERROR", "CONFIG_ERROR"))
+
+		gradeReq, err := pairs.NewGradeRequest(original, req.UserTranslation, source, req.TargetLanguage, req.FeedbackLanguage)
+		if err != nil {
+			panic(err)
 		}
 
-		gemini := scorebiz.NewGeminiBiz(geminiAPIKey, geminiBaseURL)
-		analysis, err := gemini.AnalyzeGrammar(c.Request.Context(), original, req.UserTranslation, req.TargetLanguage)
+		// The demo is public; attribute the call to the caller when they are signed in
+		userID, _ := c.Get("user_id")
+		callerID, _ := userID.(primitive.ObjectID)
+		ctx := usagebiz.WithCaller(c.Request.Context(), usagemodel.FeatureDemo, callerID)
+
+		analysis, err := provider.AnalyzeGrammar(ctx, gradeReq)
 		if err != nil {
 			panic(err)
 		}
@@ -81,6 +117,7 @@ func AIDemoScoreHandler(appCtx appctx.AppContext) gin.HandlerFunc {
 		resp := &AIDemoResponse{
 			OriginalText:    original,
 			UserTranslation: req.UserTranslation,
+			SourceLanguage:  source,
 			TargetLanguage:  req.TargetLanguage,
 			Score:           analysis.Score,
 			Feedback:        analysis.Feedback,
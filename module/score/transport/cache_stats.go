@@ -0,0 +1,38 @@
+package transport
+
+import (
+	"errors"
+	"hub-service/common"
+	"hub-service/core/appctx"
+	scorebiz "hub-service/module/score/biz"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetGradingCacheStats godoc
+// @Summary Grading cache statistics
+// @Description Hit and miss counters of the Redis cache in front of AI grading. Admin only.
+// @Tags scores
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} common.Response{data=scorebiz.GradingCacheStats} "Success"
+// @Failure 400 {object} common.AppError "Redis not configured"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Router /api/scores/cache/stats [get]
+func GetGradingCacheStats(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rdb := appCtx.GetRedis()
+		if rdb == nil {
+			panic(common.ErrInvalidRequest(errors.New("redis is not configured")))
+		}
+
+		stats, err := scorebiz.GetGradingCacheStats(rdb)
+		if err != nil {
+			panic(common.ErrDB(err))
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(stats))
+	}
+}
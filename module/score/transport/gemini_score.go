@@ -6,6 +6,7 @@ import (
 	"hub-service/common"
 	"hub-service/core/appctx"
 	challengestorage "hub-service/module/challenge/storage"
+	"hub-service/module/grading"
 	scorebiz "hub-service/module/score/biz"
 	scoremodel "hub-service/module/score/model"
 	"hub-service/module/score/storage"
@@ -17,10 +18,11 @@ import (
 )
 
 // GeminiScoreRequest represents the request for Gemini scoring
+// Telemetry is optional; attempts matching the machine translation are flagged for review
 type GeminiScoreRequest struct {
-	ChallengeID     primitive.ObjectID `json:"challenge_id" binding:"required"`
-	UserTranslation string             `json:"user_translation" binding:"required"`
-	TargetLanguage  string             `json:"target_language" binding:"required"`
+	ChallengeID     primitive.ObjectID    `json:"challenge_id" binding:"required"`
+	UserTranslation string                `json:"user_translation" binding:"required"`
+	Telemetry       *scoremodel.Telemetry `json:"telemetry,omitempty"`
 }
 
 // GeminiScoreResponse represents the enhanced response with Gemini analysis
@@ -60,19 +62,23 @@ type GeminiTranslateResponse struct {
 
 // GeminiScoreHandler godoc
 // @Summary Score and analyze grammar using Gemini AI
-// @Description Analyzes user translation using Gemini AI for grammar, syntax, and language accuracy
+// @Description Analyzes user translation using Gemini AI for grammar, syntax, and language accuracy. The language pair comes from the challenge; feedback is written in the user's feedback_language.
 // @Tags scores
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body GeminiScoreRequest true "Gemini scoring request"
+// @Param no_cache query bool false "Admins only: skip the grading cache"
 // @Success 200 {object} common.Response{data=GeminiScoreResponse} "Success"
 // @Failure 400 {object} common.AppError "Bad request - invalid input"
 // @Failure 401 {object} common.AppError "Unauthorized"
 // @Failure 404 {object} common.AppError "Challenge not found"
+// @Failure 422 {object} common.AppError "Unsupported language pair"
 // @Failure 500 {object} common.AppError "Internal server error"
 // @Router /api/scores/ai-translate [post]
 func GeminiScoreHandler(appCtx appctx.AppContext) gin.HandlerFunc {
+	pairs := scorebiz.SupportedLanguagePairs()
+
 	return func(c *gin.Context) {
 		var req GeminiScoreRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
@@ -94,45 +100,36 @@ func GeminiScoreHandler(appCtx appctx.AppContext) gin.HandlerFunc {
 		scoreStore := storage.NewStorage(appCtx.GetDatabase())
 		challengeStore := challengestorage.NewStorage(appCtx.GetDatabase())
 
-		geminiAPIKey := appCtx.GetEnv("GEMINI_API_KEY")
-		if geminiAPIKey == "" {
-			panic(common.NewErrorResponse(errors.New("gemini api key not configured"), "Gemini API key not configured", "GEMINI_CONFIG_ERROR", "CONFIG_ERROR"))
-		}
-
-		geminiBaseURL := appCtx.GetEnv("GEMINI_BASE_URL")
-		if geminiBaseURL == "" {
-			panic(common.NewErrorResponse(errors.New("gemini base url not configured"), "Gemini base url not configured", "GEMINI_CONFIG_ERROR", "CONFIG_ERROR"))
+		provider, err := grading.NewProvider(appCtx)
+		if err != nil {
+			panic(err)
 		}
 
-		geminiBiz := scorebiz.NewGeminiBiz(geminiAPIKey, geminiBaseURL)
-		business := scorebiz.NewScoreBiz(scoreStore, challengeStore, geminiBiz)
+		business := scorebiz.NewScoreBiz(scoreStore, challengeStore, provider, pairs, grading.HintLedger(appCtx), grading.PasteDetector(appCtx), grading.SubmissionListeners(appCtx)...)
 
 		// Convert request to SubmitScoreRequest format
 		submitReq := &scoremodel.SubmitScoreRequest{
 			ChallengeID:     req.ChallengeID.Hex(),
 			UserTranslation: req.UserTranslation,
+			Telemetry:       req.Telemetry,
 		}
 
 		// Use ScoreBiz to analyze and save to database
-		result, err := business.SubmitScore(c.Request.Context(), userID, submitReq, req.TargetLanguage)
+		result, err := business.SubmitScore(grading.RequestContext(c), userID, submitReq, grading.FeedbackLanguage(c, appCtx))
 		if err != nil {
 			panic(err)
 		}
 
 		// Convert result back to GeminiScoreResponse format
 		var modelErrors []Error
-		if result.Errors != "" {
-			// Parse errors JSON string back to slice
-			var errors []scorebiz.Error
-			if err := json.Unmarshal([]byte(result.Errors), &errors); err == nil {
-				modelErrors = make([]Error, len(errors))
-				for i, err := range errors {
-					modelErrors[i] = Error{
-						Type:        err.Type,
-						Description: err.Description,
-						Position:    err.Position,
-						Correction:  err.Correction,
-					}
+		if len(result.Errors) > 0 {
+			modelErrors = make([]Error, len(result.Errors))
+			for i, err := range result.Errors {
+				modelErrors[i] = Error{
+					Type:        err.Type,
+					Description: err.Description,
+					Position:    err.Position,
+					Correction:  err.Correction,
 				}
 			}
 		}
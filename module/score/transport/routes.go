@@ -1,8 +1,10 @@
 package transport
 
 import (
+	"hub-service/common"
 	"hub-service/core/appctx"
 	"hub-service/middleware/auth"
+	"hub-service/middleware/quota"
 
 	"github.com/gin-gonic/gin"
 )
@@ -10,15 +12,23 @@ import (
 func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
 	scores := g.Group("/scores")
 	{
-		// Public demo endpoint (no auth)
-		scores.POST("/ai-demo", AIDemoScoreHandler(appCtx))
+		// Public demo endpoint (no auth, limited per IP)
+		scores.POST("/ai-demo", quota.RequireAIQuota(appCtx), AIDemoScoreHandler(appCtx))
 
 		// All score operations require authentication
 		protected := scores.Group("/")
 		protected.Use(auth.AuthMiddleware(appCtx))
 		{
 			protected.GET("/user/:user_id", GetUserScores(appCtx))
-			protected.POST("/ai-translate", auth.AuthMiddleware(appCtx), GeminiScoreHandler(appCtx))
+			protected.POST("/ai-translate", auth.AuthMiddleware(appCtx), quota.RequireAIQuota(appCtx), GeminiScoreHandler(appCtx))
+		}
+
+		// Admin-only operations
+		adminProtected := scores.Group("/")
+		adminProtected.Use(auth.AuthMiddleware(appCtx))
+		adminProtected.Use(auth.RequireRoles(common.RoleAdmin, common.RoleSuperAdmin))
+		{
+			adminProtected.GET("/cache/stats", GetGradingCacheStats(appCtx))
 		}
 	}
 }
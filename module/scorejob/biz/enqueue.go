@@ -0,0 +1,86 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"hub-service/common"
+	"hub-service/module/scorejob/model"
+	"net/http"
+	"os"
+	"time"
+)
+
+const defaultScoreJobTopic = "score-jobs"
+
+var ErrAsyncScoringUnavailable = common.NewFullErrorResponse(
+	http.StatusServiceUnavailable,
+	errors.New("kafka is not configured"),
+	"Asynchronous scoring is not available, use the synchronous endpoint",
+	"kafka is not configured",
+	"ErrAsyncScoringUnavailable",
+)
+
+// ScoreJobTopic reads KAFKA_SCORE_JOB_TOPIC, shared by the producer and the worker
+func ScoreJobTopic() string {
+	if topic := os.Getenv("KAFKA_SCORE_JOB_TOPIC"); topic != "" {
+		return topic
+	}
+	return defaultScoreJobTopic
+}
+
+type CreateScoreJobStore interface {
+	Create(ctx context.Context, data *model.ScoreJob) error
+	Finish(ctx context.Context, job *model.ScoreJob) error
+}
+
+type Producer interface {
+	Produce(ctx context.Context, topic string, key, value []byte) error
+}
+
+type enqueueScoreJobBiz struct {
+	store    CreateScoreJobStore
+	producer Producer
+}
+
+// NewEnqueueScoreJobBiz takes the Kafka client as producer; a nil producer disables async scoring
+func NewEnqueueScoreJobBiz(store CreateScoreJobStore, producer Producer) *enqueueScoreJobBiz {
+	return &enqueueScoreJobBiz{store: store, producer: producer}
+}
+
+// Enqueue persists the job and publishes its ID for the worker
+func (biz *enqueueScoreJobBiz) Enqueue(ctx context.Context, job *model.ScoreJob) (*model.ScoreJob, error) {
+	if biz.producer == nil {
+		return nil, ErrAsyncScoringUnavailable
+	}
+
+	now := time.Now()
+	job.Status = model.StatusQueued
+	job.CreatedAt = now
+	job.UpdatedAt = now
+
+	if err := biz.store.Create(ctx, job); err != nil {
+		return nil, common.ErrCannotCreateEntity("ScoreJob", err)
+	}
+
+	if err := publish(ctx, biz.producer, job); err != nil {
+		job.Status = model.StatusFailed
+		job.Error = "failed to enqueue job"
+		if finishErr := biz.store.Finish(ctx, job); finishErr != nil {
+			return nil, common.ErrDB(finishErr)
+		}
+		return nil, common.ErrCannotCreateEntity("ScoreJob", err)
+	}
+
+	return job, nil
+}
+
+// publish sends the job ID to the worker topic, keyed by user so one learner's jobs
+// stay ordered on a partition
+func publish(ctx context.Context, producer Producer, job *model.ScoreJob) error {
+	payload, err := json.Marshal(model.ScoreJobMessage{JobID: job.ID.Hex()})
+	if err != nil {
+		return err
+	}
+	return producer.Produce(ctx, ScoreJobTopic(), []byte(job.UserID.Hex()), payload)
+}
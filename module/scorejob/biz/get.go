@@ -0,0 +1,37 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"hub-service/common"
+	"hub-service/module/scorejob/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type GetScoreJobStore interface {
+	Get(ctx context.Context, id primitive.ObjectID) (*model.ScoreJob, error)
+}
+
+type getScoreJobBiz struct {
+	store GetScoreJobStore
+}
+
+func NewGetScoreJobBiz(store GetScoreJobStore) *getScoreJobBiz {
+	return &getScoreJobBiz{store: store}
+}
+
+// GetScoreJob returns a job owned by requesterID
+func (biz *getScoreJobBiz) GetScoreJob(ctx context.Context, id, requesterID primitive.ObjectID) (*model.ScoreJob, error) {
+	job, err := biz.store.Get(ctx, id)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity("ScoreJob", err)
+	}
+	if job == nil {
+		return nil, common.ErrEntityNotFound("ScoreJob", common.RecordNotFound)
+	}
+	if job.UserID != requesterID {
+		return nil, common.ErrNoPermission(errors.New("score job belongs to another user"))
+	}
+	return job, nil
+}
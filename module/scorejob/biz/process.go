@@ -0,0 +1,124 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"hub-service/common"
+	scorebiz "hub-service/module/score/biz"
+	scoremodel "hub-service/module/score/model"
+	"hub-service/module/scorejob/model"
+	translationmodel "hub-service/module/translation/model"
+	"log"
+	"runtime/debug"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ProcessScoreJobStore interface {
+	Get(ctx context.Context, id primitive.ObjectID) (*model.ScoreJob, error)
+	Claim(ctx context.Context, id primitive.ObjectID) (bool, error)
+	Finish(ctx context.Context, job *model.ScoreJob) error
+}
+
+// ChallengeGrader is satisfied by *scorebiz.ScoreBiz
+type ChallengeGrader interface {
+	SubmitScore(ctx context.Context, userID primitive.ObjectID, req *scoremodel.SubmitScoreRequest, feedbackLanguage string) (*scoremodel.SubmitScoreResponse, error)
+}
+
+// SentenceGrader is satisfied by the translation submit biz
+type SentenceGrader interface {
+	SubmitSentenceTranslation(ctx context.Context, translationID primitive.ObjectID, sentenceIndex int, userTranslation string, telemetry *scoremodel.Telemetry, userID primitive.ObjectID, feedbackLanguage string) (*translationmodel.SubmitSentenceTranslationResponse, error)
+}
+
+type processScoreJobBiz struct {
+	store     ProcessScoreJobStore
+	challenge ChallengeGrader
+	sentence  SentenceGrader
+}
+
+func NewProcessScoreJobBiz(store ProcessScoreJobStore, challenge ChallengeGrader, sentence SentenceGrader) *processScoreJobBiz {
+	return &processScoreJobBiz{store: store, challenge: challenge, sentence: sentence}
+}
+
+// Process grades one job with the same code path as the synchronous endpoints.
+// Grading failures are recorded on the job; only storage errors are returned.
+func (biz *processScoreJobBiz) Process(ctx context.Context, jobID primitive.ObjectID) error {
+	claimed, err := biz.store.Claim(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		log.Printf("Score job %s already claimed or finished, skipping", jobID.Hex())
+		return nil
+	}
+
+	job, err := biz.store.Get(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return fmt.Errorf("score job %s disappeared", jobID.Hex())
+	}
+
+	gradeCtx := ctx
+	if job.BypassCache {
+		gradeCtx = scorebiz.WithCacheBypass(ctx)
+	}
+
+	gradeErr := biz.grade(gradeCtx, job)
+	if gradeErr != nil {
+		job.Status = model.StatusFailed
+		job.Error = jobErrorMessage(gradeErr)
+	} else {
+		job.Status = model.StatusCompleted
+	}
+
+	return biz.store.Finish(ctx, job)
+}
+
+// grade runs the job through the synchronous grading path. A panic fails the job instead of
+// taking down the consumer.
+func (biz *processScoreJobBiz) grade(ctx context.Context, job *model.ScoreJob) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Score job %s panicked: %v\n%s", job.ID.Hex(), r, debug.Stack())
+			err = fmt.Errorf("score job panicked: %v", r)
+		}
+	}()
+
+	switch job.Kind {
+	case model.KindChallenge:
+		job.ChallengeResult, err = biz.challenge.SubmitScore(ctx, job.UserID, &scoremodel.SubmitScoreRequest{
+			ChallengeID:     job.ChallengeID.Hex(),
+			UserTranslation: job.UserTranslation,
+			Telemetry:       job.Telemetry,
+		}, job.FeedbackLanguage)
+	case model.KindSentence:
+		job.SentenceResult, err = biz.sentence.SubmitSentenceTranslation(ctx, job.TranslationID, job.SentenceIndex, job.UserTranslation, job.Telemetry, job.UserID, job.FeedbackLanguage)
+	default:
+		err = fmt.Errorf("unknown score job kind %q", job.Kind)
+	}
+	return err
+}
+
+// Fail marks a queued job as failed without grading it, e.g. when no provider is configured
+func (biz *processScoreJobBiz) Fail(ctx context.Context, jobID primitive.ObjectID, cause error) error {
+	claimed, err := biz.store.Claim(ctx, jobID)
+	if err != nil || !claimed {
+		return err
+	}
+
+	return biz.store.Finish(ctx, &model.ScoreJob{
+		ID:     jobID,
+		Status: model.StatusFailed,
+		Error:  jobErrorMessage(cause),
+	})
+}
+
+// jobErrorMessage exposes the user-facing message of AppErrors and hides other internals
+func jobErrorMessage(err error) string {
+	if appErr, ok := err.(*common.AppError); ok {
+		return appErr.Message
+	}
+	return "grading failed"
+}
@@ -0,0 +1,75 @@
+package biz
+
+import (
+	"context"
+	"hub-service/module/scorejob/model"
+	"log"
+	"time"
+)
+
+const (
+	reapBatchSize = 100
+	// A job claimed this many times without finishing is failed instead of requeued
+	maxJobAttempts = 3
+)
+
+type ReapScoreJobStore interface {
+	ListStale(ctx context.Context, before time.Time, limit int) ([]model.ScoreJob, error)
+	Requeue(ctx context.Context, job *model.ScoreJob) (bool, error)
+	Finish(ctx context.Context, job *model.ScoreJob) error
+}
+
+type reapScoreJobBiz struct {
+	store    ReapScoreJobStore
+	producer Producer
+}
+
+func NewReapScoreJobBiz(store ReapScoreJobStore, producer Producer) *reapScoreJobBiz {
+	return &reapScoreJobBiz{store: store, producer: producer}
+}
+
+// Reap requeues jobs left processing since before, e.g. by a worker that crashed, and publishes
+// them again. Jobs that keep getting stuck are failed so the learner stops waiting.
+// It returns how many jobs were requeued and failed.
+func (biz *reapScoreJobBiz) Reap(ctx context.Context, before time.Time) (int, int, error) {
+	jobs, err := biz.store.ListStale(ctx, before, reapBatchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	requeued, failed := 0, 0
+	for i := range jobs {
+		job := &jobs[i]
+
+		if job.Attempts >= maxJobAttempts {
+			job.Status = model.StatusFailed
+			job.Error = "grading timed out"
+			if err := biz.store.Finish(ctx, job); err != nil {
+				return requeued, failed, err
+			}
+			failed++
+			continue
+		}
+
+		ok, err := biz.store.Requeue(ctx, job)
+		if err != nil {
+			return requeued, failed, err
+		}
+		if !ok {
+			continue
+		}
+
+		if err := publish(ctx, biz.producer, job); err != nil {
+			log.Printf("Failed to republish score job %s: %v", job.ID.Hex(), err)
+			job.Status = model.StatusFailed
+			job.Error = "failed to enqueue job"
+			if err := biz.store.Finish(ctx, job); err != nil {
+				return requeued, failed, err
+			}
+			failed++
+			continue
+		}
+		requeued++
+	}
+	return requeued, failed, nil
+}
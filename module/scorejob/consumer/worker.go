@@ -0,0 +1,119 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hub-service/core/appctx"
+	challengestorage "hub-service/module/challenge/storage"
+	"hub-service/module/grading"
+	scorebiz "hub-service/module/score/biz"
+	scorestorage "hub-service/module/score/storage"
+	"hub-service/module/scorejob/biz"
+	"hub-service/module/scorejob/model"
+	"hub-service/module/scorejob/storage"
+	translationbiz "hub-service/module/translation/biz"
+	translationstorage "hub-service/module/translation/storage"
+	"log"
+	"os"
+	"runtime/debug"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultGroupID = "score-job-workers"
+	jobTimeout     = 2 * time.Minute
+)
+
+// ScoreJobWorker consumes queued score jobs and grades them
+type ScoreJobWorker struct {
+	appCtx  appctx.AppContext
+	topic   string
+	groupID string
+	pairs   scorebiz.LanguagePairs
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+func NewScoreJobWorker(appCtx appctx.AppContext) *ScoreJobWorker {
+	groupID := os.Getenv("KAFKA_SCORE_JOB_GROUP_ID")
+	if groupID == "" {
+		groupID = defaultGroupID
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &ScoreJobWorker{
+		appCtx:  appCtx,
+		topic:   biz.ScoreJobTopic(),
+		groupID: groupID,
+		pairs:   scorebiz.SupportedLanguagePairs(),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Start starts the score job worker
+func (w *ScoreJobWorker) Start() {
+	if w.appCtx.GetKafka() == nil {
+		log.Println("Kafka client not available, score job worker not started")
+		return
+	}
+
+	log.Printf("Starting score job worker for topic: %s, group: %s", w.topic, w.groupID)
+
+	w.appCtx.GetKafka().StartConsumer(w.ctx, w.topic, w.groupID, w.handleMessage)
+}
+
+// Stop stops the score job worker
+func (w *ScoreJobWorker) Stop() {
+	log.Println("Stopping score job worker...")
+	w.cancel()
+}
+
+func (w *ScoreJobWorker) handleMessage(msg *kafkago.Message) (err error) {
+	// One bad message must not stop the consumer goroutine, and with it the process
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Score job message panicked: %v\n%s", r, debug.Stack())
+			err = fmt.Errorf("score job message panicked: %v", r)
+		}
+	}()
+
+	var jobMsg model.ScoreJobMessage
+	if err := json.Unmarshal(msg.Value, &jobMsg); err != nil {
+		log.Printf("Failed to unmarshal score job message: %v", err)
+		return err
+	}
+
+	jobID, err := primitive.ObjectIDFromHex(jobMsg.JobID)
+	if err != nil {
+		log.Printf("Invalid score job id %q: %v", jobMsg.JobID, err)
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(w.ctx, jobTimeout)
+	defer cancel()
+
+	db := w.appCtx.GetDatabase()
+	store := storage.NewStorage(db)
+
+	// Build the same bizes the synchronous endpoints use
+	provider, err := grading.NewProvider(w.appCtx)
+	if err != nil {
+		return biz.NewProcessScoreJobBiz(store, nil, nil).Fail(ctx, jobID, err)
+	}
+	hints := grading.HintLedger(w.appCtx)
+	paste := grading.PasteDetector(w.appCtx)
+	listeners := grading.SubmissionListeners(w.appCtx)
+
+	challengeGrader := scorebiz.NewScoreBiz(scorestorage.NewStorage(db), challengestorage.NewStorage(db), provider, w.pairs, hints, paste, listeners...)
+	sentenceGrader := translationbiz.NewSubmitTranslationBiz(translationstorage.NewStorage(db), provider, w.pairs, hints, paste, listeners...)
+
+	processor := biz.NewProcessScoreJobBiz(store, challengeGrader, sentenceGrader)
+
+	return processor.Process(ctx, jobID)
+}
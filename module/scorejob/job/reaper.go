@@ -0,0 +1,101 @@
+// Package job requeues score jobs whose worker stopped before finishing them
+package job
+
+import (
+	"context"
+	"hub-service/core/appctx"
+	"hub-service/module/scorejob/biz"
+	"hub-service/module/scorejob/storage"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	reapInterval = time.Minute
+	// staleAfter is well past the worker's job timeout, so a job this old has no live worker
+	staleAfter = 5 * time.Minute
+)
+
+type Reaper struct {
+	appCtx  appctx.AppContext
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	running bool
+	mu      sync.Mutex
+}
+
+func NewReaper(appCtx appctx.AppContext) *Reaper {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Reaper{
+		appCtx: appCtx,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start starts requeueing stale score jobs every minute
+func (r *Reaper) Start() {
+	if r.appCtx.GetKafka() == nil {
+		return
+	}
+
+	r.mu.Lock()
+	if r.running {
+		r.mu.Unlock()
+		return
+	}
+	r.running = true
+	r.mu.Unlock()
+
+	log.Println("Starting score job reaper...")
+
+	r.wg.Add(1)
+	go r.run()
+}
+
+// Stop stops the reaper
+func (r *Reaper) Stop() {
+	r.mu.Lock()
+	if !r.running {
+		r.mu.Unlock()
+		return
+	}
+	r.running = false
+	r.mu.Unlock()
+
+	r.cancel()
+	r.wg.Wait()
+	log.Println("Score job reaper stopped")
+}
+
+func (r *Reaper) run() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(reapInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+			r.reap()
+		}
+	}
+}
+
+func (r *Reaper) reap() {
+	business := biz.NewReapScoreJobBiz(storage.NewStorage(r.appCtx.GetDatabase()), r.appCtx.GetKafka())
+
+	requeued, failed, err := business.Reap(r.ctx, time.Now().Add(-staleAfter))
+	if err != nil {
+		log.Printf("Error reaping score jobs: %v", err)
+		return
+	}
+	if requeued > 0 || failed > 0 {
+		log.Printf("Requeued %d stale score jobs, failed %d", requeued, failed)
+	}
+}
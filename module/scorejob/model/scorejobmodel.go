@@ -0,0 +1,80 @@
+package model
+
+import (
+	scoremodel "hub-service/module/score/model"
+	translationmodel "hub-service/module/translation/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const CollectionName = "score_jobs"
+
+// Job kinds, matching the synchronous endpoints they replace
+const (
+	KindChallenge = "challenge"
+	KindSentence  = "sentence"
+)
+
+// Job statuses
+const (
+	StatusQueued     = "queued"
+	StatusProcessing = "processing"
+	StatusCompleted  = "completed"
+	StatusFailed     = "failed"
+)
+
+// ScoreJob is an asynchronous grading request and, once finished, its result
+type ScoreJob struct {
+	ID               primitive.ObjectID    `json:"id" bson:"_id,omitempty"`
+	UserID           primitive.ObjectID    `json:"user_id" bson:"user_id"`
+	Kind             string                `json:"kind" bson:"kind"`
+	Status           string                `json:"status" bson:"status"`
+	ChallengeID      primitive.ObjectID    `json:"challenge_id,omitempty" bson:"challenge_id,omitempty"`
+	TranslationID    primitive.ObjectID    `json:"translation_id,omitempty" bson:"translation_id,omitempty"`
+	SentenceIndex    int                   `json:"sentence_index" bson:"sentence_index"`
+	UserTranslation  string                `json:"user_translation" bson:"user_translation"`
+	Telemetry        *scoremodel.Telemetry `json:"telemetry,omitempty" bson:"telemetry,omitempty"`
+	FeedbackLanguage string                `json:"feedback_language,omitempty" bson:"feedback_language,omitempty"`
+	BypassCache      bool                  `json:"-" bson:"bypass_cache"`
+
+	ChallengeResult *scoremodel.SubmitScoreResponse                     `json:"challenge_result,omitempty" bson:"challenge_result,omitempty"`
+	SentenceResult  *translationmodel.SubmitSentenceTranslationResponse `json:"sentence_result,omitempty" bson:"sentence_result,omitempty"`
+	Error           string                                              `json:"error,omitempty" bson:"error,omitempty"`
+
+	// StartedAt is when a worker claimed the job; a processing job whose worker died is
+	// requeued once it is older than the reaper's lease. Attempts counts the claims.
+	StartedAt *time.Time `json:"started_at,omitempty" bson:"started_at,omitempty"`
+	Attempts  int        `json:"-" bson:"attempts"`
+
+	CreatedAt   time.Time  `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" bson:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+}
+
+func (ScoreJob) TableName() string {
+	return CollectionName
+}
+
+// IsFinished reports whether the job reached a terminal status
+func (j *ScoreJob) IsFinished() bool {
+	return j.Status == StatusCompleted || j.Status == StatusFailed
+}
+
+// ScoreJobMessage is the Kafka payload; the job itself lives in Mongo
+type ScoreJobMessage struct {
+	JobID string `json:"job_id"`
+}
+
+// EnqueueChallengeJobRequest mirrors the synchronous /api/scores/ai-translate body
+type EnqueueChallengeJobRequest struct {
+	ChallengeID     string                `json:"challenge_id" binding:"required"`
+	UserTranslation string                `json:"user_translation" binding:"required"`
+	Telemetry       *scoremodel.Telemetry `json:"telemetry,omitempty"`
+}
+
+// EnqueueSentenceJobRequest mirrors the synchronous sentence translate body
+type EnqueueSentenceJobRequest struct {
+	UserTranslation string                `json:"user_translation" binding:"required"`
+	Telemetry       *scoremodel.Telemetry `json:"telemetry,omitempty"`
+}
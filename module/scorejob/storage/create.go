@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/scorejob/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (s *Storage) Create(ctx context.Context, data *model.ScoreJob) error {
+	if data.ID.IsZero() {
+		data.ID = primitive.NewObjectID()
+	}
+
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+	_, err := collection.InsertOne(ctx, data)
+	return err
+}
@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/scorejob/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (s *Storage) Get(ctx context.Context, id primitive.ObjectID) (*model.ScoreJob, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	var job model.ScoreJob
+	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &job, nil
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/scorejob/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Claim moves a queued job to processing; it returns false when another worker
+// already took it or the job is finished, so redelivered messages are skipped
+func (s *Storage) Claim(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	now := time.Now()
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": model.StatusQueued},
+		bson.M{
+			"$set": bson.M{"status": model.StatusProcessing, "started_at": now, "updated_at": now},
+			"$inc": bson.M{"attempts": 1},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// Finish stores the terminal status together with the result or error
+func (s *Storage) Finish(ctx context.Context, job *model.ScoreJob) error {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	now := time.Now()
+	job.UpdatedAt = now
+	job.CompletedAt = &now
+
+	_, err := collection.UpdateOne(ctx,
+		bson.M{"_id": job.ID},
+		bson.M{"$set": bson.M{
+			"status":           job.Status,
+			"challenge_result": job.ChallengeResult,
+			"sentence_result":  job.SentenceResult,
+			"error":            job.Error,
+			"updated_at":       job.UpdatedAt,
+			"completed_at":     job.CompletedAt,
+		}},
+	)
+	return err
+}
+
+// ListStale returns processing jobs claimed before the given time, oldest first.
+// Jobs claimed before started_at was recorded fall back to updated_at, which Claim also sets.
+func (s *Storage) ListStale(ctx context.Context, before time.Time, limit int) ([]model.ScoreJob, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	query := bson.M{
+		"status": model.StatusProcessing,
+		"$or": bson.A{
+			bson.M{"started_at": bson.M{"$lt": before}},
+			bson.M{"started_at": bson.M{"$exists": false}, "updated_at": bson.M{"$lt": before}},
+		},
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "updated_at", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []model.ScoreJob{}
+	if err = cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// Requeue moves a stale job back to queued. It only matches while the job is still the claim
+// that was found stale, so a job finished in the meantime is left alone.
+func (s *Storage) Requeue(ctx context.Context, job *model.ScoreJob) (bool, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": job.ID, "status": model.StatusProcessing, "attempts": job.Attempts},
+		bson.M{
+			"$set":   bson.M{"status": model.StatusQueued, "updated_at": time.Now()},
+			"$unset": bson.M{"started_at": ""},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
@@ -0,0 +1,121 @@
+package transport
+
+import (
+	"errors"
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+	"hub-service/module/grading"
+	"hub-service/module/scorejob/biz"
+	"hub-service/module/scorejob/model"
+	"hub-service/module/scorejob/storage"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EnqueueChallengeScoreJob godoc
+// @Summary Queue a challenge translation for grading
+// @Description Asynchronous version of /api/scores/ai-translate. Returns a job to poll at /api/score-jobs/{id} or stream at /api/score-jobs/{id}/events.
+// @Tags score-jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.EnqueueChallengeJobRequest true "Challenge scoring request"
+// @Param no_cache query bool false "Admins only: skip the grading cache"
+// @Success 202 {object} common.Response{data=model.ScoreJob} "Accepted"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 503 {object} common.AppError "Kafka not configured"
+// @Router /api/score-jobs/challenges [post]
+func EnqueueChallengeScoreJob(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req model.EnqueueChallengeJobRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		challengeID, err := primitive.ObjectIDFromHex(req.ChallengeID)
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		enqueue(c, appCtx, &model.ScoreJob{
+			Kind:            model.KindChallenge,
+			ChallengeID:     challengeID,
+			UserTranslation: req.UserTranslation,
+			Telemetry:       req.Telemetry,
+		})
+	}
+}
+
+// EnqueueSentenceScoreJob godoc
+// @Summary Queue a sentence translation for grading
+// @Description Asynchronous version of /api/translations/{id}/sentences/{sentence_index}/translate
+// @Tags score-jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Translation ID"
+// @Param sentence_index path int true "Sentence index"
+// @Param request body model.EnqueueSentenceJobRequest true "Sentence scoring request"
+// @Param no_cache query bool false "Admins only: skip the grading cache"
+// @Success 202 {object} common.Response{data=model.ScoreJob} "Accepted"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 503 {object} common.AppError "Kafka not configured"
+// @Router /api/score-jobs/translations/{id}/sentences/{sentence_index} [post]
+func EnqueueSentenceScoreJob(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		translationID, err := primitive.ObjectIDFromHex(c.Param("id"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		sentenceIndex, err := strconv.Atoi(c.Param("sentence_index"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+		if sentenceIndex < 0 {
+			panic(common.ErrInvalidRequest(errors.New("sentence index out of range")))
+		}
+
+		var req model.EnqueueSentenceJobRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		enqueue(c, appCtx, &model.ScoreJob{
+			Kind:            model.KindSentence,
+			TranslationID:   translationID,
+			SentenceIndex:   sentenceIndex,
+			UserTranslation: req.UserTranslation,
+			Telemetry:       req.Telemetry,
+		})
+	}
+}
+
+func enqueue(c *gin.Context, appCtx appctx.AppContext, job *model.ScoreJob) {
+	job.UserID = c.MustGet("user_id").(primitive.ObjectID)
+	job.FeedbackLanguage = grading.FeedbackLanguage(c, appCtx)
+
+	job.BypassCache = auth.IsAdmin(c) && c.Query("no_cache") == "true"
+
+	// Keep the interface nil when Kafka is absent so the biz can report it
+	var producer biz.Producer
+	if kafkaClient := appCtx.GetKafka(); kafkaClient != nil {
+		producer = kafkaClient
+	}
+
+	store := storage.NewStorage(appCtx.GetDatabase())
+	business := biz.NewEnqueueScoreJobBiz(store, producer)
+
+	result, err := business.Enqueue(c.Request.Context(), job)
+	if err != nil {
+		panic(err)
+	}
+
+	c.JSON(http.StatusAccepted, common.SimpleSuccessResponse(result))
+}
@@ -0,0 +1,119 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/module/scorejob/biz"
+	"hub-service/module/scorejob/storage"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	streamPollInterval = time.Second
+	streamTimeout      = 3 * time.Minute
+)
+
+// GetScoreJob godoc
+// @Summary Get a score job
+// @Description Poll the status of an asynchronous grading job; the result is included once it is completed
+// @Tags score-jobs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job ID"
+// @Success 200 {object} common.Response{data=model.ScoreJob} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Router /api/score-jobs/{id} [get]
+func GetScoreJob(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		jobID, err := primitive.ObjectIDFromHex(c.Param("id"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		userID := c.MustGet("user_id").(primitive.ObjectID)
+
+		store := storage.NewStorage(appCtx.GetDatabase())
+		business := biz.NewGetScoreJobBiz(store)
+
+		result, err := business.GetScoreJob(c.Request.Context(), jobID, userID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// StreamScoreJob godoc
+// @Summary Stream a score job
+// @Description Server-Sent Events stream emitting a "status" event whenever the job status changes and a final "result" event when it finishes
+// @Tags score-jobs
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param id path string true "Job ID"
+// @Success 200 {string} string "event stream"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Router /api/score-jobs/{id}/events [get]
+func StreamScoreJob(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		jobID, err := primitive.ObjectIDFromHex(c.Param("id"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		userID := c.MustGet("user_id").(primitive.ObjectID)
+
+		store := storage.NewStorage(appCtx.GetDatabase())
+		business := biz.NewGetScoreJobBiz(store)
+
+		// Fail before the stream starts so ownership errors are normal JSON responses
+		job, err := business.GetScoreJob(c.Request.Context(), jobID, userID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+
+		ticker := time.NewTicker(streamPollInterval)
+		defer ticker.Stop()
+		deadline := time.After(streamTimeout)
+		lastStatus := ""
+
+		c.Stream(func(w io.Writer) bool {
+			if job.Status != lastStatus {
+				lastStatus = job.Status
+				if job.IsFinished() {
+					c.SSEvent("result", job)
+					return false
+				}
+				c.SSEvent("status", gin.H{"id": job.ID, "status": job.Status})
+			}
+
+			select {
+			case <-c.Request.Context().Done():
+				return false
+			case <-deadline:
+				c.SSEvent("timeout", gin.H{"id": job.ID, "status": job.Status})
+				return false
+			case <-ticker.C:
+			}
+
+			latest, err := business.GetScoreJob(c.Request.Context(), jobID, userID)
+			if err != nil {
+				c.SSEvent("error", gin.H{"message": "failed to load score job"})
+				return false
+			}
+			job = latest
+			return true
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package transport
+
+import (
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+	"hub-service/middleware/quota"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
+	jobs := g.Group("/score-jobs")
+	jobs.Use(auth.AuthMiddleware(appCtx))
+	{
+		jobs.POST("/challenges", quota.RequireAIQuota(appCtx), EnqueueChallengeScoreJob(appCtx))
+		jobs.POST("/translations/:id/sentences/:sentence_index", quota.RequireAIQuota(appCtx), EnqueueSentenceScoreJob(appCtx))
+		jobs.GET("/:id", GetScoreJob(appCtx))
+		jobs.GET("/:id/events", StreamScoreJob(appCtx))
+	}
+}
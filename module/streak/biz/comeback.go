@@ -0,0 +1,108 @@
+package biz
+
+import (
+	"context"
+	emailmodel "hub-service/module/email/model"
+	"hub-service/module/email/templates"
+	"hub-service/module/streak/model"
+	usermodel "hub-service/module/user/model"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const comebackBatchSize = 200
+
+type ComebackStore interface {
+	ListInactive(ctx context.Context, cutoff time.Time, limit int64) ([]model.Streak, error)
+	ClaimComeback(ctx context.Context, streak *model.Streak, sentAt time.Time) (bool, error)
+	ReleaseComeback(ctx context.Context, streak *model.Streak, sentAt time.Time) error
+}
+
+type UserStore interface {
+	GetByID(ctx context.Context, id primitive.ObjectID) (*usermodel.User, error)
+}
+
+type EmailQueue interface {
+	QueueEmail(ctx context.Context, req *emailmodel.SendEmailRequest) (*emailmodel.EmailResponse, error)
+}
+
+type comebackBiz struct {
+	store  ComebackStore
+	users  UserStore
+	emails EmailQueue
+}
+
+func NewComebackBiz(store ComebackStore, users UserStore, emails EmailQueue) *comebackBiz {
+	return &comebackBiz{store: store, users: users, emails: emails}
+}
+
+// SendComebackEmails emails learners who have not submitted anything since before cutoff.
+// Each learner gets at most one email per inactive spell; it is sent again only after
+// they have come back and gone quiet once more. The email is claimed before it is queued,
+// so replicas running the job at the same time never both send it.
+func (biz *comebackBiz) SendComebackEmails(ctx context.Context, cutoff time.Time) (int, error) {
+	streaks, err := biz.store.ListInactive(ctx, cutoff, comebackBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	baseUrl := strings.TrimSuffix(os.Getenv("BASE_URL_TRANSMASTER_PROD"), "/")
+	if baseUrl == "" {
+		baseUrl = "https://transmaster.site"
+	}
+
+	sent := 0
+	for i := range streaks {
+		streak := &streaks[i]
+
+		// Claimed even when there is no one to email so the learner is not picked up again
+		sentAt := time.Now()
+		claimed, err := biz.store.ClaimComeback(ctx, streak, sentAt)
+		if err != nil {
+			log.Printf("Cannot claim comeback email for user %s: %v", streak.UserID.Hex(), err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		user, err := biz.users.GetByID(ctx, streak.UserID)
+		if err != nil {
+			log.Printf("Cannot load user %s for comeback email: %v", streak.UserID.Hex(), err)
+			biz.release(ctx, streak, sentAt)
+			continue
+		}
+		if user == nil || user.Email == "" {
+			continue
+		}
+
+		_, err = biz.emails.QueueEmail(ctx, &emailmodel.SendEmailRequest{
+			To:      []string{user.Email},
+			Subject: templates.GetComebackEmailSubject(),
+			HTMLBody: templates.GetComebackEmailHTML(templates.ComebackEmailData{
+				Name:           user.Name,
+				ActionUrl:      baseUrl,
+				UnsubscribeUrl: baseUrl + "/settings",
+			}),
+			Priority: emailmodel.EmailPriorityLow,
+		})
+		if err != nil {
+			log.Printf("Failed to queue comeback email for %s: %v", user.Email, err)
+			biz.release(ctx, streak, sentAt)
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+func (biz *comebackBiz) release(ctx context.Context, streak *model.Streak, sentAt time.Time) {
+	if err := biz.store.ReleaseComeback(ctx, streak, sentAt); err != nil {
+		log.Printf("Cannot release comeback email claim for user %s: %v", streak.UserID.Hex(), err)
+	}
+}
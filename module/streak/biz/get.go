@@ -0,0 +1,37 @@
+package biz
+
+import (
+	"context"
+	"hub-service/common"
+	"hub-service/module/streak/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type GetStreakStore interface {
+	GetByUserID(ctx context.Context, userID primitive.ObjectID) (*model.Streak, error)
+}
+
+type getStreakBiz struct {
+	store GetStreakStore
+}
+
+func NewGetStreakBiz(store GetStreakStore) *getStreakBiz {
+	return &getStreakBiz{store: store}
+}
+
+// GetStreak returns the learner's streak as of now, or the defaults when they have none yet
+func (biz *getStreakBiz) GetStreak(ctx context.Context, userID primitive.ObjectID) (*model.StreakSummary, error) {
+	streak, err := biz.store.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, common.ErrCannotGetEntity(model.CollectionName, err)
+	}
+
+	now := time.Now()
+	if streak == nil {
+		streak = newStreak(userID, now)
+	}
+
+	return summarize(streak, now), nil
+}
@@ -0,0 +1,45 @@
+package biz
+
+import (
+	"context"
+	scorebiz "hub-service/module/score/biz"
+	"hub-service/module/streak/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RecordStore interface {
+	GetByUserID(ctx context.Context, userID primitive.ObjectID) (*model.Streak, error)
+	Save(ctx context.Context, data *model.Streak) (bool, error)
+}
+
+// recorder counts graded submissions towards the learner's daily goal
+type recorder struct {
+	store RecordStore
+}
+
+// NewRecorder returns a SubmissionListener that keeps daily goals and streaks up to date
+func NewRecorder(store RecordStore) *recorder {
+	return &recorder{store: store}
+}
+
+func (r *recorder) OnSubmission(ctx context.Context, event *scorebiz.SubmissionEvent) error {
+	submittedAt := event.SubmittedAt
+	if submittedAt.IsZero() {
+		submittedAt = time.Now()
+	}
+
+	_, err := modify(ctx, r.store, event.UserID, submittedAt, func(streak *model.Streak) {
+		rollOver(streak, localDate(streak, submittedAt))
+		streak.Today.Submissions++
+		if event.Analysis != nil {
+			streak.Today.Points += event.Analysis.Score
+		}
+		checkGoal(streak)
+
+		streak.LastActiveAt = &submittedAt
+		streak.UpdatedAt = time.Now()
+	})
+	return err
+}
@@ -0,0 +1,197 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"hub-service/module/streak/model"
+	"math"
+	"time"
+
+	// The runtime image ships without a zoneinfo database
+	_ "time/tzdata"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DefaultGoalTarget = 1
+
+	// A new learner starts with one freeze and earns another every 7-day
+	// streak milestone, holding at most MaxFreezes at a time
+	InitialFreezes = 1
+	MaxFreezes     = 2
+	freezeEvery    = 7
+
+	maxFrozenDates = 30
+
+	// How often a streak is read and changed again after losing a race with another write
+	maxSaveAttempts = 5
+)
+
+var errStreakContended = errors.New("streak kept changing while saving")
+
+type modifyStore interface {
+	GetByUserID(ctx context.Context, userID primitive.ObjectID) (*model.Streak, error)
+	Save(ctx context.Context, data *model.Streak) (bool, error)
+}
+
+// modify reads the learner's streak, or the defaults when they have none yet, applies change
+// and saves it. When another write got in first it starts over from a fresh read.
+func modify(ctx context.Context, store modifyStore, userID primitive.ObjectID, now time.Time, change func(streak *model.Streak)) (*model.Streak, error) {
+	for i := 0; i < maxSaveAttempts; i++ {
+		streak, err := store.GetByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if streak == nil {
+			streak = newStreak(userID, now)
+		}
+
+		change(streak)
+
+		saved, err := store.Save(ctx, streak)
+		if err != nil {
+			return nil, err
+		}
+		if saved {
+			return streak, nil
+		}
+	}
+	return nil, errStreakContended
+}
+
+// newStreak returns the default streak for a learner who has not set a goal yet
+func newStreak(userID primitive.ObjectID, now time.Time) *model.Streak {
+	return &model.Streak{
+		UserID:           userID,
+		Timezone:         "UTC",
+		GoalType:         model.GoalTypeChallenges,
+		GoalTarget:       DefaultGoalTarget,
+		FreezesAvailable: InitialFreezes,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
+func location(timezone string) *time.Location {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" {
+		return time.UTC
+	}
+	return loc
+}
+
+// localDate is the learner's calendar day at t
+func localDate(s *model.Streak, t time.Time) string {
+	return t.In(location(s.Timezone)).Format(model.DateLayout)
+}
+
+// daysBetween counts calendar days from one local date to another
+func daysBetween(from, to string) int {
+	a, errA := time.Parse(model.DateLayout, from)
+	b, errB := time.Parse(model.DateLayout, to)
+	if errA != nil || errB != nil {
+		return 0
+	}
+	return int(math.Round(b.Sub(a).Hours() / 24))
+}
+
+// rollOver starts a fresh day of progress once the learner's local date has moved on
+func rollOver(s *model.Streak, today string) {
+	if s.Today.Date < today {
+		s.Today = model.DayProgress{Date: today}
+	}
+}
+
+func goalReached(s *model.Streak) bool {
+	if s.GoalType == model.GoalTypePoints {
+		return s.Today.Points >= float64(s.GoalTarget)
+	}
+	return s.Today.Submissions >= s.GoalTarget
+}
+
+// checkGoal extends the streak the first time today's progress reaches the goal
+func checkGoal(s *model.Streak) {
+	if s.Today.GoalMet || !goalReached(s) {
+		return
+	}
+	s.Today.GoalMet = true
+	completeDay(s, s.Today.Date)
+}
+
+// completeDay counts day towards the streak. Days missed since the last completed
+// day are covered by freezes when enough are left, otherwise the streak starts over.
+func completeDay(s *model.Streak, day string) {
+	gap := 1
+	if s.LastGoalDate != "" {
+		gap = daysBetween(s.LastGoalDate, day)
+	}
+	if gap <= 0 {
+		return
+	}
+
+	missed := gap - 1
+	switch {
+	case s.LastGoalDate == "" || missed > s.FreezesAvailable:
+		s.CurrentStreak = 1
+	default:
+		for i := 1; i <= missed; i++ {
+			s.FrozenDates = append(s.FrozenDates, shiftDate(s.LastGoalDate, i))
+		}
+		if len(s.FrozenDates) > maxFrozenDates {
+			s.FrozenDates = s.FrozenDates[len(s.FrozenDates)-maxFrozenDates:]
+		}
+		s.FreezesAvailable -= missed
+		s.CurrentStreak++
+	}
+
+	s.LastGoalDate = day
+	if s.CurrentStreak > s.LongestStreak {
+		s.LongestStreak = s.CurrentStreak
+	}
+	if s.CurrentStreak%freezeEvery == 0 && s.FreezesAvailable < MaxFreezes {
+		s.FreezesAvailable++
+	}
+}
+
+func shiftDate(date string, days int) string {
+	t, err := time.Parse(model.DateLayout, date)
+	if err != nil {
+		return date
+	}
+	return t.AddDate(0, 0, days).Format(model.DateLayout)
+}
+
+// summarize reports the streak as of now without modifying it
+func summarize(s *model.Streak, now time.Time) *model.StreakSummary {
+	today := localDate(s, now)
+
+	progress := s.Today
+	if progress.Date != today {
+		progress = model.DayProgress{Date: today}
+	}
+
+	current := s.CurrentStreak
+	if s.LastGoalDate != "" && daysBetween(s.LastGoalDate, today)-1 > s.FreezesAvailable {
+		current = 0
+	}
+
+	done := float64(progress.Submissions)
+	if s.GoalType == model.GoalTypePoints {
+		done = progress.Points
+	}
+
+	return &model.StreakSummary{
+		Timezone:         s.Timezone,
+		GoalType:         s.GoalType,
+		GoalTarget:       s.GoalTarget,
+		CurrentStreak:    current,
+		LongestStreak:    s.LongestStreak,
+		LastGoalDate:     s.LastGoalDate,
+		FreezesAvailable: s.FreezesAvailable,
+		Today:            progress,
+		GoalProgress:     math.Min(1, done/float64(s.GoalTarget)),
+		AtRisk:           current > 0 && !progress.GoalMet,
+		Reminders:        !s.RemindersOff,
+	}
+}
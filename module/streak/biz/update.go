@@ -0,0 +1,63 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"hub-service/common"
+	"hub-service/module/streak/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrInvalidTimezone = common.NewErrorResponse(
+	errors.New("invalid timezone"),
+	"Timezone must be an IANA name such as Europe/Berlin",
+	"invalid timezone",
+	"ErrInvalidTimezone",
+)
+
+type UpdateStreakStore interface {
+	GetByUserID(ctx context.Context, userID primitive.ObjectID) (*model.Streak, error)
+	Save(ctx context.Context, data *model.Streak) (bool, error)
+}
+
+type updateStreakBiz struct {
+	store UpdateStreakStore
+}
+
+func NewUpdateStreakBiz(store UpdateStreakStore) *updateStreakBiz {
+	return &updateStreakBiz{store: store}
+}
+
+// UpdateSettings changes the learner's daily goal and timezone. Progress already made today
+// counts towards the new goal.
+func (biz *updateStreakBiz) UpdateSettings(ctx context.Context, userID primitive.ObjectID, req *model.UpdateStreakSettingsRequest) (*model.StreakSummary, error) {
+	if req.Timezone != "" {
+		if _, err := time.LoadLocation(req.Timezone); err != nil {
+			return nil, ErrInvalidTimezone
+		}
+	}
+
+	now := time.Now()
+	streak, err := modify(ctx, biz.store, userID, now, func(streak *model.Streak) {
+		streak.GoalType = req.GoalType
+		streak.GoalTarget = req.GoalTarget
+		if req.Timezone != "" {
+			streak.Timezone = req.Timezone
+		}
+		if req.Reminders != nil {
+			streak.RemindersOff = !*req.Reminders
+		}
+
+		if streak.Today.Date == localDate(streak, now) {
+			checkGoal(streak)
+		}
+		streak.UpdatedAt = now
+	})
+	if err != nil {
+		return nil, common.ErrCannotUpdateEntity(model.CollectionName, err)
+	}
+
+	return summarize(streak, now), nil
+}
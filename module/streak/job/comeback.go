@@ -0,0 +1,119 @@
+// Package job sends comeback emails to learners who have stopped practising
+package job
+
+import (
+	"context"
+	"hub-service/core/appctx"
+	emailbiz "hub-service/module/email/biz"
+	emailrepository "hub-service/module/email/repository"
+	"hub-service/module/streak/biz"
+	"hub-service/module/streak/storage"
+	userstorage "hub-service/module/user/storage"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	comebackInterval     = 1 * time.Hour
+	defaultComebackAfter = 3
+)
+
+// ComebackAfter returns how long a learner must be inactive before they get a comeback email
+func ComebackAfter() time.Duration {
+	days := defaultComebackAfter
+	if raw := os.Getenv("COMEBACK_AFTER_DAYS"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			log.Printf("Invalid COMEBACK_AFTER_DAYS %q, using %d", raw, defaultComebackAfter)
+		} else {
+			days = n
+		}
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+type ComebackScheduler struct {
+	appCtx  appctx.AppContext
+	after   time.Duration
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	running bool
+	mu      sync.Mutex
+}
+
+func NewComebackScheduler(appCtx appctx.AppContext) *ComebackScheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &ComebackScheduler{
+		appCtx: appCtx,
+		after:  ComebackAfter(),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start starts the hourly comeback email job
+func (s *ComebackScheduler) Start() {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	log.Println("Starting comeback email scheduler...")
+
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Stop stops the scheduler
+func (s *ComebackScheduler) Stop() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = false
+	s.mu.Unlock()
+
+	s.cancel()
+	s.wg.Wait()
+	log.Println("Comeback email scheduler stopped")
+}
+
+func (s *ComebackScheduler) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(comebackInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.sendComebackEmails()
+		}
+	}
+}
+
+func (s *ComebackScheduler) sendComebackEmails() {
+	db := s.appCtx.GetDatabase()
+	emails := emailbiz.NewEmailBusiness(emailrepository.NewEmailRepository(db.MongoDB.Database), s.appCtx.GetKafka(), s.appCtx.GetRedis())
+
+	business := biz.NewComebackBiz(storage.NewStorage(db), userstorage.NewUserStorage(s.appCtx), emails)
+	sent, err := business.SendComebackEmails(s.ctx, time.Now().Add(-s.after))
+	if err != nil {
+		log.Printf("Error sending comeback emails: %v", err)
+		return
+	}
+	if sent > 0 {
+		log.Printf("Queued %d comeback emails", sent)
+	}
+}
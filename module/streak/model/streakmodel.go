@@ -0,0 +1,78 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const CollectionName = "user_streaks"
+
+// Goal types. Both count challenge and passage sentence submissions alike.
+const (
+	GoalTypeChallenges = "challenges"
+	GoalTypePoints     = "points"
+)
+
+// DateLayout is the layout of the local calendar dates stored on a streak
+const DateLayout = "2006-01-02"
+
+// Streak is a learner's daily goal and streak, one document per user.
+// Dates are calendar days in the learner's timezone.
+type Streak struct {
+	ID               primitive.ObjectID `json:"-" bson:"_id,omitempty"`
+	UserID           primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Timezone         string             `json:"timezone" bson:"timezone"`
+	GoalType         string             `json:"goal_type" bson:"goal_type"`
+	GoalTarget       int                `json:"goal_target" bson:"goal_target"`
+	CurrentStreak    int                `json:"current_streak" bson:"current_streak"`
+	LongestStreak    int                `json:"longest_streak" bson:"longest_streak"`
+	LastGoalDate     string             `json:"last_goal_date,omitempty" bson:"last_goal_date,omitempty"`
+	FreezesAvailable int                `json:"freezes_available" bson:"freezes_available"`
+	FrozenDates      []string           `json:"frozen_dates,omitempty" bson:"frozen_dates,omitempty"`
+	Today            DayProgress        `json:"today" bson:"today"`
+	RemindersOff     bool               `json:"-" bson:"reminders_off"`
+	LastActiveAt     *time.Time         `json:"last_active_at,omitempty" bson:"last_active_at,omitempty"`
+	ComebackSentAt   *time.Time         `json:"-" bson:"comeback_sent_at,omitempty"`
+	CreatedAt        time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at" bson:"updated_at"`
+	// Version is raised by every write so a save based on a stale read is refused
+	Version int64 `json:"-" bson:"version"`
+}
+
+func (Streak) TableName() string {
+	return CollectionName
+}
+
+// DayProgress is what the learner did on one local day
+type DayProgress struct {
+	Date        string  `json:"date" bson:"date"`
+	Submissions int     `json:"submissions" bson:"submissions"`
+	Points      float64 `json:"points" bson:"points"`
+	GoalMet     bool    `json:"goal_met" bson:"goal_met"`
+}
+
+// StreakSummary is the streak as of now, as shown to the learner. A streak whose
+// missed days can no longer be covered by freezes is reported as 0 before it is reset.
+type StreakSummary struct {
+	Timezone         string      `json:"timezone"`
+	GoalType         string      `json:"goal_type"`
+	GoalTarget       int         `json:"goal_target"`
+	CurrentStreak    int         `json:"current_streak"`
+	LongestStreak    int         `json:"longest_streak"`
+	LastGoalDate     string      `json:"last_goal_date,omitempty"`
+	FreezesAvailable int         `json:"freezes_available"`
+	Today            DayProgress `json:"today"`
+	GoalProgress     float64     `json:"goal_progress" example:"0.5"`
+	AtRisk           bool        `json:"at_risk"`
+	Reminders        bool        `json:"reminders"`
+}
+
+// UpdateStreakSettingsRequest changes the daily goal, the timezone days are counted in
+// and whether comeback reminder emails are sent
+type UpdateStreakSettingsRequest struct {
+	GoalType   string `json:"goal_type" binding:"required,oneof=challenges points" example:"challenges"`
+	GoalTarget int    `json:"goal_target" binding:"required,min=1,max=1000" example:"3"`
+	Timezone   string `json:"timezone" example:"Asia/Ho_Chi_Minh"`
+	Reminders  *bool  `json:"reminders" example:"true"`
+}
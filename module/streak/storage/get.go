@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/streak/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Storage) GetByUserID(ctx context.Context, userID primitive.ObjectID) (*model.Streak, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	var streak model.Streak
+	if err := collection.FindOne(ctx, bson.M{"user_id": userID}).Decode(&streak); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &streak, nil
+}
+
+// ListInactive returns streaks of learners who want reminders, were last active before cutoff
+// and have not been sent a comeback email since
+func (s *Storage) ListInactive(ctx context.Context, cutoff time.Time, limit int64) ([]model.Streak, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	filter := bson.M{
+		"last_active_at": bson.M{"$lt": cutoff},
+		"reminders_off":  bson.M{"$ne": true},
+		"$or": []bson.M{
+			{"comeback_sent_at": bson.M{"$exists": false}},
+			{"$expr": bson.M{"$lt": []string{"$comeback_sent_at", "$last_active_at"}}},
+		},
+	}
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	streaks := []model.Streak{}
+	if err = cursor.All(ctx, &streaks); err != nil {
+		return nil, err
+	}
+
+	return streaks, nil
+}
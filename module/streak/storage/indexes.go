@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/streak/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureIndexes keeps one streak per user, so two first submissions cannot both create one
+func (s *Storage) EnsureIndexes(ctx context.Context) error {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/streak/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Save writes the whole streak document, creating it on first use. It reports false without
+// writing when the streak changed since it was read, so the caller can read it again.
+func (s *Storage) Save(ctx context.Context, data *model.Streak) (bool, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	if data.ID.IsZero() {
+		data.ID = primitive.NewObjectID()
+		data.Version = 1
+		if _, err := collection.InsertOne(ctx, data); err != nil {
+			data.ID, data.Version = primitive.NilObjectID, 0
+			// Another submission created the learner's streak first
+			if mongo.IsDuplicateKeyError(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+
+	filter := bson.M{"_id": data.ID, "version": data.Version}
+	if data.Version == 0 {
+		// Streaks saved before versioning have no version field
+		filter["version"] = bson.M{"$in": bson.A{0, nil}}
+	}
+
+	data.Version++
+	result, err := collection.ReplaceOne(ctx, filter, data)
+	if err != nil || result.MatchedCount == 0 {
+		data.Version--
+		return false, err
+	}
+	return true, nil
+}
+
+// ClaimComeback marks the comeback email of the streak's current inactive spell as sent. It
+// reports false when the learner was active again or another replica claimed the email first.
+func (s *Storage) ClaimComeback(ctx context.Context, streak *model.Streak, sentAt time.Time) (bool, error) {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	filter := bson.M{"_id": streak.ID, "last_active_at": streak.LastActiveAt}
+	if streak.ComebackSentAt == nil {
+		filter["comeback_sent_at"] = bson.M{"$exists": false}
+	} else {
+		filter["comeback_sent_at"] = streak.ComebackSentAt
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, bson.M{
+		"$set": bson.M{"comeback_sent_at": sentAt},
+		"$inc": bson.M{"version": 1},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// ReleaseComeback undoes ClaimComeback when the email could not be queued, so the next run
+// tries again
+func (s *Storage) ReleaseComeback(ctx context.Context, streak *model.Streak, sentAt time.Time) error {
+	collection := s.db.MongoDB.GetCollection(model.CollectionName)
+
+	update := bson.M{"$inc": bson.M{"version": 1}}
+	if streak.ComebackSentAt == nil {
+		update["$unset"] = bson.M{"comeback_sent_at": ""}
+	} else {
+		update["$set"] = bson.M{"comeback_sent_at": streak.ComebackSentAt}
+	}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": streak.ID, "comeback_sent_at": sentAt}, update)
+	return err
+}
@@ -0,0 +1,17 @@
+package transport
+
+import (
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
+	streaks := g.Group("/streaks")
+	streaks.Use(auth.AuthMiddleware(appCtx))
+	{
+		streaks.GET("/me", GetMyStreak(appCtx))
+		streaks.PUT("/me", UpdateMyStreakSettings(appCtx))
+	}
+}
@@ -0,0 +1,67 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/module/streak/biz"
+	"hub-service/module/streak/model"
+	"hub-service/module/streak/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetMyStreak godoc
+// @Summary Get my daily goal and streak
+// @Description The caller's daily goal, today's progress in their timezone, current and longest streak and remaining streak freezes. A missed day is covered by a freeze when the learner next meets their goal.
+// @Tags streaks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} common.Response{data=model.StreakSummary} "Success"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Router /api/streaks/me [get]
+func GetMyStreak(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("user_id").(primitive.ObjectID)
+
+		business := biz.NewGetStreakBiz(storage.NewStorage(appCtx.GetDatabase()))
+		result, err := business.GetStreak(c.Request.Context(), userID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// UpdateMyStreakSettings godoc
+// @Summary Set my daily goal
+// @Description Set the daily goal as a number of graded submissions or points, the IANA timezone days are counted in, and whether to receive comeback reminder emails.
+// @Tags streaks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.UpdateStreakSettingsRequest true "Goal settings"
+// @Success 200 {object} common.Response{data=model.StreakSummary} "Success"
+// @Failure 400 {object} common.AppError "Invalid goal or timezone"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Router /api/streaks/me [put]
+func UpdateMyStreakSettings(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req model.UpdateStreakSettingsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		userID := c.MustGet("user_id").(primitive.ObjectID)
+
+		business := biz.NewUpdateStreakBiz(storage.NewStorage(appCtx.GetDatabase()))
+		result, err := business.UpdateSettings(c.Request.Context(), userID, &req)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
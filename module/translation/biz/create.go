@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"hub-service/infrastructure/external/deepl"
 	"hub-service/module/translation/model"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -15,17 +16,24 @@ type CreateTranslationStore interface {
 	CreateSentence(ctx context.Context, data *model.TranslationSentenceCreate) error
 }
 
+// MachineTranslator generates machine reference translations; nil when DeepL is not configured
+type MachineTranslator interface {
+	TranslateMany(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error)
+}
+
 type createTranslationBiz struct {
-	store   CreateTranslationStore
-	apiKey  string
-	baseURL string
+	store      CreateTranslationStore
+	translator MachineTranslator
+	apiKey     string
+	baseURL    string
 }
 
-func NewCreateTranslationBiz(store CreateTranslationStore, apiKey, baseURL string) *createTranslationBiz {
+func NewCreateTranslationBiz(store CreateTranslationStore, translator MachineTranslator, apiKey, baseURL string) *createTranslationBiz {
 	return &createTranslationBiz{
-		store:   store,
-		apiKey:  apiKey,
-		baseURL: baseURL,
+		store:      store,
+		translator: translator,
+		apiKey:     apiKey,
+		baseURL:    baseURL,
 	}
 }
 
@@ -33,6 +41,22 @@ func (biz *createTranslationBiz) CreateTranslation(ctx context.Context, data *mo
 	// Split content into sentences using improved splitter
 	splitter := NewSentenceSplitter()
 	sentences := splitter.SplitIntoSentencesAdvanced(data.Content)
+	for i := range sentences {
+		sentences[i] = strings.TrimSpace(sentences[i])
+	}
+
+	// Translate before writing anything so a DeepL failure leaves no partial passage
+	var machineTranslations []string
+	if data.GenerateReferences {
+		if biz.translator == nil {
+			return nil, deepl.ErrTranslatorUnavailable
+		}
+		translations, err := biz.translator.TranslateMany(ctx, sentences, data.SourceLang, data.TargetLang)
+		if err != nil {
+			return nil, deepl.ErrTranslationFailed(err)
+		}
+		machineTranslations = translations
+	}
 
 	// Calculate total score (each sentence worth 10 points by default)
 	totalScore := float64(len(sentences)) * 10.0
@@ -76,11 +100,14 @@ func (biz *createTranslationBiz) CreateTranslation(ctx context.Context, data *mo
 			ID:            primitive.NewObjectID(),
 			TranslationID: translation.ID,
 			SentenceIndex: i,
-			Content:       strings.TrimSpace(sentence),
+			Content:       sentence,
 			MaxScore:      10.0, // Each sentence worth 10 points
 			CreatedAt:     &translation.CreatedAt,
 			UpdatedAt:     &translation.UpdatedAt,
 		}
+		if machineTranslations != nil {
+			sentenceData.MachineTranslation = machineTranslations[i]
+		}
 
 		if err := biz.store.CreateSentence(ctx, sentenceData); err != nil {
 			return nil, err
@@ -66,9 +66,12 @@ func (biz *getTranslationBiz) GetTranslationWithUserProgress(ctx context.Context
 		totalPossibleScore += sentence.MaxScore
 	}
 
-	// Calculate user's total score and completed count
+	// Calculate user's total score and completed count. Sentences with a pending or confirmed
+	// paste flag stay out of the score until cleared.
 	for _, score := range userScores {
-		totalUserScore += score.BestScore
+		if score.PasteFlag.CountsTowardTotals() {
+			totalUserScore += score.BestScore
+		}
 		completedCount++
 	}
 
@@ -0,0 +1,47 @@
+package biz
+
+import (
+	"context"
+	"strings"
+
+	common "hub-service/common"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SetSentenceReferencesStore interface {
+	SetSentenceReferences(ctx context.Context, translationID primitive.ObjectID, sentenceIndex int, references []string, machineTranslation *string) (bool, error)
+}
+
+type setSentenceReferencesBiz struct {
+	store SetSentenceReferencesStore
+}
+
+func NewSetSentenceReferencesBiz(store SetSentenceReferencesStore) *setSentenceReferencesBiz {
+	return &setSentenceReferencesBiz{store: store}
+}
+
+// SetSentenceReferences replaces the accepted translations of a sentence, and its machine translation
+// when one is given; empty values remove them
+func (biz *setSentenceReferencesBiz) SetSentenceReferences(ctx context.Context, translationID primitive.ObjectID, sentenceIndex int, references []string, machineTranslation *string) error {
+	cleaned := make([]string, 0, len(references))
+	for _, reference := range references {
+		if reference = strings.TrimSpace(reference); reference != "" {
+			cleaned = append(cleaned, reference)
+		}
+	}
+
+	if machineTranslation != nil {
+		trimmed := strings.TrimSpace(*machineTranslation)
+		machineTranslation = &trimmed
+	}
+
+	found, err := biz.store.SetSentenceReferences(ctx, translationID, sentenceIndex, cleaned, machineTranslation)
+	if err != nil {
+		return common.ErrCannotUpdateEntity("TranslationSentence", err)
+	}
+	if !found {
+		return common.ErrEntityNotFound("TranslationSentence", common.RecordNotFound)
+	}
+	return nil
+}
@@ -4,14 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
-	"io"
-	"net/http"
-	"strings"
 	"time"
 
 	common "hub-service/common"
+	scorebiz "hub-service/module/score/biz"
+	scoremodel "hub-service/module/score/model"
 	"hub-service/module/translation/model"
+	usagebiz "hub-service/module/usage/biz"
+	usagemodel "hub-service/module/usage/model"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -26,22 +26,26 @@ type SubmitTranslationStore interface {
 }
 
 type submitTranslationBiz struct {
-	store   SubmitTranslationStore
-	apiKey  string
-	baseURL string
-	client  *http.Client
+	store         SubmitTranslationStore
+	analyzer      scorebiz.GeminiAnalyzer
+	languagePairs scorebiz.LanguagePairs
+	hints         scorebiz.HintLedger
+	paste         scorebiz.PasteDetector
+	listeners     []scorebiz.SubmissionListener
 }
 
-func NewSubmitTranslationBiz(store SubmitTranslationStore, apiKey, baseURL string) *submitTranslationBiz {
+func NewSubmitTranslationBiz(store SubmitTranslationStore, analyzer scorebiz.GeminiAnalyzer, languagePairs scorebiz.LanguagePairs, hints scorebiz.HintLedger, paste scorebiz.PasteDetector, listeners ...scorebiz.SubmissionListener) *submitTranslationBiz {
 	return &submitTranslationBiz{
-		store:   store,
-		apiKey:  apiKey,
-		baseURL: baseURL,
-		client:  &http.Client{},
+		store:         store,
+		analyzer:      analyzer,
+		languagePairs: languagePairs,
+		hints:         hints,
+		paste:         paste,
+		listeners:     listeners,
 	}
 }
 
-func (biz *submitTranslationBiz) SubmitSentenceTranslation(ctx context.Context, translationID primitive.ObjectID, sentenceIndex int, userTranslation string, userID primitive.ObjectID) (*model.SubmitSentenceTranslationResponse, error) {
+func (biz *submitTranslationBiz) SubmitSentenceTranslation(ctx context.Context, translationID primitive.ObjectID, sentenceIndex int, userTranslation string, telemetry *scoremodel.Telemetry, userID primitive.ObjectID, feedbackLanguage string) (*model.SubmitSentenceTranslationResponse, error) {
 	// Get translation and sentence
 	translation, err := biz.store.GetTranslation(ctx, translationID)
 	if err != nil {
@@ -53,7 +57,7 @@ func (biz *submitTranslationBiz) SubmitSentenceTranslation(ctx context.Context,
 		return nil, err
 	}
 
-	if sentenceIndex >= len(sentences) {
+	if sentenceIndex < 0 || sentenceIndex >= len(sentences) {
 		return nil, common.ErrInvalidRequest(errors.New("sentence index out of range"))
 	}
 
@@ -63,20 +67,52 @@ func (biz *submitTranslationBiz) SubmitSentenceTranslation(ctx context.Context,
 	existingScore, _ := biz.store.GetUserScore(ctx, userID, translationID, sentenceIndex)
 
 	// Calculate score using AI
-	score, feedback, errors, suggestions, err := biz.calculateScore(sentence.Content, userTranslation, translation.TargetLang)
+	gradeReq, err := biz.languagePairs.NewGradeRequest(sentence.Content, userTranslation, translation.SourceLang, translation.TargetLang, feedbackLanguage)
 	if err != nil {
 		return nil, err
 	}
+	gradeReq.References = sentence.GradingReferences()
+	gradeReq.MachineTranslation = sentence.MachineTranslation
+
+	analysis, err := biz.analyzer.AnalyzeGrammar(usagebiz.WithCaller(ctx, usagemodel.FeatureSentence, userID), gradeReq)
+	if err != nil {
+		return nil, err
+	}
+
+	penalty, err := scorebiz.ApplyHintPenalty(ctx, biz.hints, &scorebiz.HintTarget{
+		Kind:          scorebiz.SubmissionKindSentence,
+		UserID:        userID,
+		TranslationID: translationID,
+		SentenceIndex: sentenceIndex,
+	}, analysis)
+	if err != nil {
+		return nil, err
+	}
+	score, feedback, errors, suggestions := flattenAnalysis(analysis)
 
 	now := time.Now()
 	attemptCount := 1
 	bestScore := score
+	previousBest := 0.0
 	isNewBest := true
 
 	if existingScore != nil {
-		// Update existing score
 		attemptCount = existingScore.AttemptCount + 1
+	}
+	pasteFlag := scorebiz.DetectPaste(ctx, biz.paste, &scorebiz.PasteCheck{
+		Content:            sentence.Content,
+		UserTranslation:    userTranslation,
+		SourceLang:         translation.SourceLang,
+		TargetLang:         translation.TargetLang,
+		MachineTranslation: sentence.MachineTranslation,
+		Telemetry:          telemetry,
+		AttemptNumber:      attemptCount,
+	})
+
+	if existingScore != nil {
+		// Update existing score
 		bestScore = existingScore.BestScore
+		previousBest = existingScore.BestScore
 		isNewBest = false
 
 		if score > existingScore.BestScore {
@@ -96,6 +132,8 @@ func (biz *submitTranslationBiz) SubmitSentenceTranslation(ctx context.Context,
 			Suggestions:     suggestions,
 			AttemptCount:    attemptCount,
 			BestScore:       bestScore,
+			PromptVersion:   analysis.PromptVersion,
+			PasteFlag:       pasteFlag,
 			CreatedAt:       existingScore.CreatedAt,
 			UpdatedAt:       now,
 		}
@@ -115,6 +153,8 @@ func (biz *submitTranslationBiz) SubmitSentenceTranslation(ctx context.Context,
 			Suggestions:     suggestions,
 			AttemptCount:    attemptCount,
 			BestScore:       bestScore,
+			PromptVersion:   analysis.PromptVersion,
+			PasteFlag:       pasteFlag,
 			CreatedAt:       now,
 			UpdatedAt:       now,
 		}
@@ -123,9 +163,40 @@ func (biz *submitTranslationBiz) SubmitSentenceTranslation(ctx context.Context,
 	}
 
 	if err != nil {
+		scorebiz.ReturnHintPenalty(ctx, biz.hints, penalty)
 		return nil, err
 	}
 
+	// An open flag survives a clean resubmission, since the update leaves it in place
+	var previousFlag *scoremodel.PasteFlag
+	if existingScore != nil {
+		previousFlag = existingScore.PasteFlag
+	}
+	currentFlag := pasteFlag
+	if currentFlag == nil {
+		currentFlag = previousFlag
+	}
+
+	scorebiz.NotifySubmission(ctx, biz.listeners, &scorebiz.SubmissionEvent{
+		Kind:              scorebiz.SubmissionKindSentence,
+		UserID:            userID,
+		TranslationID:     translationID,
+		SentenceID:        sentence.ID,
+		SentenceIndex:     sentenceIndex,
+		OriginalContent:   sentence.Content,
+		UserTranslation:   userTranslation,
+		Analysis:          analysis,
+		AttemptCount:      attemptCount,
+		PreviousBest:      previousBest,
+		BestScore:         bestScore,
+		IsNewBest:         isNewBest,
+		HintsUsed:         penalty.HintsUsed,
+		HintPenalty:       penalty.Points,
+		PasteFlag:         currentFlag,
+		PreviousPasteFlag: previousFlag,
+		SubmittedAt:       now,
+	})
+
 	// Calculate total user score and progress
 	userScores, err := biz.store.GetUserScoresByTranslation(ctx, userID, translationID)
 	if err != nil {
@@ -134,7 +205,9 @@ func (biz *submitTranslationBiz) SubmitSentenceTranslation(ctx context.Context,
 
 	totalUserScore := 0.0
 	for _, userScore := range userScores {
-		totalUserScore += userScore.BestScore
+		if userScore.PasteFlag.CountsTowardTotals() {
+			totalUserScore += userScore.BestScore
+		}
 	}
 
 	// Calculate total possible score from all sentences
@@ -158,157 +231,21 @@ func (biz *submitTranslationBiz) SubmitSentenceTranslation(ctx context.Context,
 		AttemptCount:    attemptCount,
 		BestScore:       bestScore,
 		IsNewBest:       isNewBest,
+		HintsUsed:       penalty.HintsUsed,
+		HintPenalty:     penalty.Points,
 		TotalUserScore:  totalUserScore,
 		ProgressPercent: progressPercent,
 	}, nil
 }
 
-// Helper function to calculate score using AI
-func (biz *submitTranslationBiz) calculateScore(original, translation, targetLanguage string) (float64, string, string, string, error) {
-	promptText := fmt.Sprintf(GeminiGrammarPrompt, original, translation, targetLanguage)
-
-	req := GeminiRequest{
-		Contents: []Content{
-			{
-				Parts: []Part{
-					{Text: promptText},
-				},
-			},
-		},
-	}
-
-	jsonData, err := json.Marshal(req)
-	if err != nil {
-		return 0, "", "", "", errors.New("failed to marshal request")
-	}
-
-	httpReq, err := http.NewRequestWithContext(context.Background(), "POST", biz.baseURL, strings.NewReader(string(jsonData)))
-	if err != nil {
-		return 0, "", "", "", errors.New("failed to create request")
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("x-goog-api-key", biz.apiKey)
-
-	resp, err := biz.client.Do(httpReq)
-	if err != nil {
-		return 0, "", "", "", errors.New("failed to execute request")
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return 0, "", "", "", fmt.Errorf("status code: %d, body: %s", resp.StatusCode, string(body))
-	}
-
-	var geminiResp GeminiResponse
-	if err := json.NewDecoder(resp.Body).Decode(&geminiResp); err != nil {
-		return 0, "", "", "", errors.New("failed to decode response")
-	}
-
-	if len(geminiResp.Candidates) == 0 || len(geminiResp.Candidates[0].Content.Parts) == 0 {
-		return 0, "", "", "", errors.New("no response from Gemini")
-	}
-
-	responseText := geminiResp.Candidates[0].Content.Parts[0].Text
-	responseText = strings.TrimSpace(responseText)
-	if strings.HasPrefix(responseText, "```json") {
-		responseText = strings.TrimPrefix(responseText, "```json")
-		responseText = strings.TrimSpace(responseText)
-	}
-	if strings.HasPrefix(responseText, "```") {
-		responseText = strings.TrimPrefix(responseText, "```")
-		responseText = strings.TrimSpace(responseText)
-	}
-	if strings.HasSuffix(responseText, "```") {
-		responseText = strings.TrimSuffix(responseText, "```")
-		responseText = strings.TrimSpace(responseText)
-	}
-
-	var analysis GrammarAnalysis
-	if err := json.Unmarshal([]byte(responseText), &analysis); err != nil {
-		return 0, "", "", "", errors.New("failed to parse Gemini analysis")
-	}
-
-	errors := ""
-	if len(analysis.Errors) > 0 {
-		b, _ := json.Marshal(analysis.Errors)
-		errors = string(b)
-	}
+// flattenAnalysis converts a grading result into the fields stored on a sentence score
+func flattenAnalysis(analysis *scorebiz.GrammarAnalysis) (float64, string, scoremodel.ErrorList, string) {
+	errors := scorebiz.StoredErrors(analysis.Errors)
 	suggestions := ""
 	if len(analysis.Suggestions) > 0 {
 		b, _ := json.Marshal(analysis.Suggestions)
 		suggestions = string(b)
 	}
 
-	return analysis.Score, analysis.Feedback, errors, suggestions, nil
-}
-
-// Gemini API types
-type GeminiRequest struct {
-	Contents []Content `json:"contents"`
-}
-
-type Content struct {
-	Parts []Part `json:"parts"`
+	return analysis.Score, analysis.Feedback, errors, suggestions
 }
-
-type Part struct {
-	Text string `json:"text"`
-}
-
-type GeminiResponse struct {
-	Candidates []Candidate `json:"candidates"`
-}
-
-type Candidate struct {
-	Content Content `json:"content"`
-}
-
-type GrammarAnalysis struct {
-	Score       float64  `json:"score"`
-	Errors      []Error  `json:"errors"`
-	Suggestions []string `json:"suggestions"`
-	Feedback    string   `json:"feedback"`
-}
-
-type Error struct {
-	Type        string `json:"type"`
-	Description string `json:"description"`
-	Position    int    `json:"position"`
-	Correction  string `json:"correction"`
-}
-
-const GeminiGrammarPrompt = `
-    You are an English teacher assisting Vietnamese learners.
-
-    Your task is to evaluate the student's English translation of a Vietnamese sentence and return structured feedback in JSON format. The response must be suitable for educational apps that teach English to Vietnamese users.
-
-    Original Vietnamese sentence: "%s"
-    Student's English translation: "%s"
-    Target language: %s
-
-    Return a JSON object with the following fields:
-
-    {
-        "score": 0 - 100,
-        "errors": [
-            {
-                "type": "grammar | syntax | vocabulary",
-                "description": "Simple explanation in Vietnamese to help learners understand the mistake",
-                "position": character index of the mistake (or 0 if unknown),
-                "correction": "Suggested correction in English"
-            }
-        ],
-        "suggestions": [
-            "Learning tips or revision advice in Vietnamese"
-        ],
-        "feedback": "Write a short comment in Vietnamese to share how you feel - whether it's great, okay, not so good, or anything else you'd like to say."
-    }
-
-    Requirements:
-    - Use Vietnamese for 'description', 'suggestions', and 'feedback'.
-    - Be slightly generous in scoring. Give 100 points if the student's translation is fully correct or only has very minor, acceptable differences (e.g., “Hi” vs “Hello”).
-    - Only deduct points for actual mistakes that affect grammar, meaning, or clarity.
-    - Do NOT return any markdown, explanation, or extra text. Only respond with the raw JSON object.
-`
@@ -1,6 +1,7 @@
 package model
 
 import (
+	scoremodel "hub-service/module/score/model"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -32,16 +33,17 @@ func (Translation) TableName() string {
 
 // TranslationCreate is the model for creating a new translation
 type TranslationCreate struct {
-	ID         primitive.ObjectID `json:"-" bson:"_id,omitempty"`
-	Title      string             `json:"title" bson:"title" binding:"required"`
-	Content    string             `json:"content" bson:"content" binding:"required"`
-	SourceLang string             `json:"source_lang" bson:"source_lang" binding:"required"`
-	TargetLang string             `json:"target_lang" bson:"target_lang" binding:"required"`
-	Category   string             `json:"category" bson:"category"`
-	Difficulty string             `json:"difficulty" bson:"difficulty" binding:"required,oneof=easy medium hard"`
-	CreatedAt  *time.Time         `json:"-" bson:"created_at"`
-	UpdatedAt  *time.Time         `json:"-" bson:"updated_at"`
-	Image      string             `json:"image" bson:"image"`
+	ID                 primitive.ObjectID `json:"-" bson:"_id,omitempty"`
+	Title              string             `json:"title" bson:"title" binding:"required"`
+	Content            string             `json:"content" bson:"content" binding:"required"`
+	SourceLang         string             `json:"source_lang" bson:"source_lang" binding:"required"`
+	TargetLang         string             `json:"target_lang" bson:"target_lang" binding:"required"`
+	Category           string             `json:"category" bson:"category"`
+	Difficulty         string             `json:"difficulty" bson:"difficulty" binding:"required,oneof=easy medium hard"`
+	CreatedAt          *time.Time         `json:"-" bson:"created_at"`
+	UpdatedAt          *time.Time         `json:"-" bson:"updated_at"`
+	Image              string             `json:"image" bson:"image"`
+	GenerateReferences bool               `json:"generate_references" bson:"-"`
 }
 
 func (TranslationCreate) TableName() string {
@@ -66,28 +68,59 @@ func (TranslationUpdate) TableName() string {
 
 // TranslationSentence represents a single sentence within a translation
 type TranslationSentence struct {
-	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	TranslationID primitive.ObjectID `json:"translation_id" bson:"translation_id"`
-	SentenceIndex int                `json:"sentence_index" bson:"sentence_index"`
-	Content       string             `json:"content" bson:"content"`
-	MaxScore      float64            `json:"max_score" bson:"max_score"`
-	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`
-	UpdatedAt     time.Time          `json:"updated_at" bson:"updated_at"`
+	ID                    primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	TranslationID         primitive.ObjectID `json:"translation_id" bson:"translation_id"`
+	SentenceIndex         int                `json:"sentence_index" bson:"sentence_index"`
+	Content               string             `json:"content" bson:"content"`
+	MaxScore              float64            `json:"max_score" bson:"max_score"`
+	ReferenceTranslations []string           `json:"reference_translations,omitempty" bson:"reference_translations,omitempty"`
+	MachineTranslation    string             `json:"machine_translation,omitempty" bson:"machine_translation,omitempty"`
+	CreatedAt             time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt             time.Time          `json:"updated_at" bson:"updated_at"`
 }
 
 func (TranslationSentence) TableName() string {
 	return SentenceCollectionName
 }
 
+// HideReferences drops the accepted answers before the sentence is shown to a learner
+func (s *TranslationSentence) HideReferences() {
+	s.ReferenceTranslations = nil
+	s.MachineTranslation = ""
+}
+
+// GradingReferences are the admin references a submission is accepted against. The machine
+// translation is left out: pasting it must not match, it is only given to the grader as context.
+func (s *TranslationSentence) GradingReferences() []string {
+	return s.ReferenceTranslations
+}
+
+// HintReferences are the admin references followed by the machine reference, for hints
+func (s *TranslationSentence) HintReferences() []string {
+	if s.MachineTranslation == "" {
+		return s.ReferenceTranslations
+	}
+	return append(append([]string{}, s.ReferenceTranslations...), s.MachineTranslation)
+}
+
+// HideSentenceReferences drops the accepted answers before sentences are shown to a learner
+func HideSentenceReferences(sentences []TranslationSentence) {
+	for i := range sentences {
+		sentences[i].HideReferences()
+	}
+}
+
 // TranslationSentenceCreate is the model for creating a new sentence
 type TranslationSentenceCreate struct {
-	ID            primitive.ObjectID `json:"-" bson:"_id,omitempty"`
-	TranslationID primitive.ObjectID `json:"translation_id" bson:"translation_id"`
-	SentenceIndex int                `json:"sentence_index" bson:"sentence_index"`
-	Content       string             `json:"content" bson:"content" binding:"required"`
-	MaxScore      float64            `json:"max_score" bson:"max_score"`
-	CreatedAt     *time.Time         `json:"-" bson:"created_at"`
-	UpdatedAt     *time.Time         `json:"-" bson:"updated_at"`
+	ID                    primitive.ObjectID `json:"-" bson:"_id,omitempty"`
+	TranslationID         primitive.ObjectID `json:"translation_id" bson:"translation_id"`
+	SentenceIndex         int                `json:"sentence_index" bson:"sentence_index"`
+	Content               string             `json:"content" bson:"content" binding:"required"`
+	MaxScore              float64            `json:"max_score" bson:"max_score"`
+	ReferenceTranslations []string           `json:"reference_translations,omitempty" bson:"reference_translations,omitempty"`
+	MachineTranslation    string             `json:"machine_translation,omitempty" bson:"machine_translation,omitempty"`
+	CreatedAt             *time.Time         `json:"-" bson:"created_at"`
+	UpdatedAt             *time.Time         `json:"-" bson:"updated_at"`
 }
 
 func (TranslationSentenceCreate) TableName() string {
@@ -96,20 +129,24 @@ func (TranslationSentenceCreate) TableName() string {
 
 // UserTranslationScore represents a user's score for a specific sentence
 type UserTranslationScore struct {
-	ID              primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	UserID          primitive.ObjectID `json:"user_id" bson:"user_id"`
-	TranslationID   primitive.ObjectID `json:"translation_id" bson:"translation_id"`
-	SentenceID      primitive.ObjectID `json:"sentence_id" bson:"sentence_id"`
-	SentenceIndex   int                `json:"sentence_index" bson:"sentence_index"`
-	UserTranslation string             `json:"user_translation" bson:"user_translation"`
-	Score           float64            `json:"score" bson:"score"`
-	Feedback        string             `json:"feedback" bson:"feedback"`
-	Errors          string             `json:"errors" bson:"errors"`
-	Suggestions     string             `json:"suggestions" bson:"suggestions"`
-	AttemptCount    int                `json:"attempt_count" bson:"attempt_count"`
-	BestScore       float64            `json:"best_score" bson:"best_score"`
-	CreatedAt       time.Time          `json:"created_at" bson:"created_at"`
-	UpdatedAt       time.Time          `json:"updated_at" bson:"updated_at"`
+	ID              primitive.ObjectID    `json:"id" bson:"_id,omitempty"`
+	UserID          primitive.ObjectID    `json:"user_id" bson:"user_id"`
+	TranslationID   primitive.ObjectID    `json:"translation_id" bson:"translation_id"`
+	SentenceID      primitive.ObjectID    `json:"sentence_id" bson:"sentence_id"`
+	SentenceIndex   int                   `json:"sentence_index" bson:"sentence_index"`
+	UserTranslation string                `json:"user_translation" bson:"user_translation"`
+	Score           float64               `json:"score" bson:"score"`
+	Feedback        string                `json:"feedback" bson:"feedback"`
+	Errors          scoremodel.ErrorList  `json:"errors" bson:"errors" swaggertype:"string"`
+	Suggestions     string                `json:"suggestions" bson:"suggestions"`
+	AttemptCount    int                   `json:"attempt_count" bson:"attempt_count"`
+	BestScore       float64               `json:"best_score" bson:"best_score"`
+	PromptVersion   string                `json:"prompt_version" bson:"prompt_version"`
+	HumanReviewed   bool                  `json:"human_reviewed" bson:"human_reviewed"`
+	ReviewedAt      *time.Time            `json:"reviewed_at,omitempty" bson:"reviewed_at,omitempty"`
+	PasteFlag       *scoremodel.PasteFlag `json:"paste_flag,omitempty" bson:"paste_flag,omitempty"`
+	CreatedAt       time.Time             `json:"created_at" bson:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at" bson:"updated_at"`
 }
 
 const UserTranslationScoreCollectionName = "user_translation_scores"
@@ -118,21 +155,27 @@ func (UserTranslationScore) TableName() string {
 	return UserTranslationScoreCollectionName
 }
 
-// UserTranslationScoreCreate is the model for creating a new user score
+// UserTranslationScoreCreate is the model for creating a new user score.
+// It also replaces the whole record on resubmission, which resets any human review.
+// PasteFlag is omitted when the attempt is not flagged, so an open flag survives a clean resubmission.
 type UserTranslationScoreCreate struct {
-	UserID          primitive.ObjectID `json:"user_id" bson:"user_id"`
-	TranslationID   primitive.ObjectID `json:"translation_id" bson:"translation_id"`
-	SentenceID      primitive.ObjectID `json:"sentence_id" bson:"sentence_id"`
-	SentenceIndex   int                `json:"sentence_index" bson:"sentence_index"`
-	UserTranslation string             `json:"user_translation" bson:"user_translation"`
-	Score           float64            `json:"score" bson:"score"`
-	Feedback        string             `json:"feedback" bson:"feedback"`
-	Errors          string             `json:"errors" bson:"errors"`
-	Suggestions     string             `json:"suggestions" bson:"suggestions"`
-	AttemptCount    int                `json:"attempt_count" bson:"attempt_count"`
-	BestScore       float64            `json:"best_score" bson:"best_score"`
-	CreatedAt       time.Time          `json:"created_at" bson:"created_at"`
-	UpdatedAt       time.Time          `json:"updated_at" bson:"updated_at"`
+	UserID          primitive.ObjectID    `json:"user_id" bson:"user_id"`
+	TranslationID   primitive.ObjectID    `json:"translation_id" bson:"translation_id"`
+	SentenceID      primitive.ObjectID    `json:"sentence_id" bson:"sentence_id"`
+	SentenceIndex   int                   `json:"sentence_index" bson:"sentence_index"`
+	UserTranslation string                `json:"user_translation" bson:"user_translation"`
+	Score           float64               `json:"score" bson:"score"`
+	Feedback        string                `json:"feedback" bson:"feedback"`
+	Errors          scoremodel.ErrorList  `json:"errors" bson:"errors" swaggertype:"string"`
+	Suggestions     string                `json:"suggestions" bson:"suggestions"`
+	AttemptCount    int                   `json:"attempt_count" bson:"attempt_count"`
+	BestScore       float64               `json:"best_score" bson:"best_score"`
+	PromptVersion   string                `json:"prompt_version" bson:"prompt_version"`
+	HumanReviewed   bool                  `json:"human_reviewed" bson:"human_reviewed"`
+	ReviewedAt      *time.Time            `json:"reviewed_at" bson:"reviewed_at"`
+	PasteFlag       *scoremodel.PasteFlag `json:"paste_flag,omitempty" bson:"paste_flag,omitempty"`
+	CreatedAt       time.Time             `json:"created_at" bson:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at" bson:"updated_at"`
 }
 
 func (UserTranslationScoreCreate) TableName() string {
@@ -155,24 +198,34 @@ type TranslationWithUserProgress struct {
 	ProgressPercent float64                `json:"progress_percent"`
 }
 
+// SetSentenceReferencesRequest replaces the accepted translations of a sentence
+type SetSentenceReferencesRequest struct {
+	ReferenceTranslations []string `json:"reference_translations" binding:"dive,required"`
+	// MachineTranslation is left unchanged when omitted; an empty string removes it
+	MachineTranslation *string `json:"machine_translation,omitempty"`
+}
+
 // SubmitSentenceTranslationRequest for submitting a sentence translation
 type SubmitSentenceTranslationRequest struct {
-	UserTranslation string `json:"user_translation" binding:"required"`
+	UserTranslation string                `json:"user_translation" binding:"required"`
+	Telemetry       *scoremodel.Telemetry `json:"telemetry,omitempty"`
 }
 
 // SubmitSentenceTranslationResponse for sentence translation result
 type SubmitSentenceTranslationResponse struct {
-	Score           float64 `json:"score"`
-	UserTranslation string  `json:"user_translation"`
-	Feedback        string  `json:"feedback"`
-	Errors          string  `json:"errors"`
-	Suggestions     string  `json:"suggestions"`
-	OriginalContent string  `json:"original_content"`
-	AttemptCount    int     `json:"attempt_count"`
-	BestScore       float64 `json:"best_score"`
-	IsNewBest       bool    `json:"is_new_best"`
-	TotalUserScore  float64 `json:"total_user_score"`
-	ProgressPercent float64 `json:"progress_percent"`
+	Score           float64              `json:"score"`
+	UserTranslation string               `json:"user_translation"`
+	Feedback        string               `json:"feedback"`
+	Errors          scoremodel.ErrorList `json:"errors" swaggertype:"string"`
+	Suggestions     string               `json:"suggestions"`
+	OriginalContent string               `json:"original_content"`
+	AttemptCount    int                  `json:"attempt_count"`
+	BestScore       float64              `json:"best_score"`
+	IsNewBest       bool                 `json:"is_new_best"`
+	HintsUsed       int                  `json:"hints_used"`
+	HintPenalty     float64              `json:"hint_penalty"`
+	TotalUserScore  float64              `json:"total_user_score"`
+	ProgressPercent float64              `json:"progress_percent"`
 }
 
 // TranslationSummary represents a summary of user's translation progress
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	common "hub-service/common"
+	scoremodel "hub-service/module/score/model"
 	translationmodel "hub-service/module/translation/model"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -112,6 +113,34 @@ func (s *Storage) UpdateSentence(ctx context.Context, id primitive.ObjectID, dat
 	return err
 }
 
+// SetSentenceReferences replaces the reference translations of one sentence and reports whether it exists.
+// The machine translation is only touched when given: set when non-empty, removed when empty.
+func (s *Storage) SetSentenceReferences(ctx context.Context, translationID primitive.ObjectID, sentenceIndex int, references []string, machineTranslation *string) (bool, error) {
+	collection := s.db.MongoDB.Database.Collection(translationmodel.SentenceCollectionName)
+
+	set := bson.M{
+		"reference_translations": references,
+		"updated_at":             time.Now(),
+	}
+	update := bson.M{"$set": set}
+	if machineTranslation != nil {
+		if *machineTranslation == "" {
+			update["$unset"] = bson.M{"machine_translation": ""}
+		} else {
+			set["machine_translation"] = *machineTranslation
+		}
+	}
+
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"translation_id": translationID, "sentence_index": sentenceIndex},
+		update,
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
 func (s *Storage) DeleteSentence(ctx context.Context, id primitive.ObjectID) error {
 	collection := s.db.MongoDB.Database.Collection(translationmodel.SentenceCollectionName)
 	_, err := collection.DeleteOne(ctx, bson.M{"_id": id})
@@ -147,6 +176,35 @@ func (s *Storage) GetUserScore(ctx context.Context, userID, translationID primit
 	return &score, nil
 }
 
+func (s *Storage) GetUserScoreByID(ctx context.Context, id primitive.ObjectID) (*translationmodel.UserTranslationScore, error) {
+	collection := s.db.MongoDB.Database.Collection(translationmodel.UserTranslationScoreCollectionName)
+
+	var score translationmodel.UserTranslationScore
+	if err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&score); err != nil {
+		return nil, err
+	}
+
+	return &score, nil
+}
+
+// OverrideUserScore replaces the graded result with a reviewer's and marks it human reviewed.
+// It only writes while attemptCount is still the latest attempt, and returns false otherwise.
+// updated_at is left alone so it keeps tracking the learner's last submission.
+func (s *Storage) OverrideUserScore(ctx context.Context, id primitive.ObjectID, attemptCount int, score, bestScore float64, feedback string, reviewedAt time.Time) (bool, error) {
+	collection := s.db.MongoDB.Database.Collection(translationmodel.UserTranslationScoreCollectionName)
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": id, "attempt_count": attemptCount}, bson.M{"$set": bson.M{
+		"score":          score,
+		"best_score":     bestScore,
+		"feedback":       feedback,
+		"human_reviewed": true,
+		"reviewed_at":    reviewedAt,
+	}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
 func (s *Storage) UpdateUserScore(ctx context.Context, id primitive.ObjectID, data *translationmodel.UserTranslationScoreCreate) error {
 	collection := s.db.MongoDB.Database.Collection(translationmodel.UserTranslationScoreCollectionName)
 	_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": data})
@@ -177,13 +235,23 @@ func (s *Storage) GetUserScoresByTranslation(ctx context.Context, userID, transl
 func (s *Storage) GetUserTranslationSummaries(ctx context.Context, userID primitive.ObjectID) ([]translationmodel.TranslationSummary, error) {
 	collection := s.db.MongoDB.Database.Collection(translationmodel.UserTranslationScoreCollectionName)
 
+	// Sentences with a pending or confirmed paste flag stay out of the score until cleared
+	countedScore := bson.M{"$cond": bson.A{
+		bson.M{"$in": bson.A{
+			bson.M{"$ifNull": bson.A{"$paste_flag.status", ""}},
+			bson.A{scoremodel.PasteStatusPending, scoremodel.PasteStatusConfirmed},
+		}},
+		0,
+		"$best_score",
+	}}
+
 	// Aggregate pipeline to get summaries
 	pipeline := []bson.M{
 		{"$match": bson.M{"user_id": userID}},
 		{"$group": bson.M{
 			"_id":              "$translation_id",
 			"total_sentences":  bson.M{"$sum": 1},
-			"total_user_score": bson.M{"$sum": "$best_score"},
+			"total_user_score": bson.M{"$sum": countedScore},
 			"last_attempt_at":  bson.M{"$max": "$updated_at"},
 		}},
 		{"$lookup": bson.M{
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	common "hub-service/common"
+	scoremodel "hub-service/module/score/model"
+	translationmodel "hub-service/module/translation/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ListFlaggedUserScores returns the sentence scores carrying a paste flag, most recently flagged first
+func (s *Storage) ListFlaggedUserScores(ctx context.Context, filter *scoremodel.PasteFlagFilter, paging *common.Paging) ([]translationmodel.UserTranslationScore, error) {
+	collection := s.db.MongoDB.Database.Collection(translationmodel.UserTranslationScoreCollectionName)
+
+	query := bson.M{"paste_flag": bson.M{"$exists": true}}
+	if filter.Status != "" {
+		query["paste_flag.status"] = filter.Status
+	}
+	if !filter.UserID.IsZero() {
+		query["user_id"] = filter.UserID
+	}
+
+	opts := options.Find().
+		SetSkip(int64((paging.Page - 1) * paging.Limit)).
+		SetLimit(int64(paging.Limit)).
+		SetSort(bson.D{{Key: "paste_flag.flagged_at", Value: -1}})
+
+	cursor, err := collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	scores := []translationmodel.UserTranslationScore{}
+	if err = cursor.All(ctx, &scores); err != nil {
+		return nil, err
+	}
+
+	total, err := collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	paging.Total = total
+
+	return scores, nil
+}
+
+// ReviewUserScorePasteFlag records a reviewer's verdict on a pending paste flag and returns false
+// when the sentence score has no pending flag
+func (s *Storage) ReviewUserScorePasteFlag(ctx context.Context, id primitive.ObjectID, status string, reviewerID primitive.ObjectID, note string) (bool, error) {
+	collection := s.db.MongoDB.Database.Collection(translationmodel.UserTranslationScoreCollectionName)
+
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": id, "paste_flag.status": scoremodel.PasteStatusPending},
+		bson.M{"$set": bson.M{
+			"paste_flag.status":      status,
+			"paste_flag.reviewer_id": reviewerID,
+			"paste_flag.review_note": note,
+			"paste_flag.reviewed_at": time.Now(),
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
@@ -3,6 +3,7 @@ package transport
 import (
 	"hub-service/common"
 	"hub-service/core/appctx"
+	"hub-service/infrastructure/external/deepl"
 	"hub-service/module/translation/biz"
 	translationmodel "hub-service/module/translation/model"
 	"hub-service/module/translation/storage"
@@ -13,7 +14,7 @@ import (
 
 // CreateTranslation godoc
 // @Summary Create a new translation
-// @Description Create a new translation with sentences. Only admin and super_admin can access this endpoint.
+// @Description Create a new translation with sentences. Set generate_references to store a DeepL translation of every sentence as its machine reference. Only admin and super_admin can access this endpoint.
 // @Tags translations
 // @Accept json
 // @Produce json
@@ -24,6 +25,8 @@ import (
 // @Failure 401 {object} common.AppError "Unauthorized"
 // @Failure 403 {object} common.AppError "Forbidden - Only admin and super_admin can access"
 // @Failure 500 {object} common.AppError "Internal server error"
+// @Failure 502 {object} common.AppError "DeepL request failed"
+// @Failure 503 {object} common.AppError "DeepL not configured"
 // @Router /api/translations/create [post]
 func CreateTranslation(appCtx appctx.AppContext) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -35,7 +38,14 @@ func CreateTranslation(appCtx appctx.AppContext) gin.HandlerFunc {
 		store := storage.NewStorage(appCtx.GetDatabase())
 		apiKey := appCtx.GetEnv("GEMINI_API_KEY")
 		baseURL := appCtx.GetEnv("GEMINI_BASE_URL")
-		business := biz.NewCreateTranslationBiz(store, apiKey, baseURL)
+
+		// Keep the interface nil when DeepL is absent so the biz can report it
+		var translator biz.MachineTranslator
+		if t := deepl.NewTranslator(appCtx.GetDeeplClient()); t != nil {
+			translator = t
+		}
+
+		business := biz.NewCreateTranslationBiz(store, translator, apiKey, baseURL)
 
 		result, err := business.CreateTranslation(c.Request.Context(), &translation)
 		if err != nil {
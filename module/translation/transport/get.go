@@ -3,6 +3,7 @@ package transport
 import (
 	"hub-service/common"
 	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
 	"hub-service/module/translation/biz"
 	translationmodel "hub-service/module/translation/model"
 	"hub-service/module/translation/storage"
@@ -45,13 +46,17 @@ func GetTranslation(appCtx appctx.AppContext) gin.HandlerFunc {
 			panic(err)
 		}
 
+		if !auth.IsAdmin(c) {
+			translationmodel.HideSentenceReferences(result.Sentences)
+		}
+
 		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
 	}
 }
 
 // GetTranslationWithProgress godoc
 // @Summary Get translation with user progress
-// @Description Get a translation with user's progress and scores. All authenticated users can access this endpoint.
+// @Description Get a translation with user's progress and scores. Each sentence includes its reference translations once the user has answered it. All authenticated users can access this endpoint.
 // @Tags translations
 // @Accept json
 // @Produce json
@@ -85,6 +90,19 @@ func GetTranslationWithProgress(appCtx appctx.AppContext) gin.HandlerFunc {
 			panic(err)
 		}
 
+		// Learners see a sentence's references once they have submitted an answer for it
+		if !auth.IsAdmin(c) {
+			answered := make(map[int]bool, len(result.UserScores))
+			for _, score := range result.UserScores {
+				answered[score.SentenceIndex] = true
+			}
+			for i := range result.Sentences {
+				if !answered[result.Sentences[i].SentenceIndex] {
+					result.Sentences[i].HideReferences()
+				}
+			}
+		}
+
 		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
 	}
 }
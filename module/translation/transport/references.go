@@ -0,0 +1,58 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/module/translation/biz"
+	translationmodel "hub-service/module/translation/model"
+	"hub-service/module/translation/storage"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SetSentenceReferences godoc
+// @Summary Set sentence reference translations
+// @Description Replace the accepted reference translations of a sentence, and its machine reference when machine_translation is sent (an empty string removes it). Exact matches are scored without the AI, and the references back up grading when the AI is unavailable. Only admin and super_admin can access this endpoint.
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Translation ID" example("62b4c3789196e8a159933552")
+// @Param sentence_index path int true "Sentence index" example(0)
+// @Param request body translationmodel.SetSentenceReferencesRequest true "Reference translations"
+// @Success 200 {object} common.Response "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden - Only admin and super_admin can access"
+// @Failure 404 {object} common.AppError "Sentence not found"
+// @Router /api/translations/{id}/sentences/{sentence_index}/references [put]
+func SetSentenceReferences(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		translationID, err := primitive.ObjectIDFromHex(c.Param("id"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		sentenceIndex, err := strconv.Atoi(c.Param("sentence_index"))
+		if err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		var req translationmodel.SetSentenceReferencesRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		store := storage.NewStorage(appCtx.GetDatabase())
+		business := biz.NewSetSentenceReferencesBiz(store)
+
+		if err := business.SetSentenceReferences(c.Request.Context(), translationID, sentenceIndex, req.ReferenceTranslations, req.MachineTranslation); err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(true))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"hub-service/common"
 	"hub-service/core/appctx"
 	"hub-service/middleware/auth"
+	"hub-service/middleware/quota"
 
 	"github.com/gin-gonic/gin"
 )
@@ -26,13 +27,14 @@ func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
 		adminProtected.Use(auth.RequireRoles(common.RoleAdmin, common.RoleSuperAdmin))
 		{
 			adminProtected.POST("/create", CreateTranslation(appCtx))
+			adminProtected.PUT("/:id/sentences/:sentence_index/references", SetSentenceReferences(appCtx))
 		}
 
 		// User operations - accessible by all authenticated users
 		userProtected := translations.Group("/")
 		userProtected.Use(auth.AuthMiddleware(appCtx))
 		{
-			userProtected.POST("/:id/sentences/:sentence_index/translate", SubmitSentenceTranslation(appCtx))
+			userProtected.POST("/:id/sentences/:sentence_index/translate", quota.RequireAIQuota(appCtx), SubmitSentenceTranslation(appCtx))
 		}
 	}
 }
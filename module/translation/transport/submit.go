@@ -4,6 +4,8 @@ import (
 	"errors"
 	"hub-service/common"
 	"hub-service/core/appctx"
+	"hub-service/module/grading"
+	scorebiz "hub-service/module/score/biz"
 	"hub-service/module/translation/biz"
 	translationmodel "hub-service/module/translation/model"
 	"hub-service/module/translation/storage"
@@ -24,6 +26,7 @@ import (
 // @Param id path string true "Translation ID" example("62b4c3789196e8a159933552")
 // @Param sentence_index path int true "Sentence index" example(0)
 // @Param request body translationmodel.SubmitSentenceTranslationRequest true "Translation request (only user_translation field required)"
+// @Param no_cache query bool false "Admins only: skip the grading cache"
 // @Success 200 {object} common.Response{data=translationmodel.SubmitSentenceTranslationResponse} "Success"
 // @Failure 400 {object} common.AppError "Bad request - Invalid translation ID or sentence index"
 // @Failure 401 {object} common.AppError "Unauthorized"
@@ -31,6 +34,8 @@ import (
 // @Failure 500 {object} common.AppError "Internal server error"
 // @Router /api/translations/{id}/sentences/{sentence_index}/translate [post]
 func SubmitSentenceTranslation(appCtx appctx.AppContext) gin.HandlerFunc {
+	pairs := scorebiz.SupportedLanguagePairs()
+
 	return func(c *gin.Context) {
 		// Get translation ID from URL parameter
 		translationIDStr := c.Param("id")
@@ -63,11 +68,14 @@ func SubmitSentenceTranslation(appCtx appctx.AppContext) gin.HandlerFunc {
 		}
 
 		store := storage.NewStorage(database)
-		apiKey := appCtx.GetEnv("GEMINI_API_KEY")
-		baseURL := appCtx.GetEnv("GEMINI_BASE_URL")
-		business := biz.NewSubmitTranslationBiz(store, apiKey, baseURL)
+		provider, err := grading.NewProvider(appCtx)
+		if err != nil {
+			panic(err)
+		}
+
+		business := biz.NewSubmitTranslationBiz(store, provider, pairs, grading.HintLedger(appCtx), grading.PasteDetector(appCtx), grading.SubmissionListeners(appCtx)...)
 
-		result, err := business.SubmitSentenceTranslation(c.Request.Context(), translationID, sentenceIndex, req.UserTranslation, userID)
+		result, err := business.SubmitSentenceTranslation(grading.RequestContext(c), translationID, sentenceIndex, req.UserTranslation, req.Telemetry, userID, grading.FeedbackLanguage(c, appCtx))
 		if err != nil {
 			panic(err)
 		}
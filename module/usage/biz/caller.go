@@ -0,0 +1,27 @@
+package biz
+
+import (
+	"context"
+	"hub-service/module/usage/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type callerKey struct{}
+
+type caller struct {
+	feature string
+	userID  primitive.ObjectID
+}
+
+// WithCaller attributes the AI calls made with ctx to a feature and, unless userID is zero, a user
+func WithCaller(ctx context.Context, feature string, userID primitive.ObjectID) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller{feature: feature, userID: userID})
+}
+
+func callerFrom(ctx context.Context) caller {
+	if c, ok := ctx.Value(callerKey{}).(caller); ok {
+		return c
+	}
+	return caller{feature: model.FeatureUnknown}
+}
@@ -0,0 +1,88 @@
+package biz
+
+import (
+	"context"
+	"hub-service/infrastructure/external/llm"
+	"hub-service/module/usage/model"
+	"log"
+	"time"
+)
+
+const (
+	maxErrorLength     = 500
+	ledgerWriteTimeout = 5 * time.Second
+)
+
+type UsageStore interface {
+	InsertUsage(ctx context.Context, record *model.UsageRecord) error
+}
+
+// ledger is the llm.UsageRecorder that stores every AI call. Calls are queued and written
+// by Run so grading never waits on the ledger; when the queue is full entries are dropped.
+type ledger struct {
+	store   UsageStore
+	records chan *model.UsageRecord
+}
+
+func NewLedger(store UsageStore, buffer int) *ledger {
+	return &ledger{store: store, records: make(chan *model.UsageRecord, buffer)}
+}
+
+func (l *ledger) RecordCall(ctx context.Context, call *llm.Call) {
+	c := callerFrom(ctx)
+
+	record := &model.UsageRecord{
+		Feature:      c.feature,
+		Provider:     call.Provider,
+		Model:        call.Model,
+		InputTokens:  call.Usage.InputTokens,
+		OutputTokens: call.Usage.OutputTokens,
+		LatencyMs:    call.Latency.Milliseconds(),
+		Success:      call.Err == nil,
+		CreatedAt:    time.Now(),
+	}
+	if !c.userID.IsZero() {
+		userID := c.userID
+		record.UserID = &userID
+	}
+	if call.Err != nil {
+		record.Error = call.Err.Error()
+		if len(record.Error) > maxErrorLength {
+			record.Error = record.Error[:maxErrorLength]
+		}
+	}
+
+	select {
+	case l.records <- record:
+	default:
+		log.Printf("AI usage ledger queue full, dropping %s call of feature %s", call.Provider, c.feature)
+	}
+}
+
+// Run writes queued entries until ctx is done, then flushes what is left
+func (l *ledger) Run(ctx context.Context) {
+	for {
+		select {
+		case record := <-l.records:
+			l.write(record)
+		case <-ctx.Done():
+			for {
+				select {
+				case record := <-l.records:
+					l.write(record)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (l *ledger) write(record *model.UsageRecord) {
+	ctx, cancel := context.WithTimeout(context.Background(), ledgerWriteTimeout)
+	defer cancel()
+
+	if err := l.store.InsertUsage(ctx, record); err != nil {
+		log.Printf("Failed to record AI usage: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"hub-service/common"
+	"hub-service/module/usage/model"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PriceStore interface {
+	ListPrices(ctx context.Context) ([]model.Price, error)
+	SetPrice(ctx context.Context, data *model.Price) error
+}
+
+type managePricesBiz struct {
+	store PriceStore
+}
+
+func NewManagePricesBiz(store PriceStore) *managePricesBiz {
+	return &managePricesBiz{store: store}
+}
+
+func (biz *managePricesBiz) ListPrices(ctx context.Context) ([]model.Price, error) {
+	prices, err := biz.store.ListPrices(ctx)
+	if err != nil {
+		return nil, common.ErrCannotListEntity("Price", err)
+	}
+	return prices, nil
+}
+
+// SetPrice creates or replaces the price of a model. Reports use the new price for past calls too.
+func (biz *managePricesBiz) SetPrice(ctx context.Context, req *model.SetPriceRequest, adminID primitive.ObjectID) (*model.Price, error) {
+	name := strings.TrimSpace(req.Model)
+	if name == "" {
+		return nil, common.ErrInvalidRequest(errors.New("model is required"))
+	}
+
+	price := &model.Price{
+		Model:            name,
+		InputPerMillion:  *req.InputPerMillion,
+		OutputPerMillion: *req.OutputPerMillion,
+		UpdatedBy:        adminID,
+		UpdatedAt:        time.Now(),
+	}
+	if err := biz.store.SetPrice(ctx, price); err != nil {
+		return nil, common.ErrCannotUpdateEntity("Price", err)
+	}
+	return price, nil
+}
+
+// priceFor returns the price of the exact model, else the longest priced prefix of it
+func priceFor(prices []model.Price, name string) *model.Price {
+	var best *model.Price
+	for i := range prices {
+		p := &prices[i]
+		if p.Model == name {
+			return p
+		}
+		if strings.HasPrefix(name, p.Model) && (best == nil || len(p.Model) > len(best.Model)) {
+			best = p
+		}
+	}
+	return best
+}
+
+func cost(price *model.Price, inputTokens, outputTokens int64) float64 {
+	if price == nil {
+		return 0
+	}
+	return (float64(inputTokens)*price.InputPerMillion + float64(outputTokens)*price.OutputPerMillion) / 1e6
+}
@@ -0,0 +1,159 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hub-service/common"
+	"hub-service/module/usage/model"
+	"math"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	dateLayout        = "2006-01-02"
+	defaultReportDays = 30
+)
+
+type ReportStore interface {
+	AggregateUsage(ctx context.Context, groupBy string, filter *model.UsageFilter) ([]model.UsageGroup, error)
+	ListPrices(ctx context.Context) ([]model.Price, error)
+}
+
+type usageReportBiz struct {
+	store ReportStore
+}
+
+func NewUsageReportBiz(store ReportStore) *usageReportBiz {
+	return &usageReportBiz{store: store}
+}
+
+// GetReport aggregates the AI usage ledger per user, day or feature and prices it
+// with the current price table. The default window is the last 30 days.
+func (biz *usageReportBiz) GetReport(ctx context.Context, query *model.UsageQuery) (*model.UsageReport, error) {
+	groupBy := query.GroupBy
+	if groupBy == "" {
+		groupBy = model.GroupByDay
+	}
+
+	filter, err := parseFilter(query, time.Now().UTC())
+	if err != nil {
+		return nil, common.ErrInvalidRequest(err)
+	}
+
+	groups, err := biz.store.AggregateUsage(ctx, groupBy, filter)
+	if err != nil {
+		return nil, common.ErrCannotListEntity("Usage", err)
+	}
+
+	prices, err := biz.store.ListPrices(ctx)
+	if err != nil {
+		return nil, common.ErrCannotListEntity("Price", err)
+	}
+
+	report := &model.UsageReport{
+		GroupBy:        groupBy,
+		From:           filter.From,
+		To:             filter.To,
+		Rows:           []model.UsageRow{},
+		UnpricedModels: []string{},
+	}
+
+	rows := map[string]*model.UsageRow{}
+	latency := map[string]int64{}
+	var totalLatency int64
+	unpriced := map[string]bool{}
+
+	for _, g := range groups {
+		row, ok := rows[g.Key]
+		if !ok {
+			report.Rows = append(report.Rows, model.UsageRow{Key: g.Key})
+			row = &report.Rows[len(report.Rows)-1]
+			rows[g.Key] = row
+		}
+
+		price := priceFor(prices, g.Model)
+		if price == nil && g.InputTokens+g.OutputTokens > 0 && !unpriced[g.Model] {
+			unpriced[g.Model] = true
+			report.UnpricedModels = append(report.UnpricedModels, g.Model)
+		}
+		spent := cost(price, g.InputTokens, g.OutputTokens)
+
+		for _, r := range []*model.UsageRow{row, &report.Total} {
+			r.Calls += g.Calls
+			r.FailedCalls += g.FailedCalls
+			r.InputTokens += g.InputTokens
+			r.OutputTokens += g.OutputTokens
+			r.CostUSD += spent
+		}
+		latency[g.Key] += g.LatencyMs
+		totalLatency += g.LatencyMs
+	}
+
+	for i := range report.Rows {
+		row := &report.Rows[i]
+		row.AvgLatencyMs = average(latency[row.Key], row.Calls)
+		row.CostUSD = roundCost(row.CostUSD)
+	}
+	report.Total.Key = "total"
+	report.Total.AvgLatencyMs = average(totalLatency, report.Total.Calls)
+	report.Total.CostUSD = roundCost(report.Total.CostUSD)
+
+	// Most expensive first, except days which read best in order
+	if groupBy != model.GroupByDay {
+		sort.SliceStable(report.Rows, func(i, j int) bool {
+			return report.Rows[i].CostUSD > report.Rows[j].CostUSD
+		})
+	}
+	sort.Strings(report.UnpricedModels)
+
+	return report, nil
+}
+
+func parseFilter(query *model.UsageQuery, now time.Time) (*model.UsageFilter, error) {
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	if query.To != "" {
+		t, err := time.Parse(dateLayout, query.To)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to date: %w", err)
+		}
+		to = t.AddDate(0, 0, 1)
+	}
+
+	from := to.AddDate(0, 0, -defaultReportDays)
+	if query.From != "" {
+		t, err := time.Parse(dateLayout, query.From)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from date: %w", err)
+		}
+		from = t
+	}
+	if !from.Before(to) {
+		return nil, errors.New("from must not be after to")
+	}
+
+	filter := &model.UsageFilter{From: from, To: to, Feature: query.Feature}
+	if query.UserID != "" {
+		userID, err := primitive.ObjectIDFromHex(query.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user_id: %w", err)
+		}
+		filter.UserID = &userID
+	}
+	return filter, nil
+}
+
+func average(sum int64, count int) int64 {
+	if count == 0 {
+		return 0
+	}
+	return sum / int64(count)
+}
+
+// roundCost keeps sub-cent precision, since a single grading costs fractions of a cent
+func roundCost(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
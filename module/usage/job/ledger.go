@@ -0,0 +1,69 @@
+// Package job writes the AI usage ledger in the background
+package job
+
+import (
+	"context"
+	"hub-service/core/appctx"
+	"hub-service/infrastructure/external/llm"
+	"hub-service/module/usage/biz"
+	"hub-service/module/usage/storage"
+	"log"
+	"sync"
+)
+
+const ledgerBuffer = 1000
+
+type LedgerWriter struct {
+	appCtx  appctx.AppContext
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	running bool
+	mu      sync.Mutex
+}
+
+func NewLedgerWriter(appCtx appctx.AppContext) *LedgerWriter {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &LedgerWriter{
+		appCtx: appCtx,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start records every AI provider call made by this process from now on
+func (w *LedgerWriter) Start() {
+	w.mu.Lock()
+	if w.running {
+		w.mu.Unlock()
+		return
+	}
+	w.running = true
+	w.mu.Unlock()
+
+	ledger := biz.NewLedger(storage.NewStorage(w.appCtx.GetDatabase()), ledgerBuffer)
+	llm.SetUsageRecorder(ledger)
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		ledger.Run(w.ctx)
+	}()
+}
+
+// Stop stops recording and flushes the queued entries
+func (w *LedgerWriter) Stop() {
+	w.mu.Lock()
+	if !w.running {
+		w.mu.Unlock()
+		return
+	}
+	w.running = false
+	w.mu.Unlock()
+
+	llm.SetUsageRecorder(nil)
+	w.cancel()
+	w.wg.Wait()
+	log.Println("AI usage ledger stopped")
+}
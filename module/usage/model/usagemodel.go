@@ -0,0 +1,117 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	UsageCollectionName = "ai_usage"
+	PriceCollectionName = "ai_prices"
+)
+
+// Features that call the AI provider
+const (
+	FeatureChallenge  = "challenge"
+	FeatureSentence   = "sentence"
+	FeatureDemo       = "demo"
+	FeatureAppeal     = "appeal"
+	FeaturePlayground = "playground"
+	FeatureGeneration = "generation"
+	FeatureHint       = "hint"
+	FeatureRegrade    = "regrade"
+	// FeatureUnknown is recorded for calls made without a caller in their context
+	FeatureUnknown = "unknown"
+)
+
+// Report groupings
+const (
+	GroupByUser    = "user"
+	GroupByDay     = "day"
+	GroupByFeature = "feature"
+)
+
+// UsageRecord is one entry of the ledger: a single request sent to the AI provider.
+// Retries and repair prompts are separate entries.
+type UsageRecord struct {
+	ID           primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	UserID       *primitive.ObjectID `json:"user_id,omitempty" bson:"user_id,omitempty"`
+	Feature      string              `json:"feature" bson:"feature"`
+	Provider     string              `json:"provider" bson:"provider"`
+	Model        string              `json:"model" bson:"model"`
+	InputTokens  int                 `json:"input_tokens" bson:"input_tokens"`
+	OutputTokens int                 `json:"output_tokens" bson:"output_tokens"`
+	LatencyMs    int64               `json:"latency_ms" bson:"latency_ms"`
+	Success      bool                `json:"success" bson:"success"`
+	Error        string              `json:"error,omitempty" bson:"error,omitempty"`
+	CreatedAt    time.Time           `json:"created_at" bson:"created_at"`
+}
+
+// Price is what a model costs in USD per million tokens
+type Price struct {
+	ID               primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Model            string             `json:"model" bson:"model" example:"gemini-2.0-flash"`
+	InputPerMillion  float64            `json:"input_per_million" bson:"input_per_million" example:"0.1"`
+	OutputPerMillion float64            `json:"output_per_million" bson:"output_per_million" example:"0.4"`
+	UpdatedBy        primitive.ObjectID `json:"updated_by" bson:"updated_by"`
+	UpdatedAt        time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// SetPriceRequest creates or replaces the price of a model. A price applies to the exact model
+// name and to versions starting with it, e.g. gemini-2.0-flash also prices gemini-2.0-flash-001.
+type SetPriceRequest struct {
+	Model            string   `json:"model" binding:"required" example:"gemini-2.0-flash"`
+	InputPerMillion  *float64 `json:"input_per_million" binding:"required,min=0" example:"0.1"`
+	OutputPerMillion *float64 `json:"output_per_million" binding:"required,min=0" example:"0.4"`
+}
+
+// UsageQuery selects the calls to aggregate. Dates are UTC days; to is inclusive.
+type UsageQuery struct {
+	GroupBy string `form:"group_by" binding:"omitempty,oneof=user day feature" example:"day"`
+	From    string `form:"from" example:"2026-10-01"`
+	To      string `form:"to" example:"2026-10-17"`
+	Feature string `form:"feature" example:"challenge"`
+	UserID  string `form:"user_id"`
+}
+
+// UsageFilter is the parsed UsageQuery; To is exclusive
+type UsageFilter struct {
+	From    time.Time
+	To      time.Time
+	Feature string
+	UserID  *primitive.ObjectID
+}
+
+// UsageGroup is the usage of one model within one report row, as aggregated by MongoDB
+type UsageGroup struct {
+	Key          string `bson:"key"`
+	Model        string `bson:"model"`
+	Calls        int    `bson:"calls"`
+	FailedCalls  int    `bson:"failed_calls"`
+	InputTokens  int64  `bson:"input_tokens"`
+	OutputTokens int64  `bson:"output_tokens"`
+	LatencyMs    int64  `bson:"latency_ms"`
+}
+
+// UsageRow is the usage and cost of one user, day or feature
+type UsageRow struct {
+	Key          string  `json:"key" example:"2026-10-17"`
+	Calls        int     `json:"calls"`
+	FailedCalls  int     `json:"failed_calls"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	AvgLatencyMs int64   `json:"avg_latency_ms"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// UsageReport aggregates the ledger. Costs use the current price table; calls to models
+// without a price count as free and are listed in UnpricedModels.
+type UsageReport struct {
+	GroupBy        string     `json:"group_by"`
+	From           time.Time  `json:"from"`
+	To             time.Time  `json:"to"`
+	Rows           []UsageRow `json:"rows"`
+	Total          UsageRow   `json:"total"`
+	UnpricedModels []string   `json:"unpriced_models"`
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/usage/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Storage) InsertUsage(ctx context.Context, record *model.UsageRecord) error {
+	collection := s.db.MongoDB.GetCollection(model.UsageCollectionName)
+
+	_, err := collection.InsertOne(ctx, record)
+	return err
+}
+
+// groupKeys is the report row key of a ledger entry for each grouping
+var groupKeys = map[string]interface{}{
+	model.GroupByUser:    bson.M{"$ifNull": []interface{}{bson.M{"$toString": "$user_id"}, "anonymous"}},
+	model.GroupByDay:     bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$created_at"}},
+	model.GroupByFeature: "$feature",
+}
+
+// AggregateUsage sums the calls matching filter per report row and model
+func (s *Storage) AggregateUsage(ctx context.Context, groupBy string, filter *model.UsageFilter) ([]model.UsageGroup, error) {
+	collection := s.db.MongoDB.GetCollection(model.UsageCollectionName)
+
+	match := bson.M{"created_at": bson.M{"$gte": filter.From, "$lt": filter.To}}
+	if filter.Feature != "" {
+		match["feature"] = filter.Feature
+	}
+	if filter.UserID != nil {
+		match["user_id"] = *filter.UserID
+	}
+
+	pipeline := []bson.M{
+		{"$match": match},
+		{"$group": bson.M{
+			"_id": bson.M{
+				"key":   groupKeys[groupBy],
+				"model": "$model",
+			},
+			"calls":         bson.M{"$sum": 1},
+			"failed_calls":  bson.M{"$sum": bson.M{"$cond": []interface{}{"$success", 0, 1}}},
+			"input_tokens":  bson.M{"$sum": "$input_tokens"},
+			"output_tokens": bson.M{"$sum": "$output_tokens"},
+			"latency_ms":    bson.M{"$sum": "$latency_ms"},
+		}},
+		{"$project": bson.M{
+			"_id":           0,
+			"key":           "$_id.key",
+			"model":         "$_id.model",
+			"calls":         1,
+			"failed_calls":  1,
+			"input_tokens":  1,
+			"output_tokens": 1,
+			"latency_ms":    1,
+		}},
+		{"$sort": bson.M{"key": 1}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	groups := []model.UsageGroup{}
+	if err = cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"hub-service/module/usage/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Storage) ListPrices(ctx context.Context) ([]model.Price, error) {
+	collection := s.db.MongoDB.GetCollection(model.PriceCollectionName)
+
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"model": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	prices := []model.Price{}
+	if err = cursor.All(ctx, &prices); err != nil {
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+// SetPrice creates or replaces the price of data.Model
+func (s *Storage) SetPrice(ctx context.Context, data *model.Price) error {
+	collection := s.db.MongoDB.GetCollection(model.PriceCollectionName)
+
+	return collection.FindOneAndUpdate(ctx,
+		bson.M{"model": data.Model},
+		bson.M{"$set": bson.M{
+			"input_per_million":  data.InputPerMillion,
+			"output_per_million": data.OutputPerMillion,
+			"updated_by":         data.UpdatedBy,
+			"updated_at":         data.UpdatedAt,
+		}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(data)
+}
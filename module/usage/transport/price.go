@@ -0,0 +1,67 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/module/usage/biz"
+	"hub-service/module/usage/model"
+	"hub-service/module/usage/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ListPrices godoc
+// @Summary List AI model prices
+// @Description The price table used to compute AI costs, in USD per million tokens. Admin only.
+// @Tags usage
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} common.Response{data=[]model.Price} "Success"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Router /api/usage/prices [get]
+func ListPrices(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		business := biz.NewManagePricesBiz(storage.NewStorage(appCtx.GetDatabase()))
+		result, err := business.ListPrices(c.Request.Context())
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
+
+// SetPrice godoc
+// @Summary Set an AI model price
+// @Description Create or replace the price of a model in USD per million tokens. The price also applies to model versions starting with the name, e.g. gemini-2.0-flash prices gemini-2.0-flash-001. Reports always use the current price. Admin only.
+// @Tags usage
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.SetPriceRequest true "Model price"
+// @Success 200 {object} common.Response{data=model.Price} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Router /api/usage/prices [put]
+func SetPrice(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req model.SetPriceRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		adminID := c.MustGet("user_id").(primitive.ObjectID)
+
+		business := biz.NewManagePricesBiz(storage.NewStorage(appCtx.GetDatabase()))
+		result, err := business.SetPrice(c.Request.Context(), &req, adminID)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
@@ -0,0 +1,45 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/module/usage/biz"
+	"hub-service/module/usage/model"
+	"hub-service/module/usage/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetUsageReport godoc
+// @Summary Get AI usage and cost
+// @Description Aggregate every AI provider call (tokens, latency, failures) per user, UTC day or feature and price it with the current price table. Calls to models without a price count as free and are listed in unpriced_models. Admin only.
+// @Tags usage
+// @Produce json
+// @Security BearerAuth
+// @Param group_by query string false "user, day or feature" default(day)
+// @Param from query string false "First day, e.g. 2026-10-01 (defaults to 30 days before to)"
+// @Param to query string false "Last day, inclusive (defaults to today, UTC)"
+// @Param feature query string false "Only calls of this feature: challenge, sentence, demo, appeal, playground, generation, hint or unknown"
+// @Param user_id query string false "Only calls made for this user"
+// @Success 200 {object} common.Response{data=model.UsageReport} "Success"
+// @Failure 400 {object} common.AppError "Bad request"
+// @Failure 401 {object} common.AppError "Unauthorized"
+// @Failure 403 {object} common.AppError "Forbidden"
+// @Router /api/usage/report [get]
+func GetUsageReport(appCtx appctx.AppContext) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query model.UsageQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			panic(common.ErrInvalidRequest(err))
+		}
+
+		business := biz.NewUsageReportBiz(storage.NewStorage(appCtx.GetDatabase()))
+		result, err := business.GetReport(c.Request.Context(), &query)
+		if err != nil {
+			panic(err)
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
@@ -0,0 +1,19 @@
+package transport
+
+import (
+	"hub-service/common"
+	"hub-service/core/appctx"
+	"hub-service/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(g *gin.RouterGroup, appCtx appctx.AppContext) {
+	usage := g.Group("/usage")
+	usage.Use(auth.AuthMiddleware(appCtx), auth.RequireRoles(common.RoleAdmin, common.RoleSuperAdmin))
+	{
+		usage.GET("/report", GetUsageReport(appCtx))
+		usage.GET("/prices", ListPrices(appCtx))
+		usage.PUT("/prices", SetPrice(appCtx))
+	}
+}
@@ -12,6 +12,7 @@ import (
 	emailmodel "hub-service/module/email/model"
 	"hub-service/module/email/repository"
 	"hub-service/module/email/templates"
+	scorebiz "hub-service/module/score/biz"
 	scoremodel "hub-service/module/score/model"
 	scorestorage "hub-service/module/score/storage"
 	"hub-service/module/user/model"
@@ -62,16 +63,17 @@ func (biz *UserBiz) CreateUser(ctx context.Context, userCreate *model.UserCreate
 	}
 
 	return &model.UserResponse{
-		ID:         user.ID,
-		Email:      user.Email,
-		Name:       user.Name,
-		Avatar:     user.Avatar,
-		Phone:      user.Phone,
-		Bio:        user.Bio,
-		Role:       user.Role,
-		TotalScore: 0, // New user has no score yet
-		CreatedAt:  user.CreatedAt,
-		UpdatedAt:  user.UpdatedAt,
+		ID:               user.ID,
+		Email:            user.Email,
+		Name:             user.Name,
+		Avatar:           user.Avatar,
+		Phone:            user.Phone,
+		Bio:              user.Bio,
+		Role:             user.Role,
+		FeedbackLanguage: user.FeedbackLanguage,
+		TotalScore:       0, // New user has no score yet
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
 	}, nil
 }
 
@@ -111,16 +113,17 @@ func (biz *UserBiz) Login(ctx context.Context, email, password string) (*model.L
 		AccessToken:  token.AccessToken,
 		RefreshToken: token.RefreshToken,
 		User: model.UserResponse{
-			ID:         user.ID,
-			Email:      user.Email,
-			Name:       user.Name,
-			Avatar:     user.Avatar,
-			Phone:      user.Phone,
-			Bio:        user.Bio,
-			Role:       user.Role,
-			TotalScore: 0, // Will be updated if needed
-			CreatedAt:  user.CreatedAt,
-			UpdatedAt:  user.UpdatedAt,
+			ID:               user.ID,
+			Email:            user.Email,
+			Name:             user.Name,
+			Avatar:           user.Avatar,
+			Phone:            user.Phone,
+			Bio:              user.Bio,
+			Role:             user.Role,
+			FeedbackLanguage: user.FeedbackLanguage,
+			TotalScore:       0, // Will be updated if needed
+			CreatedAt:        user.CreatedAt,
+			UpdatedAt:        user.UpdatedAt,
 		},
 	}
 	return loginResponse, nil
@@ -155,16 +158,17 @@ func (biz *UserBiz) RefreshToken(ctx context.Context, refreshToken string) (*mod
 		AccessToken:  newAccessToken,
 		RefreshToken: refreshToken, // Keep the old refresh token
 		User: model.UserResponse{
-			ID:         user.ID,
-			Email:      user.Email,
-			Name:       user.Name,
-			Avatar:     user.Avatar,
-			Phone:      user.Phone,
-			Bio:        user.Bio,
-			Role:       user.Role,
-			TotalScore: 0, // Will be updated if needed
-			CreatedAt:  user.CreatedAt,
-			UpdatedAt:  user.UpdatedAt,
+			ID:               user.ID,
+			Email:            user.Email,
+			Name:             user.Name,
+			Avatar:           user.Avatar,
+			Phone:            user.Phone,
+			Bio:              user.Bio,
+			Role:             user.Role,
+			FeedbackLanguage: user.FeedbackLanguage,
+			TotalScore:       0, // Will be updated if needed
+			CreatedAt:        user.CreatedAt,
+			UpdatedAt:        user.UpdatedAt,
 		},
 	}
 
@@ -191,20 +195,29 @@ func (biz *UserBiz) GetUserByID(ctx context.Context, id primitive.ObjectID) (*mo
 	}
 
 	return &model.UserResponse{
-		ID:         user.ID,
-		Email:      user.Email,
-		Name:       user.Name,
-		Avatar:     user.Avatar,
-		Phone:      user.Phone,
-		Bio:        user.Bio,
-		Role:       user.Role,
-		TotalScore: scoreSummary.TotalScore,
-		CreatedAt:  user.CreatedAt,
-		UpdatedAt:  user.UpdatedAt,
+		ID:               user.ID,
+		Email:            user.Email,
+		Name:             user.Name,
+		Avatar:           user.Avatar,
+		Phone:            user.Phone,
+		Bio:              user.Bio,
+		Role:             user.Role,
+		FeedbackLanguage: user.FeedbackLanguage,
+		TotalScore:       scoreSummary.TotalScore,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
 	}, nil
 }
 
 func (biz *UserBiz) UpdateUser(ctx context.Context, id primitive.ObjectID, userUpdate *model.UserUpdate) (*model.UserResponse, error) {
+	if userUpdate.FeedbackLanguage != nil && *userUpdate.FeedbackLanguage != "" {
+		language := scorebiz.NormalizeLanguage(*userUpdate.FeedbackLanguage)
+		if language == "" {
+			return nil, scorebiz.ErrUnsupportedLanguage
+		}
+		userUpdate.FeedbackLanguage = &language
+	}
+
 	user, err := biz.store.Update(ctx, id, userUpdate)
 	if err != nil {
 		return nil, err
@@ -221,16 +234,17 @@ func (biz *UserBiz) UpdateUser(ctx context.Context, id primitive.ObjectID, userU
 	}
 
 	return &model.UserResponse{
-		ID:         user.ID,
-		Email:      user.Email,
-		Name:       user.Name,
-		Avatar:     user.Avatar,
-		Phone:      user.Phone,
-		Bio:        user.Bio,
-		Role:       user.Role,
-		TotalScore: scoreSummary.TotalScore,
-		CreatedAt:  user.CreatedAt,
-		UpdatedAt:  user.UpdatedAt,
+		ID:               user.ID,
+		Email:            user.Email,
+		Name:             user.Name,
+		Avatar:           user.Avatar,
+		Phone:            user.Phone,
+		Bio:              user.Bio,
+		Role:             user.Role,
+		FeedbackLanguage: user.FeedbackLanguage,
+		TotalScore:       scoreSummary.TotalScore,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
 	}, nil
 }
 
@@ -264,16 +278,17 @@ func (biz *UserBiz) ListUsers(ctx context.Context, page, limit int64, sortBy, so
 		}
 
 		responses = append(responses, model.UserResponse{
-			ID:         user.ID,
-			Email:      user.Email,
-			Name:       user.Name,
-			Avatar:     user.Avatar,
-			Phone:      user.Phone,
-			Bio:        user.Bio,
-			Role:       user.Role,
-			TotalScore: scoreSummary.TotalScore,
-			CreatedAt:  user.CreatedAt,
-			UpdatedAt:  user.UpdatedAt,
+			ID:               user.ID,
+			Email:            user.Email,
+			Name:             user.Name,
+			Avatar:           user.Avatar,
+			Phone:            user.Phone,
+			Bio:              user.Bio,
+			Role:             user.Role,
+			FeedbackLanguage: user.FeedbackLanguage,
+			TotalScore:       scoreSummary.TotalScore,
+			CreatedAt:        user.CreatedAt,
+			UpdatedAt:        user.UpdatedAt,
 		})
 	}
 
@@ -408,16 +423,17 @@ func (biz *UserBiz) SocialLogin(ctx context.Context, req *model.SocialLoginReque
 		AccessToken:  token.AccessToken,
 		RefreshToken: token.RefreshToken,
 		User: model.UserResponse{
-			ID:         user.ID,
-			Email:      user.Email,
-			Name:       user.Name,
-			Avatar:     user.Avatar,
-			Phone:      user.Phone,
-			Bio:        user.Bio,
-			Role:       user.Role,
-			TotalScore: 0, // New user or will be updated if needed
-			CreatedAt:  user.CreatedAt,
-			UpdatedAt:  user.UpdatedAt,
+			ID:               user.ID,
+			Email:            user.Email,
+			Name:             user.Name,
+			Avatar:           user.Avatar,
+			Phone:            user.Phone,
+			Bio:              user.Bio,
+			Role:             user.Role,
+			FeedbackLanguage: user.FeedbackLanguage,
+			TotalScore:       0, // New user or will be updated if needed
+			CreatedAt:        user.CreatedAt,
+			UpdatedAt:        user.UpdatedAt,
 		},
 	}
 	return loginResponse, nil
@@ -540,16 +556,17 @@ func (biz *UserBiz) SocialLoginOAuth(ctx context.Context, req *model.SocialLogin
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		User: model.UserResponse{
-			ID:         user.ID,
-			Email:      user.Email,
-			Name:       user.Name,
-			Avatar:     user.Avatar,
-			Phone:      user.Phone,
-			Bio:        user.Bio,
-			Role:       user.Role,
-			TotalScore: 0,
-			CreatedAt:  user.CreatedAt,
-			UpdatedAt:  user.UpdatedAt,
+			ID:               user.ID,
+			Email:            user.Email,
+			Name:             user.Name,
+			Avatar:           user.Avatar,
+			Phone:            user.Phone,
+			Bio:              user.Bio,
+			Role:             user.Role,
+			FeedbackLanguage: user.FeedbackLanguage,
+			TotalScore:       0,
+			CreatedAt:        user.CreatedAt,
+			UpdatedAt:        user.UpdatedAt,
 		},
 	}
 	return loginResponse, nil
@@ -1,25 +1,28 @@
 package model
 
 import (
+	achievementmodel "hub-service/module/achievement/model"
+	streakmodel "hub-service/module/streak/model"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type User struct {
-	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	Email        string             `bson:"email" json:"email"`
-	Name         string             `bson:"name" json:"name"`
-	Avatar       string             `bson:"avatar,omitempty" json:"avatar,omitempty"`
-	Phone        string             `bson:"phone,omitempty" json:"phone,omitempty"`
-	Bio          string             `bson:"bio,omitempty" json:"bio,omitempty"`
-	Role         string             `bson:"role" json:"role"`
-	Provider     string             `bson:"provider,omitempty" json:"provider,omitempty"`
-	ProviderID   string             `bson:"provider_id,omitempty" json:"provider_id,omitempty"`
-	IsFirstLogin bool               `bson:"is_first_login" json:"is_first_login"`
-	LastLoginAt  *time.Time         `bson:"last_login_at,omitempty" json:"last_login_at,omitempty"`
-	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
+	ID               primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Email            string             `bson:"email" json:"email"`
+	Name             string             `bson:"name" json:"name"`
+	Avatar           string             `bson:"avatar,omitempty" json:"avatar,omitempty"`
+	Phone            string             `bson:"phone,omitempty" json:"phone,omitempty"`
+	Bio              string             `bson:"bio,omitempty" json:"bio,omitempty"`
+	Role             string             `bson:"role" json:"role"`
+	FeedbackLanguage string             `bson:"feedback_language,omitempty" json:"feedback_language,omitempty"`
+	Provider         string             `bson:"provider,omitempty" json:"provider,omitempty"`
+	ProviderID       string             `bson:"provider_id,omitempty" json:"provider_id,omitempty"`
+	IsFirstLogin     bool               `bson:"is_first_login" json:"is_first_login"`
+	LastLoginAt      *time.Time         `bson:"last_login_at,omitempty" json:"last_login_at,omitempty"`
+	CreatedAt        time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt        time.Time          `bson:"updated_at" json:"updated_at"`
 }
 
 type UserCreate struct {
@@ -36,23 +39,27 @@ type UserCreate struct {
 }
 
 type UserUpdate struct {
-	Name   string `json:"name,omitempty"`
-	Avatar string `json:"avatar,omitempty"`
-	Phone  string `json:"phone,omitempty"`
-	Bio    string `json:"bio,omitempty"`
+	Name             string  `json:"name,omitempty"`
+	Avatar           string  `json:"avatar,omitempty"`
+	Phone            string  `json:"phone,omitempty"`
+	Bio              string  `json:"bio,omitempty"`
+	FeedbackLanguage *string `json:"feedback_language,omitempty" example:"vi"` // Omit to keep, "" to clear
 }
 
 type UserResponse struct {
-	ID         primitive.ObjectID `json:"id"`
-	Email      string             `json:"email"`
-	Name       string             `json:"name"`
-	Avatar     string             `json:"avatar,omitempty"`
-	Phone      string             `json:"phone,omitempty"`
-	Bio        string             `json:"bio,omitempty"`
-	Role       string             `json:"role"`
-	TotalScore float64            `json:"total_score" example:"95.5"` // Total score from all challenges
-	CreatedAt  time.Time          `json:"created_at"`
-	UpdatedAt  time.Time          `json:"updated_at"`
+	ID               primitive.ObjectID              `json:"id"`
+	Email            string                          `json:"email"`
+	Name             string                          `json:"name"`
+	Avatar           string                          `json:"avatar,omitempty"`
+	Phone            string                          `json:"phone,omitempty"`
+	Bio              string                          `json:"bio,omitempty"`
+	Role             string                          `json:"role"`
+	FeedbackLanguage string                          `json:"feedback_language,omitempty"`
+	TotalScore       float64                         `json:"total_score" example:"95.5"` // Total score from all challenges
+	Streak           *streakmodel.StreakSummary      `json:"streak,omitempty"`           // Daily goal and streak, only on /users/me
+	Badges           []achievementmodel.AwardedBadge `json:"badges,omitempty"`           // Earned badges, only on profile endpoints
+	CreatedAt        time.Time                       `json:"created_at"`
+	UpdatedAt        time.Time                       `json:"updated_at"`
 }
 
 type LoginResponse struct {
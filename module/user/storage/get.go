@@ -38,3 +38,21 @@ func (s *UserStorage) GetByEmail(ctx context.Context, email string) (*model.User
 
 	return &user, nil
 }
+
+// GetByIDs loads several users at once; missing IDs are skipped
+func (s *UserStorage) GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]model.User, error) {
+	collection := s.appCtx.GetDatabase().MongoDB.GetCollection("users")
+
+	cursor, err := collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	users := []model.User{}
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
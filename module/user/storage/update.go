@@ -31,7 +31,17 @@ func (s *UserStorage) Update(ctx context.Context, id primitive.ObjectID, userUpd
 		updateData["bio"] = userUpdate.Bio
 	}
 
-	if len(updateData) == 0 {
+	// An empty feedback language clears the preference
+	unsetData := bson.M{}
+	if userUpdate.FeedbackLanguage != nil {
+		if *userUpdate.FeedbackLanguage == "" {
+			unsetData["feedback_language"] = ""
+		} else {
+			updateData["feedback_language"] = *userUpdate.FeedbackLanguage
+		}
+	}
+
+	if len(updateData) == 0 && len(unsetData) == 0 {
 		return s.GetByID(ctx, id)
 	}
 
@@ -40,6 +50,9 @@ func (s *UserStorage) Update(ctx context.Context, id primitive.ObjectID, userUpd
 	update := bson.M{
 		"$set": updateData,
 	}
+	if len(unsetData) > 0 {
+		update["$unset"] = unsetData
+	}
 
 	result := collection.FindOneAndUpdate(
 		ctx,
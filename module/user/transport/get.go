@@ -2,6 +2,8 @@ package transport
 
 import (
 	"hub-service/core/appctx"
+	achievementbiz "hub-service/module/achievement/biz"
+	achievementstorage "hub-service/module/achievement/storage"
 	"hub-service/module/user/biz"
 	"hub-service/module/user/model"
 	"net/http"
@@ -13,7 +15,7 @@ import (
 
 // GetUserByID godoc
 // @Summary Get user by ID
-// @Description Get user information by user ID
+// @Description Get user information by user ID, including the badges they earned
 // @Tags users
 // @Accept json
 // @Produce json
@@ -40,6 +42,12 @@ func GetUserByID(appCtx appctx.AppContext) gin.HandlerFunc {
 			return
 		}
 
+		// Badges are extra information, so the profile is still returned without them
+		badgeBiz := achievementbiz.NewListUserBadgesBiz(achievementstorage.NewStorage(appCtx.GetDatabase()))
+		if badges, err := badgeBiz.ListUserBadges(c.Request.Context(), objectID); err == nil {
+			user.Badges = badges
+		}
+
 		c.JSON(http.StatusOK, model.GetUserResponse{Status: "success", Data: *user})
 	}
 }
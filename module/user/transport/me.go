@@ -2,6 +2,10 @@ package transport
 
 import (
 	"hub-service/core/appctx"
+	achievementbiz "hub-service/module/achievement/biz"
+	achievementstorage "hub-service/module/achievement/storage"
+	streakbiz "hub-service/module/streak/biz"
+	streakstorage "hub-service/module/streak/storage"
 	"hub-service/module/user/biz"
 	"hub-service/module/user/model"
 	"net/http"
@@ -16,7 +20,7 @@ import (
 
 // GetMe godoc
 // @Summary Get current user profile
-// @Description Get current user's profile information using access token
+// @Description Get current user's profile information using access token, including their daily goal, streak and badges
 // @Tags users
 // @Accept json
 // @Produce json
@@ -46,6 +50,18 @@ func GetMe(appCtx appctx.AppContext) gin.HandlerFunc {
 			return
 		}
 
+		// The streak is extra information, so the profile is still returned without it
+		streakBiz := streakbiz.NewGetStreakBiz(streakstorage.NewStorage(appCtx.GetDatabase()))
+		if streak, err := streakBiz.GetStreak(c.Request.Context(), objectID); err == nil {
+			user.Streak = streak
+		}
+
+		// Badges are extra information, so the profile is still returned without them
+		badgeBiz := achievementbiz.NewListUserBadgesBiz(achievementstorage.NewStorage(appCtx.GetDatabase()))
+		if badges, err := badgeBiz.ListUserBadges(c.Request.Context(), objectID); err == nil {
+			user.Badges = badges
+		}
+
 		c.JSON(http.StatusOK, model.GetUserResponse{Status: "success", Data: *user})
 	}
 }
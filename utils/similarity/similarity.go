@@ -0,0 +1,194 @@
+// Package similarity scores a candidate translation against reference translations
+// with a character n-gram F-score (chrF) and a smoothed sentence-level BLEU.
+// It needs no external service, so it can grade when the LLM cannot.
+package similarity
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	chrFOrder = 6
+	chrFBeta  = 2.0
+	bleuOrder = 4
+
+	// chrF tracks human judgement better on short sentences, BLEU rewards word order
+	chrFWeight = 0.7
+	bleuWeight = 0.3
+)
+
+// Result is the best match of a candidate among its references. Scores range from 0 to 100.
+type Result struct {
+	ChrF       float64 `json:"chrf"`
+	BLEU       float64 `json:"bleu"`
+	Score      float64 `json:"score"`
+	ExactMatch bool    `json:"exact_match"`
+	Reference  string  `json:"-"`
+}
+
+// Compare scores candidate against every reference and keeps the closest one.
+// It returns nil when there are no non-empty references.
+func Compare(candidate string, references []string) *Result {
+	normalized := Normalize(candidate)
+
+	var best *Result
+	for _, reference := range references {
+		ref := Normalize(reference)
+		if ref == "" {
+			continue
+		}
+
+		result := &Result{Reference: reference}
+		if ref == normalized {
+			result.ChrF, result.BLEU, result.Score, result.ExactMatch = 100, 100, 100, true
+			return result
+		}
+
+		result.ChrF = round(chrF(normalized, ref))
+		result.BLEU = round(bleu(normalized, ref))
+		result.Score = round(chrFWeight*result.ChrF + bleuWeight*result.BLEU)
+		if best == nil || result.Score > best.Score {
+			best = result
+		}
+	}
+	return best
+}
+
+// Normalize lowercases text, drops apostrophes inside words ("don't" → "dont"),
+// turns other punctuation into spaces and collapses whitespace
+func Normalize(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	for _, r := range text {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.Is(unicode.Mn, r):
+			b.WriteRune(unicode.ToLower(r))
+		case r == '\'' || r == '’' || r == '‘':
+			// skip
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// chrF averages character n-gram precision and recall over orders 1-6 (spaces ignored)
+// and combines them with an F-beta score weighting recall twice as much as precision
+func chrF(candidate, reference string) float64 {
+	cand := []rune(strings.ReplaceAll(candidate, " ", ""))
+	ref := []rune(strings.ReplaceAll(reference, " ", ""))
+	if len(cand) == 0 || len(ref) == 0 {
+		return 0
+	}
+
+	var precisionSum, recallSum float64
+	orders := 0
+	for n := 1; n <= chrFOrder; n++ {
+		candGrams := charNGrams(cand, n)
+		refGrams := charNGrams(ref, n)
+		candTotal, refTotal := total(candGrams), total(refGrams)
+		if candTotal == 0 || refTotal == 0 {
+			break
+		}
+
+		matches := overlap(candGrams, refGrams)
+		precisionSum += float64(matches) / float64(candTotal)
+		recallSum += float64(matches) / float64(refTotal)
+		orders++
+	}
+	if orders == 0 {
+		return 0
+	}
+
+	precision := precisionSum / float64(orders)
+	recall := recallSum / float64(orders)
+	if precision == 0 && recall == 0 {
+		return 0
+	}
+
+	beta2 := chrFBeta * chrFBeta
+	return 100 * (1 + beta2) * precision * recall / (beta2*precision + recall)
+}
+
+// bleu is sentence BLEU with add-one smoothing on orders above 1 and the usual brevity penalty.
+// Orders longer than the candidate are skipped so short answers are not rewarded by smoothing.
+func bleu(candidate, reference string) float64 {
+	cand := strings.Fields(candidate)
+	ref := strings.Fields(reference)
+	if len(cand) == 0 || len(ref) == 0 {
+		return 0
+	}
+
+	maxOrder := bleuOrder
+	if len(cand) < maxOrder {
+		maxOrder = len(cand)
+	}
+
+	var logSum float64
+	for n := 1; n <= maxOrder; n++ {
+		candGrams := wordNGrams(cand, n)
+		matches := overlap(candGrams, wordNGrams(ref, n))
+		candTotal := total(candGrams)
+
+		var precision float64
+		if n == 1 {
+			if matches == 0 {
+				return 0
+			}
+			precision = float64(matches) / float64(candTotal)
+		} else {
+			precision = float64(matches+1) / float64(candTotal+1)
+		}
+		logSum += math.Log(precision)
+	}
+
+	brevity := 1.0
+	if len(cand) < len(ref) {
+		brevity = math.Exp(1 - float64(len(ref))/float64(len(cand)))
+	}
+
+	return 100 * brevity * math.Exp(logSum/float64(maxOrder))
+}
+
+func charNGrams(runes []rune, n int) map[string]int {
+	grams := map[string]int{}
+	for i := 0; i+n <= len(runes); i++ {
+		grams[string(runes[i:i+n])]++
+	}
+	return grams
+}
+
+func wordNGrams(words []string, n int) map[string]int {
+	grams := map[string]int{}
+	for i := 0; i+n <= len(words); i++ {
+		grams[strings.Join(words[i:i+n], " ")]++
+	}
+	return grams
+}
+
+func overlap(candidate, reference map[string]int) int {
+	matches := 0
+	for gram, count := range candidate {
+		if refCount, ok := reference[gram]; ok {
+			if refCount < count {
+				count = refCount
+			}
+			matches += count
+		}
+	}
+	return matches
+}
+
+func total(grams map[string]int) int {
+	sum := 0
+	for _, count := range grams {
+		sum += count
+	}
+	return sum
+}
+
+func round(score float64) float64 {
+	return math.Round(score*10) / 10
+}